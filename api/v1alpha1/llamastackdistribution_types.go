@@ -21,10 +21,13 @@ package v1alpha1
 
 //nolint:gci
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -44,9 +47,15 @@ const (
 	LlamaStackDistributionKind = "LlamaStackDistribution"
 )
 
+// DefaultServiceAccountTokenMountPath is the default directory of the projected ServiceAccount token
+const DefaultServiceAccountTokenMountPath = "/var/run/secrets/tokens"
+
 // DefaultStorageSize is the default size for persistent storage
 var DefaultStorageSize = resource.MustParse("10Gi")
 
+// DefaultStorageAccessMode is the default access mode of the persistent storage
+const DefaultStorageAccessMode = corev1.ReadWriteOnce
+
 // DistributionType defines the distribution configuration for llama-stack.
 // +kubebuilder:validation:XValidation:rule="!(has(self.name) && has(self.image))",message="Only one of name or image can be specified"
 type DistributionType struct {
@@ -56,6 +65,11 @@ type DistributionType struct {
 	// Image is the direct container image reference to use
 	// +optional
 	Image string `json:"image,omitempty"`
+	// UseDigest pins the server image to the digest its tag points to when the image is first deployed, so that
+	// the pods keep running the same image when the tag moves. The operator emits an Event when the tag moves to
+	// a new digest. Images referenced by digest are used as is
+	// +optional
+	UseDigest bool `json:"useDigest,omitempty"`
 }
 
 // HealthStatus represents the health status of a provider
@@ -66,12 +80,66 @@ type ProviderHealthStatus struct {
 
 // LlamaStackDistributionSpec defines the desired state of LlamaStackDistribution.
 type LlamaStackDistributionSpec struct {
-	// +kubebuilder:default:=1
-	Replicas int32      `json:"replicas,omitempty"`
+	// Replicas is the number of server replicas. When unset, the distribution's catalog
+	// default is used, falling back to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32     `json:"replicas,omitempty"`
 	Server   ServerSpec `json:"server"`
+	// MaintenanceWindow restricts the changes that restart the server pods, such as image or pod template
+	// updates, to a recurring time window. Outside of it the changes are deferred until the window opens,
+	// while status and non-disruptive changes such as scaling keep being reconciled.
+	// Changes are applied at any time when unset
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+	// Labels are added to every resource created for the distribution, including the server pods, e.g.
+	// cost-allocation labels. They can't override the labels set by the operator
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are added to every resource created for the distribution, including the server pods, e.g.
+	// service mesh annotations. They can't override the annotations set by the operator, and the annotations
+	// of a specific resource, such as the ingress annotations, take precedence over them
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// MaintenanceWindowSpec defines a recurring time window during which the server pods may be restarted
+type MaintenanceWindowSpec struct {
+	// Days are the days of the week on which the window opens. Defaults to every day
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=7
+	Days []MaintenanceDay `json:"days,omitempty"`
+	// Start is the time of day at which the window opens, as HH:MM in the window time zone
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration is how long the window stays open, at most 24h
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone of Start, e.g. Europe/Paris. Defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// DeploymentStrategySpec defines how the server pods are replaced on updates
+// +kubebuilder:validation:XValidation:rule="!has(self.rollingUpdate) || self.type == 'RollingUpdate'",message="rollingUpdate requires the RollingUpdate type"
+type DeploymentStrategySpec struct {
+	// Type is the type of the strategy, RollingUpdate or Recreate
+	// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+	// +kubebuilder:default=RollingUpdate
+	// +optional
+	Type appsv1.DeploymentStrategyType `json:"type,omitempty"`
+	// RollingUpdate sets how many pods may be added above, and removed below, the desired replicas during
+	// a rolling update. Both default to 25%
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
 }
 
+// MaintenanceDay is a day of the week
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type MaintenanceDay string
+
 // ServerSpec defines the desired state of llama server.
+// +kubebuilder:validation:XValidation:rule="!(has(self.service) && has(self.serviceOverride))",message="service and serviceOverride are mutually exclusive"
 type ServerSpec struct {
 	Distribution  DistributionType `json:"distribution"`
 	ContainerSpec ContainerSpec    `json:"containerSpec,omitempty"`
@@ -79,20 +147,427 @@ type ServerSpec struct {
 	// Storage defines the persistent storage configuration
 	// +optional
 	Storage *StorageSpec `json:"storage,omitempty"`
+	// SharedMemory replaces the 64Mi /dev/shm of the server container with a memory-backed volume, e.g. for
+	// PyTorch-based distributions crashing with "bus error". The volume counts toward the memory of the container
+	// +optional
+	SharedMemory *SharedMemorySpec `json:"sharedMemory,omitempty"`
+	// InitContainers run in order before the server container starts, e.g. to pre-download model weights into
+	// the storage. They run after the init containers of the operator, and mount the storage at its mount path
+	// with HF_HOME pointing to it unless they mount it or set HF_HOME themselves. The startup probe of the server
+	// only starts once they complete
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// SidecarContainers run next to the server container, e.g. to ship its logs or metrics. They can mount the
+	// volumes of the pod, including the podOverrides volumes. The readiness of the distribution only depends on
+	// the server container and the pod readiness gates, though a failing readiness probe of a sidecar still removes the pod from the Service
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 	// UserConfig defines the user configuration for the llama-stack server
 	// +optional
 	UserConfig *UserConfigSpec `json:"userConfig,omitempty"`
 	// TLSConfig defines the TLS configuration for the llama-stack server
 	// +optional
 	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+	// HealthCheck configures how the operator probes the llama-stack server health endpoint
+	// +optional
+	HealthCheck *HealthCheckSpec `json:"healthCheck,omitempty"`
+	// APIAuth configures the credentials the operator sends with its health, providers, models and version
+	// requests, for servers requiring authentication on their API
+	// +optional
+	APIAuth *APIAuthSpec `json:"apiAuth,omitempty"`
+	// SchedulerName is the name of the scheduler that places the server pods.
+	// Defaults to the cluster default scheduler when unset.
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// ServiceAccountToken mounts a projected ServiceAccount token with a dedicated audience into the
+	// server container, e.g. for workload identity federation with external services
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenSpec `json:"serviceAccountToken,omitempty"`
+	// RollbackPolicy controls what happens when a rollout exceeds the Deployment progress deadline.
+	// Auto restores the last pod template that completed a rollout until the spec changes again,
+	// None leaves the failed rollout in place. Defaults to None
+	// +optional
+	RollbackPolicy RollbackPolicy `json:"rollbackPolicy,omitempty"`
+	// DeploymentStrategy is the strategy replacing the server pods on updates. Recreate stops the old pods
+	// before starting the new ones, e.g. for single-replica GPU distributions that can't load the model twice.
+	// Defaults to RollingUpdate
+	// +optional
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
+	// TerminationGracePeriodSeconds is the time the server pods have to shut down, e.g. to finish in-flight
+	// inference requests, before they are killed. It includes the time spent in the preStop hook. Defaults to 30
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// PreStop is the hook run in the server container before it is stopped, e.g. to drain its connections
+	// during rollouts and node drains
+	// +optional
+	PreStop *corev1.LifecycleHandler `json:"preStop,omitempty"`
+	// FeatureGates enables or disables experimental server features by name. They are passed to the server
+	// in the LLAMA_STACK_FEATURE_GATES environment variable as a comma-separated list of name=bool pairs,
+	// and validated by the server. Changing them rolls out the server pods
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// ReportPodTemplate records a summary of the pod template built by the operator in status.podTemplate,
+	// to check that overrides took effect without inspecting the Deployment
+	// +optional
+	ReportPodTemplate bool `json:"reportPodTemplate,omitempty"`
+	// PreStartJob runs a Job to completion before each rollout of the server pods, e.g. to migrate the
+	// schema of a backing database. The rollout holds while the Job runs, and when it fails
+	// +optional
+	PreStartJob *PreStartJobSpec `json:"preStartJob,omitempty"`
+	// UpgradeStrategy verifies a new server image on a canary pod before the Deployment is rolled out.
+	// The image is rolled out right away when unset
+	// +optional
+	UpgradeStrategy *UpgradeStrategySpec `json:"upgradeStrategy,omitempty"`
+	// Autoscaling creates a HorizontalPodAutoscaler scaling the server Deployment on CPU utilization.
+	// The HPA then owns the replica count of the Deployment, and spec.replicas is ignored
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// Probes overrides the liveness, readiness and startup probes of the server container. Probes left
+	// unset default to HTTP checks of /v1/health on the server port
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+	// Route exposes the server Service outside of the cluster with an OpenShift Route. On clusters without
+	// the Route API, no Route is created and the RouteReady condition reports it
+	// +optional
+	Route *RouteSpec `json:"route,omitempty"`
+	// Service configures the Service exposing the public ports of the server
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+	// ServiceOverride references an existing Service exposing the public ports of the server, e.g. one managed
+	// by a service mesh. The operator then doesn't create a Service for the public ports, and targets the
+	// referenced one for its health checks, the status, the Route and the Ingress
+	// +optional
+	ServiceOverride *ServiceOverrideSpec `json:"serviceOverride,omitempty"`
+	// NetworkPolicy customizes the NetworkPolicy of the server pods, created when the operator config enables
+	// NetworkPolicies
+	// +optional
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+	// Ingress exposes the server Service outside of the cluster with an Ingress
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+	// Metrics configures the scraping of the server metrics by the Prometheus Operator
+	// +optional
+	Metrics *MetricsSpec `json:"metrics,omitempty"`
+	// PodDisruptionBudget limits the server pods evicted at once by voluntary disruptions, e.g. node drains
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// ServiceSpec defines the Service exposing the public ports of the server
+// +kubebuilder:validation:XValidation:rule="!has(self.loadBalancerClass) || self.type == 'LoadBalancer'",message="loadBalancerClass requires the LoadBalancer type"
+// +kubebuilder:validation:XValidation:rule="!has(self.externalTrafficPolicy) || self.type == 'NodePort' || self.type == 'LoadBalancer'",message="externalTrafficPolicy requires the NodePort or LoadBalancer type"
+// +kubebuilder:validation:XValidation:rule="!has(self.headless) || !self.headless || self.type == 'ClusterIP'",message="headless requires the ClusterIP type"
+// +kubebuilder:validation:XValidation:rule="!has(self.sessionAffinityTimeoutSeconds) || (has(self.sessionAffinity) && self.sessionAffinity == 'ClientIP')",message="sessionAffinityTimeoutSeconds requires the ClientIP session affinity"
+type ServiceSpec struct {
+	// Type is the type of the Service. NodePort exposes the server on a port of every node, e.g. on bare-metal
+	// clusters, LoadBalancer provisions an external load balancer. Defaults to ClusterIP
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default=ClusterIP
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// LoadBalancerClass selects the load balancer implementation of a LoadBalancer Service. It can't be
+	// changed once the Service is created. Defaults to the cloud provider load balancer
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+	// ExternalTrafficPolicy controls whether external traffic is routed to node-local endpoints only (Local),
+	// preserving the client source IP, or to all endpoints (Cluster). Defaults to Cluster
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+	// Headless creates the Service without a cluster IP, so that its DNS name resolves to the addresses of the
+	// server pods, e.g. for the replicas to discover their peers. Requires the ClusterIP type. The cluster IP
+	// of a Service is immutable, so changing it replaces the Service
+	// +optional
+	Headless bool `json:"headless,omitempty"`
+	// PublishNotReadyAddresses publishes the addresses of the server pods before they are ready, e.g. for the
+	// replicas to discover their peers while starting
+	// +optional
+	PublishNotReadyAddresses bool `json:"publishNotReadyAddresses,omitempty"`
+	// SessionAffinity sends the requests of a client to the same server pod when set to ClientIP, e.g. to
+	// keep the per-session caches of the inference providers warm. Defaults to None
+	// +kubebuilder:validation:Enum=None;ClientIP
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+	// SessionAffinityTimeoutSeconds is how long the requests of a client stick to the same server pod.
+	// Defaults to 10800 (3 hours)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	SessionAffinityTimeoutSeconds *int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+}
+
+// ServiceOverrideSpec references an existing Service exposing the server
+type ServiceOverrideSpec struct {
+	// Name is the name of the Service, in the namespace of the distribution. The Service must exist, and
+	// its selector, when set, must select the server pods
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+}
+
+// NetworkPolicySpec customizes the NetworkPolicy of the server pods
+type NetworkPolicySpec struct {
+	// AllowedIngress lists the peers allowed to reach the server ports, e.g. the namespaces of a tenant, some
+	// pods or CIDR blocks. They are allowed next to the operator namespace, which is always allowed, and the
+	// llama-stack pods of every namespace, unless restrictIngress is set
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	AllowedIngress []networkingv1.NetworkPolicyPeer `json:"allowedIngress,omitempty"`
+	// RestrictIngress drops the rule allowing the llama-stack pods of every namespace to reach the server ports,
+	// so that only the operator namespace, for the health checks, and the allowedIngress peers reach them.
+	// Defaults to false
+	// +optional
+	RestrictIngress bool `json:"restrictIngress,omitempty"`
+	// Egress restricts the outgoing traffic of the server pods, e.g. in clusters denying egress by default.
+	// The outgoing traffic isn't restricted when unset
+	// +optional
+	Egress *NetworkPolicyEgressSpec `json:"egress,omitempty"`
+}
+
+// NetworkPolicyEgressSpec restricts the outgoing traffic of the server pods
+type NetworkPolicyEgressSpec struct {
+	// Rules lists the destinations the server pods may reach, e.g. the vector databases and the external
+	// model APIs of the providers. DNS on port 53 is always allowed, so without rules the server pods may
+	// only resolve names
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Rules []networkingv1.NetworkPolicyEgressRule `json:"rules,omitempty"`
+}
+
+// RouteSpec defines the OpenShift Route exposing the server Service
+type RouteSpec struct {
+	// Host is the host name of the Route. Defaults to a host name generated by the router
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Host string `json:"host,omitempty"`
+	// TLS configures the TLS termination of the Route. The Route serves plain HTTP when unset
+	// +optional
+	TLS *RouteTLSSpec `json:"tls,omitempty"`
+	// Labels are added to the labels of the Route, e.g. to select a router shard
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// RouteTLSTermination defines where the TLS connections of a Route are terminated
+// +kubebuilder:validation:Enum=edge;reencrypt;passthrough
+type RouteTLSTermination string
+
+const (
+	// RouteTLSTerminationEdge terminates TLS at the router, which forwards plain HTTP to the server
+	RouteTLSTerminationEdge RouteTLSTermination = "edge"
+	// RouteTLSTerminationReencrypt terminates TLS at the router, which opens a new TLS connection to the server
+	RouteTLSTerminationReencrypt RouteTLSTermination = "reencrypt"
+	// RouteTLSTerminationPassthrough sends the TLS connections to the server, which terminates them
+	RouteTLSTerminationPassthrough RouteTLSTermination = "passthrough"
+)
+
+// RouteTLSSpec defines the TLS termination of the Route, using the default certificate of the router
+type RouteTLSSpec struct {
+	// Termination is where the TLS connections are terminated
+	Termination RouteTLSTermination `json:"termination"`
+	// InsecureEdgeTerminationPolicy handles plain HTTP requests to edge and reencrypt Routes: None rejects them,
+	// Allow serves them, Redirect redirects them to HTTPS. Defaults to None
+	// +kubebuilder:validation:Enum=None;Allow;Redirect
+	// +optional
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
 }
 
+// IngressSpec defines the Ingress exposing the server Service
+type IngressSpec struct {
+	// Host is the host name the Ingress serves. The Ingress serves every host when unset
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Host string `json:"host,omitempty"`
+	// IngressClassName is the IngressClass of the Ingress. Defaults to the default IngressClass of the cluster
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Annotations are added to the annotations of the Ingress, e.g. to configure the ingress controller
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Path is the path sent to the server. Defaults to /
+	// +kubebuilder:default:="/"
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// PathType is how the path of the requests is matched. Defaults to Prefix
+	// +kubebuilder:default:=Prefix
+	// +kubebuilder:validation:Enum=Exact;Prefix;ImplementationSpecific
+	// +optional
+	PathType *networkingv1.PathType `json:"pathType,omitempty"`
+	// TLS terminates TLS at the ingress controller with the certificate of a Secret
+	// +optional
+	TLS *IngressTLSSpec `json:"tls,omitempty"`
+}
+
+// IngressTLSSpec defines the TLS termination of the Ingress
+type IngressTLSSpec struct {
+	// SecretName is the name of the Secret holding the TLS certificate and key, in the namespace of the
+	// distribution
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// MetricsSpec defines the ServiceMonitor scraping the server metrics. The ServiceMonitor is only created
+// on clusters with the Prometheus Operator CRDs
+type MetricsSpec struct {
+	// Enabled creates a ServiceMonitor scraping the server
+	Enabled bool `json:"enabled"`
+	// Path is the HTTP path of the metrics. Defaults to /metrics
+	// +kubebuilder:default:="/metrics"
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Port is the name of the Service port serving the metrics, either the server port http or one of the
+	// named ports of the container. Defaults to http
+	// +kubebuilder:default:="http"
+	// +kubebuilder:validation:MaxLength=15
+	// +optional
+	Port string `json:"port,omitempty"`
+	// Interval is how often Prometheus scrapes the metrics, e.g. 30s. Defaults to the scrape interval of Prometheus
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget of the server pods
+// +kubebuilder:validation:XValidation:rule="has(self.minAvailable) != has(self.maxUnavailable)",message="exactly one of minAvailable and maxUnavailable must be set"
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of server pods that must stay available during a disruption
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of server pods that can be unavailable during a disruption
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ProbesSpec defines the probes of the llama-stack server container
+type ProbesSpec struct {
+	// LivenessProbe restarts the server container when it stops responding
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe removes the server pod from the Service endpoints while it can't serve requests
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// StartupProbe holds the liveness and readiness probes until the server has started. The default
+	// allows 10 minutes for large distributions to load, more for the catalog distributions known to warm up slowly
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+}
+
+// AutoscalingSpec defines the HorizontalPodAutoscaler of the server Deployment
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not exceed maxReplicas"
+type AutoscalingSpec struct {
+	// MinReplicas is the lowest number of replicas the HPA scales down to. Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the highest number of replicas the HPA scales up to
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the average CPU utilization of the server pods, relative to their
+	// CPU requests, that the HPA maintains. Defaults to 80
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+}
+
+// PreStartJobSpec defines a Job run before the server pods are rolled out. The Job pod runs the server
+// container, with its environment and volumes except the persistent storage, and the command of the Job
+type PreStartJobSpec struct {
+	// Image is the image of the Job container. Defaults to the server image
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Command is the entrypoint of the Job container
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+	// Args are the arguments of the Job command
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env are additional environment variables of the Job container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// BackoffLimit is the number of retries before the Job is marked failed. Defaults to 3
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds bounds the time the Job may run before it is marked failed. Defaults to 1800
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// Resources are the compute resources of the Job container. The resources of the server container aren't
+	// copied, so that the Job doesn't wait for the resources held by the running server pods, e.g. GPUs
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// MountStorage mounts the persistent storage of the server in the Job container, e.g. to migrate its files.
+	// A ReadWriteOnce volume held by a server pod on another node keeps the Job pending
+	// +optional
+	MountStorage bool `json:"mountStorage,omitempty"`
+}
+
+// UpgradeStrategySpec defines how a new server image is verified before it is rolled out. When the image of
+// the server changes, a canary pod running the new pod template must answer the health and providers
+// endpoints before the Deployment is updated. The canary pod runs without the resources of the containers,
+// and with empty volumes in place of the persistent ones, so that it doesn't wait for the server pods
+type UpgradeStrategySpec struct {
+	// CanaryTimeout is how long the canary pod has to pass the checks before the upgrade is held, until the
+	// image changes again or the canary pod is deleted. Defaults to 10m
+	// +optional
+	CanaryTimeout *metav1.Duration `json:"canaryTimeout,omitempty"`
+}
+
+// RollbackPolicy defines how failed rollouts of the server Deployment are handled
+// +kubebuilder:validation:Enum=None;Auto
+type RollbackPolicy string
+
+const (
+	// RollbackPolicyNone leaves a failed rollout in place
+	RollbackPolicyNone RollbackPolicy = "None"
+	// RollbackPolicyAuto restores the last pod template that completed a rollout
+	RollbackPolicyAuto RollbackPolicy = "Auto"
+)
+
+// ServiceAccountTokenSpec defines a projected ServiceAccount token mounted into the server container
+type ServiceAccountTokenSpec struct {
+	// Audience is the intended audience of the token. The recipient must reject tokens
+	// with a different audience
+	// +kubebuilder:validation:MinLength=1
+	Audience string `json:"audience"`
+	// MountPath is the directory the token is mounted in. The token is available in the
+	// "token" file of that directory. Defaults to /var/run/secrets/tokens
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// ExpirationSeconds is the requested validity of the token. The kubelet refreshes the token
+	// before it expires. Defaults to 3600
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// UserConfigSpec defines the run.yaml of the llama-stack server, either from a ConfigMap or inline
+// +kubebuilder:validation:XValidation:rule="has(self.configMapName) != has(self.inline)",message="exactly one of configMapName and inline must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.configMapKey) || has(self.configMapName)",message="configMapKey requires configMapName"
 type UserConfigSpec struct {
 	// ConfigMapName is the name of the ConfigMap containing user configuration
-	ConfigMapName string `json:"configMapName"`
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
 	// ConfigMapNamespace is the namespace of the ConfigMap (defaults to the same namespace as the CR)
 	// +optional
 	ConfigMapNamespace string `json:"configMapNamespace,omitempty"`
+	// ConfigMapKey is the key of the ConfigMap holding the run.yaml. Defaults to mounting every key of the
+	// ConfigMap, the server reading the run.yaml key
+	// +optional
+	ConfigMapKey string `json:"configMapKey,omitempty"`
+	// Inline is the content of the run.yaml. The operator stores it in a ConfigMap named <name>-user-config
+	// and rolls out the pods when it changes
+	// +optional
+	Inline string `json:"inline,omitempty"`
 }
 
 // TLSConfig defines the TLS configuration for the llama-stack server
@@ -119,33 +594,361 @@ type CABundleConfig struct {
 	ConfigMapKeys []string `json:"configMapKeys,omitempty"`
 }
 
+// RedirectPolicy defines how redirects returned by the health endpoint are handled
+// +kubebuilder:validation:Enum=Follow;Reject
+type RedirectPolicy string
+
+const (
+	// RedirectPolicyFollow follows redirects, up to HealthCheckSpec.MaxRedirects
+	RedirectPolicyFollow RedirectPolicy = "Follow"
+	// RedirectPolicyReject treats any redirect as an unhealthy response
+	RedirectPolicyReject RedirectPolicy = "Reject"
+)
+
+// HealthCheckMethod is the HTTP method used to probe the health endpoint
+// +kubebuilder:validation:Enum=GET;HEAD;POST
+type HealthCheckMethod string
+
+const (
+	// HealthCheckMethodGet probes the health endpoint with a GET request
+	HealthCheckMethodGet HealthCheckMethod = "GET"
+	// HealthCheckMethodHead probes the health endpoint with a HEAD request
+	HealthCheckMethodHead HealthCheckMethod = "HEAD"
+	// HealthCheckMethodPost probes the health endpoint with a POST request, optionally sending Body
+	HealthCheckMethodPost HealthCheckMethod = "POST"
+)
+
+// HealthCheckSpec defines how the operator probes the llama-stack server health endpoint
+// +kubebuilder:validation:XValidation:rule="!has(self.body) || (has(self.method) && self.method == 'POST')",message="body can only be set when method is POST"
+// +kubebuilder:validation:XValidation:rule="!has(self.caBundle) || (has(self.scheme) && self.scheme == 'HTTPS')",message="caBundle can only be set when scheme is HTTPS"
+// +kubebuilder:validation:XValidation:rule="!has(self.caBundleSecretRef) || (has(self.scheme) && self.scheme == 'HTTPS')",message="caBundleSecretRef can only be set when scheme is HTTPS"
+// +kubebuilder:validation:XValidation:rule="!has(self.caBundle) || !has(self.caBundleSecretRef)",message="caBundle and caBundleSecretRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.insecureSkipVerify) || !self.insecureSkipVerify || (has(self.scheme) && self.scheme == 'HTTPS' && !has(self.caBundle) && !has(self.caBundleSecretRef))",message="insecureSkipVerify can only be set when scheme is HTTPS without a CA bundle"
+type HealthCheckSpec struct {
+	// Endpoints lists the paths probed by the health check, all of them must report a healthy status.
+	// The default liveness, readiness and startup probes check the first one. Defaults to /v1/health
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:items:Pattern=`^/`
+	// +optional
+	Endpoints []string `json:"endpoints,omitempty"`
+	// Port is the server container port the health endpoints are probed on, e.g. a separate admin port.
+	// It must be the server port or one of containerSpec.ports, and is reached through the Service
+	// exposing it. The default liveness, readiness and startup probes check it too. Defaults to the server port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// Scheme is the scheme of the health, providers and version requests made to the server.
+	// Defaults to HTTP
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+	// CABundle references the ConfigMap holding the CA certificates verifying the server certificate
+	// when Scheme is HTTPS. Defaults to the system CA certificates of the operator
+	// +optional
+	CABundle *CABundleConfig `json:"caBundle,omitempty"`
+	// CABundleSecretRef references the key of a Secret in the namespace of the distribution holding the CA
+	// certificates verifying the server certificate when Scheme is HTTPS, e.g. the ca.crt of a cert-manager
+	// Certificate Secret
+	// +optional
+	CABundleSecretRef *corev1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+	// InsecureSkipVerify disables the verification of the server certificate when Scheme is HTTPS,
+	// e.g. for self-signed certificates on development clusters
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// Method is the HTTP method used to probe the health endpoint.
+	// Defaults to GET
+	// +optional
+	Method HealthCheckMethod `json:"method,omitempty"`
+	// Body is a static request body sent with POST health checks, with the application/json content type
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Body string `json:"body,omitempty"`
+	// RedirectPolicy controls how 3xx responses from the health endpoint are handled.
+	// Follow follows redirects up to MaxRedirects, Reject treats a redirect as unhealthy.
+	// Defaults to Follow
+	// +optional
+	RedirectPolicy RedirectPolicy `json:"redirectPolicy,omitempty"`
+	// MaxRedirects is the maximum number of redirects followed when RedirectPolicy is Follow.
+	// Defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRedirects *int32 `json:"maxRedirects,omitempty"`
+	// StableAfter is how long the distribution must stay Ready before the Stable condition is set and a
+	// Stable Event is emitted, e.g. to gate a promotion. Leaving the Ready phase restarts the period.
+	// The Stable condition is not reported when unset
+	// +optional
+	StableAfter *metav1.Duration `json:"stableAfter,omitempty"`
+	// WarmupPeriod is how long after a rollout of the server pods failing health checks are expected
+	// warm-up: the distribution is reported Initializing instead of Failed until the period ends
+	// +optional
+	WarmupPeriod *metav1.Duration `json:"warmupPeriod,omitempty"`
+	// Timeout bounds each health, providers, models and version request made to the server, retries
+	// included, e.g. to give large distributions under load more time to answer. Defaults to 5s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// MaxAttempts is the number of times a request failing to reach the server or answered with a 5xx
+	// status is sent, with exponential backoff, before the request is considered failed. Defaults to 3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+	// Interval is how often a running distribution is probed again, so that the status follows the server
+	// health without waiting for a change of the owned objects. The server is only probed on changes when unset
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// RequiredProviders lists the IDs of the providers the server must report on /v1/providers before the
+	// distribution is Ready, e.g. the inference provider serving the models. The distribution stays
+	// Initializing while one of them is missing
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	// +optional
+	RequiredProviders []string `json:"requiredProviders,omitempty"`
+}
+
+// APIAuthSpec defines the credentials the operator authenticates to the server API with
+type APIAuthSpec struct {
+	// BearerTokenSecretRef references the key of a Secret, in the namespace of the distribution, holding the
+	// token sent in the Authorization header of the requests. The token is read again on every probe, so a
+	// rotated token is picked up without a restart, and it is never sent along redirects
+	BearerTokenSecretRef corev1.SecretKeySelector `json:"bearerTokenSecretRef"`
+}
+
+// SharedMemorySpec defines the shared memory volume mounted at /dev/shm
+type SharedMemorySpec struct {
+	// Size is the size limit of the shared memory volume. It must be positive and can't exceed the memory
+	// limit of the server container
+	Size resource.Quantity `json:"size"`
+}
+
 // StorageSpec defines the persistent storage configuration
 type StorageSpec struct {
-	// Size is the size of the persistent volume claim created for holding persistent data of the llama-stack server
+	// Size is the size of the persistent volume claim created for holding persistent data of the llama-stack server.
+	// Increasing it expands the PVC when its StorageClass allows volume expansion, the PVC never shrinks
 	Size *resource.Quantity `json:"size,omitempty"`
 	// MountPath is the path where the storage will be mounted in the container
 	MountPath string `json:"mountPath,omitempty"`
+	// SubPath mounts a directory of the volume instead of its root. It must be a relative path
+	// without .. elements
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+	// StorageClassName is the StorageClass of the persistent volume claim. The cluster default
+	// StorageClass is used when unset. The PVC is immutable, changing it after creation has no effect
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// AccessModes are the access modes of the persistent volume claim, ReadWriteOnce when unset.
+	// ReadWriteMany lets multiple replicas share the volume. The PVC is immutable, changing them
+	// after creation has no effect
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:Enum=ReadWriteOnce;ReadOnlyMany;ReadWriteMany;ReadWriteOncePod
+	// +listType=set
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// ImageVolumes mounts OCI images or artifacts, e.g. packaged model weights, read-only into the
+	// server container. Requires a cluster supporting image volumes, Kubernetes 1.31 or later with
+	// the ImageVolume feature gate enabled
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ImageVolumes []ImageVolumeSpec `json:"imageVolumes,omitempty"`
+}
+
+// ImageVolumeSpec defines an OCI image or artifact mounted as a volume
+type ImageVolumeSpec struct {
+	// Name is the name of the volume in the pod
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Reference is the OCI image or artifact reference, e.g. quay.io/example/granite-weights:1.0
+	// +kubebuilder:validation:MinLength=1
+	Reference string `json:"reference"`
+	// MountPath is the path where the volume is mounted in the server container
+	// +kubebuilder:validation:Pattern=`^/`
+	MountPath string `json:"mountPath"`
+	// SubPath mounts a directory of the image instead of its root, e.g. one model variant of an image
+	// packaging several. It must be a relative path without .. elements. Mounting a subpath
+	// of an image volume requires Kubernetes 1.33 or later
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+	// PullPolicy is the policy for pulling the image. Defaults to Always for the latest tag,
+	// IfNotPresent otherwise
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
 }
 
 // ContainerSpec defines the llama-stack server container configuration.
 type ContainerSpec struct {
 	// +kubebuilder:default:="llama-stack"
-	Name      string                      `json:"name,omitempty"` // Optional, defaults to "llama-stack"
-	Port      int32                       `json:"port,omitempty"` // Defaults to 8321 if unset
+	Name string `json:"name,omitempty"` // Optional, defaults to "llama-stack"
+	// Port is the port the server listens on for its HTTP API, exposed as the http port of the Service.
+	// It is always TCP, as the probes, Route, Ingress and clients speak HTTP to it. Additional ports, which
+	// can use UDP or SCTP, are set in ports. Defaults to 8321
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+	// ImagePullPolicy is the pull policy of the server image. Defaults to Always for images tagged latest
+	// or without a tag, and to IfNotPresent otherwise
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Resources are the compute resource requests and limits of the server container. Extended resources,
+	// such as the nvidia.com/gpu limits of GPU distributions, are passed through unchanged
+	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 	Env       []corev1.EnvVar             `json:"env,omitempty"` // Runtime env vars (e.g., INFERENCE_MODEL)
-	Command   []string                    `json:"command,omitempty"`
-	Args      []string                    `json:"args,omitempty"`
+	// EnvFrom sets environment variables from the keys of ConfigMaps and Secrets, e.g. provider credentials.
+	// Variables set by env take precedence over the ones from envFrom
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// Command overrides the entrypoint of the server image, e.g. for custom distribution images.
+	// The image entrypoint is kept when unset or empty
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args overrides the arguments of the server image, e.g. to pass --config with the path of a mounted
+	// run.yaml. The image arguments are kept when unset or empty
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// ThreadCountEnv lists environment variables, e.g. GOMAXPROCS or OMP_NUM_THREADS, set to the CPU limit
+	// rounded up to a whole number of CPUs. Ignored when no CPU limit is set. Variables also listed in env
+	// keep the value from env
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:items:Pattern=`^[-._a-zA-Z][-._a-zA-Z0-9]*$`
+	ThreadCountEnv []string `json:"threadCountEnv,omitempty"`
+	// Ports defines additional named ports exposed by the server container, e.g. a metrics port
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:XValidation:rule="self.all(p, p.name != 'http')",message="port name 'http' is reserved for the server port"
+	Ports []PortSpec `json:"ports,omitempty"`
+}
+
+// PortExposure defines which Service exposes a port
+// +kubebuilder:validation:Enum=Public;Internal
+type PortExposure string
+
+const (
+	// PortExposurePublic exposes the port on the server Service, next to the server port
+	PortExposurePublic PortExposure = "Public"
+	// PortExposureInternal exposes the port on a separate ClusterIP-only Service
+	PortExposureInternal PortExposure = "Internal"
+)
+
+// PortSpec defines an additional named port of the llama-stack server container
+type PortSpec struct {
+	// Name is the name of the port, used for both the container port and the Service port
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Port is the port number the container listens on
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Exposure selects the Service exposing the port: Public ports are added to the server Service,
+	// Internal ports to a separate ClusterIP Service named <name>-internal-service.
+	// Defaults to Public
+	// +kubebuilder:default:=Public
+	// +optional
+	Exposure PortExposure `json:"exposure,omitempty"`
+	// Protocol is the network protocol of the port, used for the container port, the Service port
+	// and the NetworkPolicy rule. Defaults to TCP
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	// +kubebuilder:default:=TCP
+	// +optional
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
 }
 
 // PodOverrides allows advanced pod-level customization.
+// +kubebuilder:validation:XValidation:rule="!has(self.createServiceAccount) || !self.createServiceAccount || has(self.serviceAccountName)",message="createServiceAccount requires serviceAccountName"
+// +kubebuilder:validation:XValidation:rule="!has(self.grantConfigAccess) || !self.grantConfigAccess || (has(self.createServiceAccount) && self.createServiceAccount)",message="grantConfigAccess requires createServiceAccount"
 type PodOverrides struct {
 	// ServiceAccountName allows users to specify their own ServiceAccount
 	// If not specified, the operator will use the default ServiceAccount
 	// +optional
-	ServiceAccountName string               `json:"serviceAccountName,omitempty"`
-	Volumes            []corev1.Volume      `json:"volumes,omitempty"`
-	VolumeMounts       []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// CreateServiceAccount makes the operator create and own the ServiceAccount named by serviceAccountName,
+	// instead of expecting it to exist. A ServiceAccount created by someone else is never taken over
+	// +optional
+	CreateServiceAccount bool `json:"createServiceAccount,omitempty"`
+	// GrantConfigAccess grants the created ServiceAccount get and watch on the ConfigMaps of the distribution
+	// in its namespace, the user config and the CA bundle, through a Role and a RoleBinding named after it.
+	// The rules are built by the operator, so that editing a distribution never grants other permissions
+	// +optional
+	GrantConfigAccess bool `json:"grantConfigAccess,omitempty"`
+	// Volumes are added to the volumes of the server pods, next to the volumes managed by the operator,
+	// e.g. a ConfigMap or an emptyDir for scratch space. Their names must not collide with the managed volumes
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// VolumeMounts are added to the mounts of the server container, next to the mounts managed by the
+	// operator. Their mount paths must not collide with the managed mounts
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// HostNetwork runs the server pods in the host network namespace.
+	// The server ports are then bound on the node, so every LlamaStackDistribution using the
+	// host network must declare ports that don't collide with the other ones
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// SafeToEvict sets the cluster-autoscaler.kubernetes.io/safe-to-evict annotation on the server pods.
+	// Setting it to false keeps the cluster autoscaler from evicting pods that use emptyDir or local storage,
+	// at the cost of blocking the scale-down of the nodes running them.
+	// Unset leaves the decision to the cluster autoscaler
+	// +optional
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+	// LogCollection sets the log collection annotations configured in the operator config on the server pods,
+	// so that the log collector of the cluster handles the logs of every distribution the same way
+	// +optional
+	LogCollection bool `json:"logCollection,omitempty"`
+	// PodAnnotations are added to the annotations of the server pods only, e.g. sidecar.istio.io/inject or
+	// linkerd.io/inject to enable the sidecar injection of a service mesh. They take precedence over
+	// spec.annotations, but can't override the annotations set by the operator, such as the config checksums
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// NodeSelector restricts the server pods to the nodes with these labels, e.g. the nodes with GPUs
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity sets the node affinity and the pod (anti-)affinity scheduling rules of the server pods
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Tolerations allow the server pods to run on tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PriorityClassName is the PriorityClass of the server pods, e.g. to keep inference pods from being evicted
+	// before less important workloads under node pressure. A missing PriorityClass is reported by the
+	// PriorityClassAvailable condition, and the pods are rejected until it is created
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// TopologySpreadConstraints spread the server pods across the topology domains of the cluster, e.g. zones.
+	// They replace the spread across the nodes of spreadAcrossNodes
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// SpreadAcrossNodes spreads the pods of an instance running, or autoscaled to, more than one replica across
+	// the nodes, on a best-effort basis that doesn't block their scheduling. It is ignored when
+	// topologySpreadConstraints are set. Defaults to false
+	// +optional
+	SpreadAcrossNodes bool `json:"spreadAcrossNodes,omitempty"`
+	// ImagePullSecrets are the Secrets holding the credentials of the private registries of the images,
+	// in the namespace of the distribution
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// SecurityContext is the security context of the server pods. The fields set here replace the defaults,
+	// a RuntimeDefault seccomp profile. Setting fsGroup lets the kubelet grant access to the storage volume,
+	// replacing the root init container fixing its ownership, which restricted namespaces reject
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+	// ContainerSecurityContext is the security context of the server container. The fields set here replace
+	// the defaults passing the restricted Pod Security Standard: runAsNonRoot, no privilege escalation, all
+	// capabilities dropped and a RuntimeDefault seccomp profile. GPU distributions may need to relax them
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
 }
 
 // ProviderInfo represents a single provider from the providers endpoint.
@@ -157,17 +960,41 @@ type ProviderInfo struct {
 	Health       ProviderHealthStatus `json:"health"`
 }
 
+// ModelInfo represents a model served by the distribution, as reported by the models endpoint
+type ModelInfo struct {
+	// Identifier is the name the model is requested with
+	Identifier string `json:"identifier"`
+	// ProviderID is the provider serving the model
+	ProviderID string `json:"provider_id"`
+	// ProviderResourceID is the name of the model at the provider
+	ProviderResourceID string `json:"provider_resource_id,omitempty"`
+	// ModelType is the type of model, e.g. llm or embedding
+	ModelType string `json:"model_type,omitempty"`
+}
+
 // DistributionConfig represents the configuration information from the providers endpoint.
 type DistributionConfig struct {
 	// ActiveDistribution shows which distribution is currently being used
 	ActiveDistribution string         `json:"activeDistribution,omitempty"`
 	Providers          []ProviderInfo `json:"providers,omitempty"`
+	// Models lists the models served by the distribution, cleared while the deployment is not ready
+	// +optional
+	Models []ModelInfo `json:"models,omitempty"`
 	// AvailableDistributions lists all available distributions and their images
 	AvailableDistributions map[string]string `json:"availableDistributions,omitempty"`
+	// PinnedImage is the server image whose digest the Deployment is pinned to, when distribution.useDigest is set
+	// +optional
+	PinnedImage string `json:"pinnedImage,omitempty"`
+	// ImageDigest is the digest the server image is pinned to
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// LatestImageDigest is the digest the tag of the pinned image points to, when it moved away from ImageDigest
+	// +optional
+	LatestImageDigest string `json:"latestImageDigest,omitempty"`
 }
 
 // LlamaStackDistributionPhase represents the current phase of the LlamaStackDistribution
-// +kubebuilder:validation:Enum=Pending;Initializing;Ready;Failed;Terminating
+// +kubebuilder:validation:Enum=Pending;Initializing;Ready;Degraded;Failed;Terminating
 type DistributionPhase string
 
 const (
@@ -177,6 +1004,9 @@ const (
 	LlamaStackDistributionPhaseInitializing DistributionPhase = "Initializing"
 	// LlamaStackDistributionPhaseReady indicates that the distribution is ready to use
 	LlamaStackDistributionPhaseReady DistributionPhase = "Ready"
+	// LlamaStackDistributionPhaseDegraded indicates that the distribution is running but its server or one of
+	// its providers reports unhealthy
+	LlamaStackDistributionPhaseDegraded DistributionPhase = "Degraded"
 	// LlamaStackDistributionPhaseFailed indicates that the distribution has failed
 	LlamaStackDistributionPhaseFailed DistributionPhase = "Failed"
 	// LlamaStackDistributionPhaseTerminating indicates that the distribution is being terminated
@@ -193,8 +1023,93 @@ type VersionInfo struct {
 	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
 }
 
+// AutoscalingStatus reports the replica counts of the HorizontalPodAutoscaler of the server Deployment
+type AutoscalingStatus struct {
+	// CurrentReplicas is the number of replicas last seen by the HPA
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// DesiredReplicas is the number of replicas last computed by the HPA
+	DesiredReplicas int32 `json:"desiredReplicas"`
+}
+
+// ReplicaStatus describes the readiness of a single server pod
+type ReplicaStatus struct {
+	// PodName is the name of the pod
+	PodName string `json:"podName"`
+	// Ready indicates whether the pod is ready to serve traffic
+	Ready bool `json:"ready"`
+	// Reason explains why the pod is not ready
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// RolloutStatus describes a rollout of the server Deployment
+type RolloutStatus struct {
+	// TemplateHash identifies the pod template rolled out
+	TemplateHash string `json:"templateHash"`
+	// StartTime is when the operator observed the rollout
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// PodTemplateSummary summarizes the pod template built for the server Deployment
+type PodTemplateSummary struct {
+	// Hash identifies the pod template, it changes with any field of the template
+	Hash string `json:"hash"`
+	// Image is the image of the server container
+	Image string `json:"image,omitempty"`
+	// Resources are the resources of the server container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// EnvNames lists the environment variables of the server container. Values are left out since they
+	// may hold credentials
+	// +optional
+	EnvNames []string `json:"envNames,omitempty"`
+	// Volumes lists the volumes of the pods
+	// +optional
+	Volumes []string `json:"volumes,omitempty"`
+	// ServiceAccountName is the ServiceAccount the pods run as
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// NodeSelector is the node selector of the pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// ServiceStatus describes a Service exposing the llama-stack server
+type ServiceStatus struct {
+	// Name is the name of the Service
+	Name string `json:"name"`
+	// Exposure is the exposure of the ports served by the Service
+	Exposure PortExposure `json:"exposure"`
+	// Ports lists the ports exposed by the Service
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+	// Type is the type of the Service
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// NodePorts lists the node ports allocated to the ports of a NodePort or LoadBalancer Service
+	// +optional
+	NodePorts []int32 `json:"nodePorts,omitempty"`
+	// ExternalAddresses lists the IPs or host names assigned to a LoadBalancer Service
+	// +optional
+	ExternalAddresses []string `json:"externalAddresses,omitempty"`
+	// Headless is true when the Service has no cluster IP, its DNS name resolving to the server pods
+	// +optional
+	Headless bool `json:"headless,omitempty"`
+}
+
+// RouteStatus reports the OpenShift Route exposing the server
+type RouteStatus struct {
+	// Host is the host name admitted by the router
+	// +optional
+	Host string `json:"host,omitempty"`
+}
+
 // LlamaStackDistributionStatus defines the observed state of LlamaStackDistribution.
 type LlamaStackDistributionStatus struct {
+	// ObservedGeneration is the generation of the spec the status was last computed from. The status
+	// reflects the latest spec once it equals metadata.generation
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Phase represents the current phase of the distribution
 	Phase DistributionPhase `json:"phase,omitempty"`
 	// Version contains version information for both operator and deployment
@@ -205,6 +1120,37 @@ type LlamaStackDistributionStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// AvailableReplicas is the number of available replicas
 	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// Autoscaling reports the replica counts of the HorizontalPodAutoscaler, when autoscaling is enabled
+	// +optional
+	Autoscaling *AutoscalingStatus `json:"autoscaling,omitempty"`
+	// ReplicaStatuses reports the readiness of each server pod
+	// +optional
+	ReplicaStatuses []ReplicaStatus `json:"replicaStatuses,omitempty"`
+	// Services lists the Services exposing the server
+	// +optional
+	Services []ServiceStatus `json:"services,omitempty"`
+	// Route reports the OpenShift Route exposing the server, when spec.server.route is set
+	// +optional
+	Route *RouteStatus `json:"route,omitempty"`
+	// ReadySince is when the distribution last entered the Ready phase
+	// +optional
+	ReadySince *metav1.Time `json:"readySince,omitempty"`
+	// LastTransitionReason is the reason of the condition that last made the distribution leave the Ready phase.
+	// Unlike the condition, it is kept once the distribution is Ready again
+	// +optional
+	LastTransitionReason string `json:"lastTransitionReason,omitempty"`
+	// LastTransitionMessage is the message of the condition that last made the distribution leave the Ready phase
+	// +optional
+	LastTransitionMessage string `json:"lastTransitionMessage,omitempty"`
+	// LastTransitionTime is when the distribution last left the Ready phase
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Rollout describes the last rollout of the server pods
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// PodTemplate summarizes the pod template built by the operator, when spec.server.reportPodTemplate is set
+	// +optional
+	PodTemplate *PodTemplateSummary `json:"podTemplate,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -242,7 +1188,30 @@ func init() { //nolint:gochecknoinits
 	SchemeBuilder.Register(&LlamaStackDistribution{}, &LlamaStackDistributionList{})
 }
 
-// HasPorts checks if the container spec defines a port.
+// GetPortsByExposure returns the additional container ports with the given exposure.
+func (r *LlamaStackDistribution) GetPortsByExposure(exposure PortExposure) []PortSpec {
+	var ports []PortSpec
+	for _, port := range r.Spec.Server.ContainerSpec.Ports {
+		portExposure := port.Exposure
+		if portExposure == "" {
+			portExposure = PortExposurePublic
+		}
+		if portExposure == exposure {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// GetProtocol returns the protocol of the port, defaulting to TCP.
+func (p PortSpec) GetProtocol() corev1.Protocol {
+	if p.Protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return p.Protocol
+}
+
+// HasPorts checks if the container spec defines a port, either the server port or additional named ports.
 func (r *LlamaStackDistribution) HasPorts() bool {
-	return r.Spec.Server.ContainerSpec.Port != 0 || len(r.Spec.Server.ContainerSpec.Env) > 0
+	return r.Spec.Server.ContainerSpec.Port != 0 || len(r.Spec.Server.ContainerSpec.Ports) > 0 || len(r.Spec.Server.ContainerSpec.Env) > 0
 }
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/mutate-llamastack-io-v1alpha1-llamastackdistribution,mutating=true,failurePolicy=fail,sideEffects=None,groups=llamastack.io,resources=llamastackdistributions,verbs=create;update,versions=v1alpha1,name=mllamastackdistribution.kb.io,admissionReviewVersions=v1
+
+// LlamaStackDistributionDefaulter fills in the defaults of the replicas, storage size and server port at
+// admission, so that the persisted spec is self-describing. The controller applies the same replicas and storage
+// size, through GetDefaultReplicas and GetSize, to the instances admitted while the webhook is disabled. The
+// defaulted port also gives a Service to the instances without ports, which the controller only exposes when a
+// port is set.
+// +kubebuilder:object:generate=false
+type LlamaStackDistributionDefaulter struct {
+	// DistributionReplicas holds the catalog default replica count per distribution name
+	DistributionReplicas map[string]int32
+}
+
+var _ admission.CustomDefaulter = &LlamaStackDistributionDefaulter{}
+
+// SetupWebhookWithManager registers the defaulting webhook of the LlamaStackDistributions with the manager.
+func (d *LlamaStackDistributionDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&LlamaStackDistribution{}).
+		WithDefaulter(d).
+		Complete(); err != nil {
+		return fmt.Errorf("failed to set up the LlamaStackDistribution webhook: %w", err)
+	}
+	return nil
+}
+
+// Default implements admission.CustomDefaulter.
+func (d *LlamaStackDistributionDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*LlamaStackDistribution)
+	if !ok {
+		return fmt.Errorf("failed to default object: expected a LlamaStackDistribution but got %T", obj)
+	}
+	d.SetDefaults(instance)
+	return nil
+}
+
+// SetDefaults fills in the unset replicas, storage size and server port of the instance. Applying it again
+// leaves the spec unchanged. The replicas are left unset when autoscaling manages them.
+func (d *LlamaStackDistributionDefaulter) SetDefaults(instance *LlamaStackDistribution) {
+	if instance.Spec.Replicas == nil && instance.Spec.Server.Autoscaling == nil {
+		instance.Spec.Replicas = ptr.To(GetDefaultReplicas(instance, d.DistributionReplicas))
+	}
+	if storage := instance.Spec.Server.Storage; storage != nil && storage.Size == nil {
+		storage.Size = ptr.To(storage.GetSize())
+	}
+	if instance.Spec.Server.ContainerSpec.Port == 0 {
+		instance.Spec.Server.ContainerSpec.Port = DefaultServerPort
+	}
+}
+
+// GetDefaultReplicas returns the replica count of an instance without spec.replicas: the catalog default of its
+// distribution, falling back to 1.
+func GetDefaultReplicas(instance *LlamaStackDistribution, distributionReplicas map[string]int32) int32 {
+	if replicas, ok := distributionReplicas[instance.Spec.Server.Distribution.Name]; ok {
+		return replicas
+	}
+	return 1
+}
+
+// GetSize returns the size of the storage, DefaultStorageSize when unset.
+func (s *StorageSpec) GetSize() resource.Quantity {
+	if s.Size != nil {
+		return s.Size.DeepCopy()
+	}
+	return DefaultStorageSize.DeepCopy()
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestSetDefaults(t *testing.T) {
+	defaulter := &LlamaStackDistributionDefaulter{DistributionReplicas: map[string]int32{"starter": 2}}
+
+	instance := &LlamaStackDistribution{}
+	instance.Spec.Server.Distribution.Name = "starter"
+	instance.Spec.Server.Storage = &StorageSpec{}
+	defaulter.SetDefaults(instance)
+	assert.Equal(t, ptr.To(int32(2)), instance.Spec.Replicas, "the catalog default should be used")
+	require.NotNil(t, instance.Spec.Server.Storage.Size)
+	assert.Equal(t, "10Gi", instance.Spec.Server.Storage.Size.String())
+	assert.Equal(t, DefaultServerPort, instance.Spec.Server.ContainerSpec.Port)
+
+	instance = &LlamaStackDistribution{}
+	instance.Spec.Server.Distribution.Image = "llama-stack:custom"
+	defaulter.SetDefaults(instance)
+	assert.Equal(t, ptr.To(int32(1)), instance.Spec.Replicas)
+	assert.Nil(t, instance.Spec.Server.Storage, "storage should stay disabled")
+
+	// Set values are kept
+	instance = &LlamaStackDistribution{}
+	instance.Spec.Replicas = ptr.To(int32(0))
+	instance.Spec.Server.Storage = &StorageSpec{Size: ptr.To(resource.MustParse("1Gi"))}
+	instance.Spec.Server.ContainerSpec.Port = 8080
+	defaulter.SetDefaults(instance)
+	assert.Equal(t, ptr.To(int32(0)), instance.Spec.Replicas)
+	assert.Equal(t, "1Gi", instance.Spec.Server.Storage.Size.String())
+	assert.Equal(t, int32(8080), instance.Spec.Server.ContainerSpec.Port)
+
+	// Autoscaling manages the replicas
+	instance = &LlamaStackDistribution{}
+	instance.Spec.Server.Autoscaling = &AutoscalingSpec{MaxReplicas: 3}
+	defaulter.SetDefaults(instance)
+	assert.Nil(t, instance.Spec.Replicas)
+}
+
+func TestSharedDefaults(t *testing.T) {
+	distributionReplicas := map[string]int32{"starter": 2}
+	instance := &LlamaStackDistribution{}
+	assert.Equal(t, int32(1), GetDefaultReplicas(instance, distributionReplicas))
+	assert.Equal(t, int32(1), GetDefaultReplicas(instance, nil))
+	instance.Spec.Server.Distribution.Name = "starter"
+	assert.Equal(t, int32(2), GetDefaultReplicas(instance, distributionReplicas))
+
+	storage := &StorageSpec{}
+	assert.Equal(t, "10Gi", ptr.To(storage.GetSize()).String())
+	storage.Size = ptr.To(resource.MustParse("1Gi"))
+	assert.Equal(t, "1Gi", ptr.To(storage.GetSize()).String())
+}
+
+func TestDefaultIsIdempotent(t *testing.T) {
+	defaulter := &LlamaStackDistributionDefaulter{}
+	instance := &LlamaStackDistribution{}
+	instance.Name = "llsd"
+	instance.Spec.Server.Distribution.Name = "starter"
+	instance.Spec.Server.Storage = &StorageSpec{MountPath: "/.llama"}
+	instance.Spec.Server.ContainerSpec.Env = []corev1.EnvVar{{Name: "INFERENCE_MODEL", Value: "llama3.2:1b"}}
+	require.NoError(t, defaulter.Default(context.Background(), instance))
+
+	// The defaulted spec survives a round trip through the API server and is not changed again
+	data, err := json.Marshal(instance)
+	require.NoError(t, err)
+	roundTripped := &LlamaStackDistribution{}
+	require.NoError(t, json.Unmarshal(data, roundTripped))
+	require.NoError(t, defaulter.Default(context.Background(), roundTripped))
+	roundTrippedData, err := json.Marshal(roundTripped)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(roundTrippedData))
+
+	require.Error(t, defaulter.Default(context.Background(), &LlamaStackDistributionList{}))
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// getAPIToken returns the bearer token the operator authenticates to the server with, empty when the
+// instance doesn't configure API auth. The token itself must never be logged or reported.
+func (r *LlamaStackDistributionReconciler) getAPIToken(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) (string, error) {
+	apiAuth := instance.Spec.Server.APIAuth
+	if apiAuth == nil {
+		return "", nil
+	}
+	ref := apiAuth.BearerTokenSecretRef
+	name := types.NamespacedName{Name: ref.Name, Namespace: instance.Namespace}
+
+	secret := &corev1.Secret{}
+	if err := r.getSecret(ctx, name, secret); err != nil {
+		err = fmt.Errorf("failed to get API token Secret %s: %w", name, err)
+		if k8serrors.IsNotFound(err) {
+			return "", &apiTokenError{err: err}
+		}
+		return "", err
+	}
+	token := strings.TrimSpace(string(secret.Data[ref.Key]))
+	if token == "" {
+		return "", &apiTokenError{err: fmt.Errorf("failed to find API token: key %s of Secret %s is missing or empty", ref.Key, name)}
+	}
+	return token, nil
+}
+
+// apiTokenError is an API token missing from the Secret of spec.server.apiAuth. No request is sent to the
+// server without it, so the status points at the Secret rather than at the server, which may well be up.
+type apiTokenError struct {
+	err error
+}
+
+func (e *apiTokenError) Error() string {
+	return e.err.Error() + ": set the bearer token Secret of spec.server.apiAuth"
+}
+
+func (e *apiTokenError) Unwrap() error {
+	return e.err
+}
+
+// isAPITokenError returns true when the error is an API token missing from its Secret.
+func isAPITokenError(err error) bool {
+	var tokenErr *apiTokenError
+	return errors.As(err, &tokenErr)
+}
+
+// withAPIToken returns the client sending the bearer token with its requests, if any. The copy shares the
+// transport of the client, so that the connections are still reused.
+func withAPIToken(httpClient *http.Client, token string) *http.Client {
+	if token == "" {
+		return httpClient
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	tokenClient := *httpClient
+	tokenClient.Transport = &bearerTokenTransport{base: base, token: token}
+	return &tokenClient
+}
+
+// bearerTokenTransport sets the Authorization header of the requests made to the server. Redirected requests
+// are sent without it, so that the token is never disclosed to another host.
+type bearerTokenTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The client sets the response of the redirect on the requests it creates to follow it
+	if req.Response != nil {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+// apiAuthError is a request to the server rejected because of its credentials. It is told apart from the
+// other failures, so that the status points at the API token rather than at the server.
+type apiAuthError struct {
+	endpoint   string
+	statusCode int
+}
+
+func (e *apiAuthError) Error() string {
+	if e.statusCode == http.StatusForbidden {
+		return fmt.Sprintf("server denied the request to %s with status code 403: "+
+			"the bearer token of spec.server.apiAuth isn't allowed to call it", e.endpoint)
+	}
+	return fmt.Sprintf("server rejected the request to %s with status code 401: "+
+		"set a valid bearer token in spec.server.apiAuth", e.endpoint)
+}
+
+// getAPIAuthError returns an apiAuthError when the status code rejects the credentials of the request, nil otherwise.
+func getAPIAuthError(endpoint string, statusCode int) error {
+	if statusCode != http.StatusUnauthorized && statusCode != http.StatusForbidden {
+		return nil
+	}
+	return &apiAuthError{endpoint: endpoint, statusCode: statusCode}
+}
+
+// isAPIAuthError returns true when the error is a request rejected because of its credentials.
+func isAPIAuthError(err error) bool {
+	var authErr *apiAuthError
+	return errors.As(err, &authErr)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testAPIToken = "s3cr3t-token"
+
+// newAPIAuthTestInstance returns an instance authenticating with the token of the llsd-token Secret.
+func newAPIAuthTestInstance() *llamav1alpha1.LlamaStackDistribution {
+	instance := newHealthCheckTestInstance(nil)
+	instance.Spec.Server.APIAuth = &llamav1alpha1.APIAuthSpec{
+		BearerTokenSecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "llsd-token"},
+			Key:                  "token",
+		},
+	}
+	return instance
+}
+
+// withAPITokenSecret sets a fake client holding the token Secret of the test instance on the reconciler.
+func withAPITokenSecret(t *testing.T, r *LlamaStackDistributionReconciler, token string) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "llsd-token", Namespace: "test-namespace"},
+		Data:       map[string][]byte{"token": []byte(token)},
+	}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+}
+
+func TestGetAPIToken(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{}
+	ctx := context.Background()
+
+	token, err := r.getAPIToken(ctx, newHealthCheckTestInstance(nil))
+	require.NoError(t, err)
+	assert.Empty(t, token, "no token is sent without API auth")
+
+	withAPITokenSecret(t, r, testAPIToken+"\n")
+	token, err = r.getAPIToken(ctx, newAPIAuthTestInstance())
+	require.NoError(t, err)
+	assert.Equal(t, testAPIToken, token, "the trailing newline of the Secret is trimmed")
+
+	instance := newAPIAuthTestInstance()
+	instance.Spec.Server.APIAuth.BearerTokenSecretRef.Key = "missing"
+	_, err = r.getAPIToken(ctx, instance)
+	require.ErrorContains(t, err, "key missing of Secret test-namespace/llsd-token is missing or empty")
+	assert.True(t, isAPITokenError(err))
+
+	instance.Spec.Server.APIAuth.BearerTokenSecretRef.Name = "missing"
+	_, err = r.getAPIToken(ctx, instance)
+	require.ErrorContains(t, err, "failed to get API token Secret test-namespace/missing")
+	assert.True(t, isAPITokenError(err))
+	assert.False(t, isAPIAuthError(err), "no request was rejected")
+}
+
+func TestAPITokenRequests(t *testing.T) {
+	var redirectedAuth string
+	mux := http.NewServeMux()
+	authorized := func(w http.ResponseWriter, req *http.Request) bool {
+		if req.Header.Get("Authorization") != "Bearer "+testAPIToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+	mux.HandleFunc("/v1/health", func(w http.ResponseWriter, req *http.Request) {
+		if authorized(w, req) {
+			_, _ = io.WriteString(w, `{"status": "OK"}`)
+		}
+	})
+	mux.HandleFunc("/v1/providers", func(w http.ResponseWriter, req *http.Request) {
+		if authorized(w, req) {
+			_, _ = io.WriteString(w, `{"data": [{"api": "inference", "provider_id": "ollama", "health": {"status": "OK"}}]}`)
+		}
+	})
+	mux.HandleFunc("/v1/health/redirect", func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, "/v1/health/target", http.StatusFound)
+	})
+	mux.HandleFunc("/v1/health/target", func(w http.ResponseWriter, req *http.Request) {
+		redirectedAuth = req.Header.Get("Authorization")
+		_, _ = io.WriteString(w, `{"status": "OK"}`)
+	})
+	r := newHealthCheckTestReconciler(t, mux)
+	withAPITokenSecret(t, r, testAPIToken)
+	ctx := context.Background()
+
+	instance := newAPIAuthTestInstance()
+	r.performHealthChecks(ctx, instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseReady, instance.Status.Phase)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeHealthCheck))
+	require.Len(t, instance.Status.DistributionConfig.Providers, 1)
+
+	// The token isn't sent along redirects
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Endpoints: []string{"/v1/health/redirect"}}
+	healthy, _, err := r.checkHealth(ctx, instance)
+	require.NoError(t, err)
+	assert.True(t, healthy)
+	assert.Empty(t, redirectedAuth)
+
+	// A rejected token is reported as such rather than as an unhealthy server
+	withAPITokenSecret(t, r, "wrong-token")
+	instance = newAPIAuthTestInstance()
+	r.performHealthChecks(ctx, instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseDegraded, instance.Status.Phase)
+	condition := GetCondition(&instance.Status, ConditionTypeHealthCheck)
+	require.NotNil(t, condition)
+	assert.Contains(t, condition.Message, "status code 401: set a valid bearer token in spec.server.apiAuth")
+	assert.NotContains(t, condition.Message, "wrong-token")
+	condition = GetCondition(&instance.Status, ConditionTypeProvidersHealthy)
+	require.NotNil(t, condition)
+	assert.Contains(t, condition.Message, "status code 401")
+
+	// A missing or empty token is reported as such rather than as a server still starting
+	for _, token := range []string{"", " \n"} {
+		withAPITokenSecret(t, r, token)
+		instance = newAPIAuthTestInstance()
+		r.performHealthChecks(ctx, instance)
+		assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseDegraded, instance.Status.Phase)
+		condition = GetCondition(&instance.Status, ConditionTypeHealthCheck)
+		require.NotNil(t, condition)
+		assert.Contains(t, condition.Message, "key token of Secret test-namespace/llsd-token is missing or empty: "+
+			"set the bearer token Secret of spec.server.apiAuth")
+	}
+	instance = newAPIAuthTestInstance()
+	instance.Spec.Server.APIAuth.BearerTokenSecretRef.Name = "missing"
+	r.performHealthChecks(ctx, instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseDegraded, instance.Status.Phase)
+	condition = GetCondition(&instance.Status, ConditionTypeHealthCheck)
+	require.NotNil(t, condition)
+	assert.Contains(t, condition.Message, "failed to get API token Secret test-namespace/missing")
+}
+
+func TestGetAPIAuthError(t *testing.T) {
+	require.NoError(t, getAPIAuthError("/v1/health", http.StatusOK))
+	require.NoError(t, getAPIAuthError("/v1/health", http.StatusServiceUnavailable))
+
+	err := getAPIAuthError("/v1/health", http.StatusUnauthorized)
+	require.ErrorContains(t, err, "server rejected the request to /v1/health with status code 401")
+	assert.True(t, isAPIAuthError(err))
+
+	err = getAPIAuthError("/v1/providers", http.StatusForbidden)
+	require.ErrorContains(t, err, "server denied the request to /v1/providers with status code 403")
+	assert.True(t, isAPIAuthError(err))
+}
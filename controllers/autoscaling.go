@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultTargetCPUUtilizationPercentage is the CPU utilization maintained by the HPA when none is set.
+const defaultTargetCPUUtilizationPercentage = 80
+
+// getDeploymentReplicas returns the replica count set on the Deployment, nil when the HPA owns it.
+func (r *LlamaStackDistributionReconciler) getDeploymentReplicas(instance *llamav1alpha1.LlamaStackDistribution) *int32 {
+	if instance.Spec.Server.Autoscaling != nil {
+		return nil
+	}
+	return ptr.To(r.getReplicas(instance))
+}
+
+// reconcileHPA creates or updates the HorizontalPodAutoscaler of the server Deployment when autoscaling is
+// enabled, and deletes it otherwise.
+func (r *LlamaStackDistributionReconciler) reconcileHPA(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	hpa := buildHorizontalPodAutoscaler(instance)
+	if instance.Spec.Server.Autoscaling == nil {
+		return deploy.DeleteIfControlled(ctx, r.Client, instance, hpa, log.FromContext(ctx))
+	}
+	return deploy.ApplyHorizontalPodAutoscaler(ctx, r.Client, r.Scheme, instance, hpa, log.FromContext(ctx))
+}
+
+// buildHorizontalPodAutoscaler returns the HorizontalPodAutoscaler scaling the server Deployment on CPU utilization.
+func buildHorizontalPodAutoscaler(instance *llamav1alpha1.LlamaStackDistribution) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+	autoscaling := instance.Spec.Server.Autoscaling
+	if autoscaling == nil {
+		return hpa
+	}
+
+	hpa.Labels = deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+		deploy.InstanceLabelKey: instance.Name,
+	})
+	hpa.Annotations = deploy.GetResourceAnnotations(instance)
+	hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Name:       instance.Name,
+		},
+		MinReplicas: ptr.To(ptr.Deref(autoscaling.MinReplicas, 1)),
+		MaxReplicas: autoscaling.MaxReplicas,
+		Metrics: []autoscalingv2.MetricSpec{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To(ptr.Deref(autoscaling.TargetCPUUtilizationPercentage, defaultTargetCPUUtilizationPercentage)),
+				},
+			},
+		}},
+	}
+	return hpa
+}
+
+// updateAutoscalingStatus reports the replica counts of the HorizontalPodAutoscaler, and whether it is able
+// to scale the Deployment.
+func (r *LlamaStackDistributionReconciler) updateAutoscalingStatus(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
+	if instance.Spec.Server.Autoscaling == nil {
+		instance.Status.Autoscaling = nil
+		meta.RemoveStatusCondition(&instance.Status.Conditions, ConditionTypeAutoscalingReady)
+		return
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := r.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, hpa); err != nil {
+		SetAutoscalingReadyCondition(instance, false, fmt.Sprintf("Failed to get HorizontalPodAutoscaler: %v", err))
+		return
+	}
+
+	instance.Status.Autoscaling = &llamav1alpha1.AutoscalingStatus{
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+	}
+	ready, message := getHPAReadiness(hpa)
+	SetAutoscalingReadyCondition(instance, ready, message)
+}
+
+// getHPAReadiness reports whether the HPA is able to scale the Deployment from the metrics it collects,
+// with the reason it isn't.
+func getHPAReadiness(hpa *autoscalingv2.HorizontalPodAutoscaler) (bool, string) {
+	seen := 0
+	for _, condition := range hpa.Status.Conditions {
+		if condition.Type != autoscalingv2.AbleToScale && condition.Type != autoscalingv2.ScalingActive {
+			continue
+		}
+		seen++
+		if condition.Status != corev1.ConditionTrue {
+			return false, fmt.Sprintf("HorizontalPodAutoscaler %s is false: %s: %s", condition.Type, condition.Reason, condition.Message)
+		}
+	}
+	if seen == 0 {
+		return false, "Waiting for the HorizontalPodAutoscaler to compute the replica count"
+	}
+	return true, MessageAutoscalingReady
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildHorizontalPodAutoscaler(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Replicas = ptr.To(int32(2))
+	instance.Spec.Server.Autoscaling = &llamav1alpha1.AutoscalingSpec{MaxReplicas: 5}
+
+	hpa := buildHorizontalPodAutoscaler(instance)
+	assert.Equal(t, "llsd", hpa.Name)
+	assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, "llsd", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(1), ptr.Deref(hpa.Spec.MinReplicas, 0))
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	require.Len(t, hpa.Spec.Metrics, 1)
+	require.NotNil(t, hpa.Spec.Metrics[0].Resource)
+	assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32(defaultTargetCPUUtilizationPercentage), ptr.Deref(hpa.Spec.Metrics[0].Resource.Target.AverageUtilization, 0))
+
+	// The HPA owns the replica count of the Deployment
+	r := &LlamaStackDistributionReconciler{}
+	assert.Nil(t, r.getDeploymentReplicas(instance))
+	instance.Spec.Server.Autoscaling = nil
+	assert.Equal(t, int32(2), ptr.Deref(r.getDeploymentReplicas(instance), 0))
+}
+
+func TestGetHPAReadiness(t *testing.T) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	ready, _ := getHPAReadiness(hpa)
+	assert.False(t, ready, "an HPA without conditions hasn't computed the replica count yet")
+
+	hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+		{Type: autoscalingv2.AbleToScale, Status: corev1.ConditionTrue},
+		{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "FailedGetResourceMetric", Message: "missing request for cpu"},
+	}
+	ready, message := getHPAReadiness(hpa)
+	assert.False(t, ready)
+	assert.Equal(t, "HorizontalPodAutoscaler ScalingActive is false: FailedGetResourceMetric: missing request for cpu", message)
+
+	hpa.Status.Conditions[1].Status = corev1.ConditionTrue
+	ready, message = getHPAReadiness(hpa)
+	assert.True(t, ready)
+	assert.Equal(t, MessageAutoscalingReady, message)
+}
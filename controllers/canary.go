@@ -0,0 +1,262 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// canaryComponent is the component label value of the canary pods.
+	canaryComponent = "canary"
+	// canaryHashLength is the length of the pod template hash suffix of the canary pod names.
+	canaryHashLength = 10
+	// defaultCanaryTimeout is how long a canary pod has to pass the checks by default.
+	defaultCanaryTimeout = 10 * time.Minute
+	// canaryRequeueInterval is how often an instance is reconciled while its canary pod is checked.
+	canaryRequeueInterval = 10 * time.Second
+)
+
+// reconcileCanary verifies the new server image on a canary pod before the Deployment is updated, and reports
+// whether the Deployment can be rolled out. Only image changes of an existing Deployment are verified: the
+// canary pod runs the desired pod template and must answer the health and providers endpoints.
+func (r *LlamaStackDistributionReconciler) reconcileCanary(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	deployment *appsv1.Deployment) (bool, error) {
+	if instance.Spec.Server.UpgradeStrategy == nil {
+		return true, r.deleteStaleCanaryPods(ctx, instance, "")
+	}
+
+	live := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), live); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// The first rollout has no running image to protect
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to fetch deployment: %w", err)
+	}
+	desiredImage := getServerImage(instance, &deployment.Spec.Template)
+	if getServerImage(instance, &live.Spec.Template) == desiredImage {
+		if IsConditionTrue(&instance.Status, ConditionTypeUpgradeInProgress) {
+			SetUpgradeInProgressCondition(instance, false, "")
+		}
+		return true, r.deleteStaleCanaryPods(ctx, instance, "")
+	}
+
+	canary, err := buildCanaryPod(instance, &deployment.Spec.Template)
+	if err != nil {
+		return false, err
+	}
+	if err := ctrl.SetControllerReference(instance, canary, r.Scheme); err != nil {
+		return false, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := r.deleteStaleCanaryPods(ctx, instance, canary.Name); err != nil {
+		return false, err
+	}
+
+	existing := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(canary), existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to fetch canary pod: %w", err)
+		}
+		log.FromContext(ctx).Info("creating canary pod before rolling out the new image", "pod", canary.Name, "image", desiredImage)
+		err := r.createWithImageVolumes(ctx, canary, canary.Annotations)
+		if err != nil && !k8serrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create canary pod: %w", err)
+		}
+		SetUpgradeInProgressCondition(instance, true, fmt.Sprintf("Starting canary pod %s with image %s", canary.Name, desiredImage))
+		return false, nil
+	}
+
+	checkErr := r.checkCanaryPod(ctx, instance, existing)
+	if checkErr == nil {
+		r.recordEvent(instance, corev1.EventTypeNormal, reasonCanaryPassed,
+			fmt.Sprintf("Canary pod %s passed the checks, rolling out image %s", existing.Name, desiredImage))
+		SetUpgradeInProgressCondition(instance, true, fmt.Sprintf("Rolling out image %s verified by canary pod %s", desiredImage, existing.Name))
+		return true, nil
+	}
+
+	if time.Since(existing.CreationTimestamp.Time) < getCanaryTimeout(instance) && existing.Status.Phase != corev1.PodFailed {
+		SetUpgradeInProgressCondition(instance, true, fmt.Sprintf("Verifying image %s on canary pod %s: %v", desiredImage, existing.Name, checkErr))
+		return false, nil
+	}
+	message := fmt.Sprintf("Canary pod %s failed the checks of image %s, the upgrade is held until the image changes or the pod is deleted: %v",
+		existing.Name, desiredImage, checkErr)
+	if condition := GetCondition(&instance.Status, ConditionTypeUpgradeInProgress); condition == nil || condition.Reason != ReasonCanaryFailed {
+		r.recordEvent(instance, corev1.EventTypeWarning, ReasonCanaryFailed, message)
+	}
+	SetUpgradeCanaryFailedCondition(instance, message)
+	return false, nil
+}
+
+// checkCanaryPod checks that the server container of the canary pod is ready and that the server answers the
+// health endpoints, on the health check port, and the providers endpoint. The pod is reached by its IP address,
+// without the proxy, and its certificate is verified against the host name of the Service.
+func (r *LlamaStackDistributionReconciler) checkCanaryPod(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	pod *corev1.Pod) error {
+	if replica := getReplicaStatus(pod, getContainerName(instance)); !replica.Ready {
+		return fmt.Errorf("pod is not ready: %s", replica.Reason)
+	}
+	if pod.Status.PodIP == "" {
+		return errors.New("pod has no IP address")
+	}
+
+	healthPort := getContainerPort(instance)
+	if healthCheck := instance.Spec.Server.HealthCheck; healthCheck != nil && healthCheck.Port != nil {
+		healthPort = *healthCheck.Port
+	}
+	for _, endpoint := range getHealthCheckEndpoints(instance) {
+		serviceURL := r.getHealthCheckURL(instance, endpoint)
+		httpClient, err := r.getPodHTTPClient(ctx, instance, serviceURL.Hostname())
+		if err != nil {
+			return err
+		}
+		healthURL := getPodURL(serviceURL, pod, healthPort)
+		req, err := newHealthCheckRequest(ctx, instance, healthURL.String())
+		if err != nil {
+			return err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to make health check request: %w", err)
+		}
+		closeResponseBody(resp.Body)
+		if err := getAPIAuthError(endpoint, resp.StatusCode); err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("health endpoint %s returned status code %d", endpoint, resp.StatusCode)
+		}
+	}
+
+	serviceURL := r.getServerURL(instance, providersEndpoint)
+	httpClient, err := r.getPodHTTPClient(ctx, instance, serviceURL.Hostname())
+	if err != nil {
+		return err
+	}
+	if _, err := r.getProviderPage(ctx, httpClient, getPodURL(serviceURL, pod, getContainerPort(instance))); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getPodURL returns the Service URL pointing to the port of the pod IP instead.
+func getPodURL(serviceURL *url.URL, pod *corev1.Pod, port int32) *url.URL {
+	podURL := *serviceURL
+	podURL.Host = net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port)))
+	return &podURL
+}
+
+// buildCanaryPod returns the canary pod of the pod template. The pod doesn't carry the selector labels of the
+// server pods, so that it doesn't receive traffic from the Service, and is named after the hash of the template.
+// The resources of the containers are dropped and the persistent volumes replaced by empty ones, so that the
+// canary pod doesn't wait for the GPUs or the ReadWriteOnce volumes held by the running server pods.
+func buildCanaryPod(instance *llamav1alpha1.LlamaStackDistribution, template *corev1.PodTemplateSpec) (*corev1.Pod, error) {
+	hash, err := getPodTemplateHash(template)
+	if err != nil {
+		return nil, err
+	}
+	podSpec := template.Spec.DeepCopy()
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Resources = corev1.ResourceRequirements{}
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Resources = corev1.ResourceRequirements{}
+	}
+	for i := range podSpec.Volumes {
+		if podSpec.Volumes[i].PersistentVolumeClaim != nil {
+			podSpec.Volumes[i].VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+		}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-canary-%s", instance.Name, hash[:canaryHashLength]),
+			Namespace: instance.Namespace,
+			Labels: deploy.MergeLabels(instance.Spec.Labels, deploy.GetPodLabels(instance), map[string]string{
+				deploy.InstanceLabelKey:  instance.Name,
+				deploy.ComponentLabelKey: canaryComponent,
+			}),
+			Annotations: template.Annotations,
+		},
+		Spec: *podSpec,
+	}, nil
+}
+
+// deleteStaleCanaryPods deletes the canary pods of the instance other than the current one.
+func (r *LlamaStackDistributionReconciler) deleteStaleCanaryPods(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	current string) error {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(instance.Namespace), client.MatchingLabels{
+		deploy.InstanceLabelKey:  instance.Name,
+		deploy.ComponentLabelKey: canaryComponent,
+	}); err != nil {
+		return fmt.Errorf("failed to list canary pods: %w", err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Name == current || !metav1.IsControlledBy(pod, instance) {
+			continue
+		}
+		if err := r.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete stale canary pod %s: %w", pod.Name, err)
+		}
+	}
+	return nil
+}
+
+// getServerImage returns the image of the server container of the pod template.
+func getServerImage(instance *llamav1alpha1.LlamaStackDistribution, template *corev1.PodTemplateSpec) string {
+	for _, container := range template.Spec.Containers {
+		if container.Name == getContainerName(instance) {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// getCanaryTimeout returns how long the canary pod of the instance has to pass the checks.
+func getCanaryTimeout(instance *llamav1alpha1.LlamaStackDistribution) time.Duration {
+	if timeout := instance.Spec.Server.UpgradeStrategy.CanaryTimeout; timeout != nil && timeout.Duration > 0 {
+		return timeout.Duration
+	}
+	return defaultCanaryTimeout
+}
+
+// getCanaryRequeue returns how soon the instance is reconciled again to check its canary pod, zero when no
+// upgrade is in progress.
+func getCanaryRequeue(instance *llamav1alpha1.LlamaStackDistribution) time.Duration {
+	if IsConditionTrue(&instance.Status, ConditionTypeUpgradeInProgress) {
+		return canaryRequeueInterval
+	}
+	return 0
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newCanaryTestDeployment returns a Deployment running the server image of the instance.
+func newCanaryTestDeployment(instance *llamav1alpha1.LlamaStackDistribution, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: getPodSelectorLabels(instance)},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: getContainerName(instance), Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildCanaryPod(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	template := &newCanaryTestDeployment(instance, "llama-stack:new").Spec.Template
+
+	pod, err := buildCanaryPod(instance, template)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pod.Name, "llsd-canary-"))
+	assert.Equal(t, canaryComponent, pod.Labels[deploy.ComponentLabelKey])
+	assert.Equal(t, "llsd", pod.Labels[deploy.InstanceLabelKey])
+	assert.NotContains(t, pod.Labels, llamav1alpha1.DefaultLabelKey, "canary pods must not be selected by the Service")
+	assert.Equal(t, "llama-stack:new", getServerImage(instance, &corev1.PodTemplateSpec{Spec: pod.Spec}))
+
+	template.Spec.Containers[0].Image = "llama-stack:newer"
+	updated, err := buildCanaryPod(instance, template)
+	require.NoError(t, err)
+	assert.NotEqual(t, pod.Name, updated.Name, "a new image runs a new canary pod")
+}
+
+func TestBuildCanaryPodDropsGPUsAndPersistentVolumes(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	template := &newCanaryTestDeployment(instance, "llama-stack:new").Spec.Template
+	template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+	}
+	template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "lls-storage", MountPath: "/.llama"}}
+	template.Spec.Volumes = []corev1.Volume{{
+		Name: "lls-storage",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "llsd-pvc"},
+		},
+	}}
+
+	pod, err := buildCanaryPod(instance, template)
+	require.NoError(t, err)
+	assert.Empty(t, pod.Spec.Containers[0].Resources.Limits, "the canary pod must not wait for the GPUs of the server pods")
+	require.Len(t, pod.Spec.Volumes, 1)
+	assert.Nil(t, pod.Spec.Volumes[0].PersistentVolumeClaim, "the canary pod must not wait for the ReadWriteOnce volume")
+	assert.NotNil(t, pod.Spec.Volumes[0].EmptyDir)
+	assert.Equal(t, template.Spec.Containers[0].VolumeMounts, pod.Spec.Containers[0].VolumeMounts)
+
+	// The template itself is left untouched
+	assert.NotNil(t, template.Spec.Volumes[0].PersistentVolumeClaim)
+	assert.NotEmpty(t, template.Spec.Containers[0].Resources.Limits)
+}
+
+func TestReconcileCanaryKeepsImageVolumes(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.UpgradeStrategy = &llamav1alpha1.UpgradeStrategySpec{}
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{
+		ImageVolumes: []llamav1alpha1.ImageVolumeSpec{{Name: "models", Reference: "models:v1", MountPath: "/models"}},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	var created client.Object
+	r := &LlamaStackDistributionReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(newCanaryTestDeployment(instance, "llama-stack:old")).
+			WithInterceptorFuncs(interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object,
+				opts ...client.CreateOption) error {
+				created = obj
+				return c.Create(ctx, obj, opts...)
+			}}).Build(),
+		Scheme: scheme,
+	}
+	desired := newCanaryTestDeployment(instance, "llama-stack:new")
+	desired.Spec.Template.Annotations = map[string]string{}
+	require.NoError(t, addImageVolumes(instance, &desired.Spec.Template.Spec, desired.Spec.Template.Annotations))
+
+	proceed, err := r.reconcileCanary(context.Background(), instance, desired)
+	require.NoError(t, err)
+	assert.False(t, proceed)
+	canary, ok := created.(*unstructured.Unstructured)
+	require.True(t, ok, "a canary pod with image volumes is created unstructured")
+	assert.Equal(t, "Pod", canary.GetKind())
+	volumes, _, err := unstructured.NestedSlice(canary.Object, "spec", "volumes")
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"name": "models", "image": map[string]any{"reference": "models:v1"}}}, volumes)
+}
+
+func TestGetCanaryTimeout(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Spec.Server.UpgradeStrategy = &llamav1alpha1.UpgradeStrategySpec{}
+	assert.Equal(t, defaultCanaryTimeout, getCanaryTimeout(instance))
+
+	instance.Spec.Server.UpgradeStrategy.CanaryTimeout = &metav1.Duration{Duration: 3 * time.Minute}
+	assert.Equal(t, 3*time.Minute, getCanaryTimeout(instance))
+}
+
+func TestReconcileCanary(t *testing.T) {
+	providersAnswered := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case defaultHealthCheckEndpoint:
+			fmt.Fprint(w, `{"status":"OK"}`)
+		case providersEndpoint:
+			providersAnswered = true
+			fmt.Fprint(w, `{"data":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	serverPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.ContainerSpec.Port = int32(serverPort)
+	instance.Spec.Server.UpgradeStrategy = &llamav1alpha1.UpgradeStrategySpec{}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(newCanaryTestDeployment(instance, "llama-stack:old")).Build(),
+		Scheme:     scheme,
+		httpClient: server.Client(),
+	}
+	ctx := context.Background()
+	desired := newCanaryTestDeployment(instance, "llama-stack:new")
+
+	// A new image starts a canary pod and holds the rollout
+	proceed, err := r.reconcileCanary(ctx, instance, desired)
+	require.NoError(t, err)
+	assert.False(t, proceed)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeUpgradeInProgress))
+	assert.Equal(t, canaryRequeueInterval, getCanaryRequeue(instance))
+	canary, err := buildCanaryPod(instance, &desired.Spec.Template)
+	require.NoError(t, err)
+	pod := &corev1.Pod{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(canary), pod))
+	assert.True(t, metav1.IsControlledBy(pod, instance))
+	// The fake client doesn't set the creation timestamp, which starts the canary timeout
+	pod.CreationTimestamp = metav1.Now()
+	require.NoError(t, r.Update(ctx, pod))
+
+	// The rollout waits for the canary pod to be ready
+	proceed, err = r.reconcileCanary(ctx, instance, desired)
+	require.NoError(t, err)
+	assert.False(t, proceed)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeUpgradeInProgress))
+
+	// A ready canary pod answering the checks lets the rollout proceed
+	pod.Status.PodIP = host
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: getContainerName(instance), Ready: true}}
+	require.NoError(t, r.Status().Update(ctx, pod))
+	proceed, err = r.reconcileCanary(ctx, instance, desired)
+	require.NoError(t, err)
+	assert.True(t, proceed)
+	assert.True(t, providersAnswered, "the canary pod must answer the providers endpoint")
+
+	// Once the Deployment runs the new image, the canary pod is deleted
+	require.NoError(t, r.Update(ctx, desired))
+	proceed, err = r.reconcileCanary(ctx, instance, desired)
+	require.NoError(t, err)
+	assert.True(t, proceed)
+	condition := GetCondition(&instance.Status, ConditionTypeUpgradeInProgress)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonUpgradeComplete, condition.Reason)
+	assert.Zero(t, getCanaryRequeue(instance))
+	pods := &corev1.PodList{}
+	require.NoError(t, r.List(ctx, pods))
+	assert.Empty(t, pods.Items)
+}
+
+func TestReconcileCanaryTimeout(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.UpgradeStrategy = &llamav1alpha1.UpgradeStrategySpec{}
+	desired := newCanaryTestDeployment(instance, "llama-stack:new")
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	canary, err := buildCanaryPod(instance, &desired.Spec.Template)
+	require.NoError(t, err)
+	canary.CreationTimestamp = metav1.NewTime(time.Now().Add(-defaultCanaryTimeout - time.Minute))
+	r := &LlamaStackDistributionReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(newCanaryTestDeployment(instance, "llama-stack:old"), canary).Build(),
+		Scheme: scheme,
+	}
+
+	// A canary pod still not ready after the timeout holds the upgrade
+	proceed, err := r.reconcileCanary(context.Background(), instance, desired)
+	require.NoError(t, err)
+	assert.False(t, proceed)
+	condition := GetCondition(&instance.Status, ConditionTypeUpgradeInProgress)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonCanaryFailed, condition.Reason)
+	assert.Zero(t, getCanaryRequeue(instance))
+}
+
+// newCanaryTestServer starts a server answering the health and providers endpoints when health is set, and the
+// providers endpoint only otherwise. It returns the host and port of the server.
+func newCanaryTestServer(t *testing.T, health bool, certificate *tls.Certificate) (string, int32) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case health && r.URL.Path == defaultHealthCheckEndpoint:
+			fmt.Fprint(w, `{"status":"OK"}`)
+		case r.URL.Path == providersEndpoint:
+			fmt.Fprint(w, `{"data":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	if certificate != nil {
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{*certificate}, MinVersion: tls.VersionTLS12}
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	serverPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return host, int32(serverPort)
+}
+
+// newCanaryTestCertificate returns a self-signed certificate valid for the DNS name only, and its PEM encoding.
+func newCanaryTestCertificate(t *testing.T, dnsName string) (*tls.Certificate, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: dnsName},
+		DNSNames:              []string{dnsName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// newCanaryTestPod returns a ready canary pod with the IP address.
+func newCanaryTestPod(instance *llamav1alpha1.LlamaStackDistribution, podIP string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-canary", Namespace: instance.Namespace},
+		Status: corev1.PodStatus{
+			PodIP:             podIP,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: getContainerName(instance), Ready: true}},
+		},
+	}
+}
+
+func TestCheckCanaryPodHealthCheckPort(t *testing.T) {
+	// The health endpoints are only served on the admin port
+	host, serverPort := newCanaryTestServer(t, false, nil)
+	_, adminPort := newCanaryTestServer(t, true, nil)
+
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.ContainerSpec.Port = serverPort
+	instance.Spec.Server.ContainerSpec.Ports = []llamav1alpha1.PortSpec{{Name: "admin", Port: adminPort}}
+	r := &LlamaStackDistributionReconciler{httpClient: newHTTPClient()}
+	pod := newCanaryTestPod(instance, host)
+
+	require.ErrorContains(t, r.checkCanaryPod(context.Background(), instance, pod), "returned status code 404")
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Port: ptr.To(adminPort)}
+	require.NoError(t, r.checkCanaryPod(context.Background(), instance, pod))
+}
+
+func TestCheckCanaryPodBypassesProxy(t *testing.T) {
+	host, serverPort := newCanaryTestServer(t, true, nil)
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.ContainerSpec.Port = serverPort
+
+	// The shared client sends the requests to other hosts than the Services through an unreachable proxy
+	httpClient := newHTTPClient()
+	unreachable, err := url.Parse("http://127.0.0.1:1")
+	require.NoError(t, err)
+	httpClient.Transport.(*http.Transport).Proxy = http.ProxyURL(unreachable)
+	r := &LlamaStackDistributionReconciler{httpClient: httpClient}
+
+	require.NoError(t, r.checkCanaryPod(context.Background(), instance, newCanaryTestPod(instance, host)))
+}
+
+func TestCheckCanaryPodHTTPS(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	// The certificate of the server is only valid for the host name of the Service, not for the pod IP
+	serviceHost := (&LlamaStackDistributionReconciler{}).getServerURL(instance, providersEndpoint).Hostname()
+	certificate, caBundle := newCanaryTestCertificate(t, serviceHost)
+	host, serverPort := newCanaryTestServer(t, true, certificate)
+	instance.Spec.Server.ContainerSpec.Port = serverPort
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{
+		Scheme:   corev1.URISchemeHTTPS,
+		CABundle: &llamav1alpha1.CABundleConfig{ConfigMapName: "server-ca"},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "server-ca", Namespace: "default"},
+		Data:       map[string]string{DefaultCABundleKey: caBundle},
+	}
+	r := &LlamaStackDistributionReconciler{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build(),
+		httpClient: newHTTPClient(),
+	}
+
+	require.NoError(t, r.checkCanaryPod(context.Background(), instance, newCanaryTestPod(instance, host)))
+}
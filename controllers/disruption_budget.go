@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcilePodDisruptionBudget creates or updates the PodDisruptionBudget of the server pods when one is
+// requested, and deletes it otherwise.
+func (r *LlamaStackDistributionReconciler) reconcilePodDisruptionBudget(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	pdb := buildPodDisruptionBudget(instance)
+	if instance.Spec.Server.PodDisruptionBudget == nil {
+		return deploy.DeleteIfControlled(ctx, r.Client, instance, pdb, log.FromContext(ctx))
+	}
+	return deploy.ApplyPodDisruptionBudget(ctx, r.Client, r.Scheme, instance, pdb, log.FromContext(ctx))
+}
+
+// buildPodDisruptionBudget returns the PodDisruptionBudget selecting the server pods.
+func buildPodDisruptionBudget(instance *llamav1alpha1.LlamaStackDistribution) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+	budget := instance.Spec.Server.PodDisruptionBudget
+	if budget == nil {
+		return pdb
+	}
+
+	pdb.Labels = deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+		deploy.InstanceLabelKey: instance.Name,
+	})
+	pdb.Annotations = deploy.GetResourceAnnotations(instance)
+	pdb.Spec = policyv1.PodDisruptionBudgetSpec{
+		Selector:       &metav1.LabelSelector{MatchLabels: getPodSelectorLabels(instance)},
+		MinAvailable:   budget.MinAvailable,
+		MaxUnavailable: budget.MaxUnavailable,
+	}
+	return pdb
+}
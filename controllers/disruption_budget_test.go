@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildPodDisruptionBudget(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.PodDisruptionBudget = &llamav1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: ptr.To(intstr.FromString("25%"))}
+
+	pdb := buildPodDisruptionBudget(instance)
+	assert.Equal(t, "llsd", pdb.Name)
+	assert.Equal(t, "llsd", pdb.Labels[deploy.InstanceLabelKey])
+	assert.Equal(t, getPodSelectorLabels(instance), pdb.Spec.Selector.MatchLabels)
+	assert.Nil(t, pdb.Spec.MinAvailable)
+	assert.Equal(t, ptr.To(intstr.FromString("25%")), pdb.Spec.MaxUnavailable)
+
+	instance.Spec.Server.PodDisruptionBudget = nil
+	assert.Nil(t, buildPodDisruptionBudget(instance).Spec.Selector)
+}
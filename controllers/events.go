@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Reasons of the Events recorded on the LlamaStackDistributions during reconciliation.
+const (
+	reasonDeploymentCreated     = "DeploymentCreated"
+	reasonDeploymentUpdated     = "DeploymentUpdated"
+	reasonPVCCreated            = "PVCCreated"
+	reasonStorageResized        = "StorageResized"
+	reasonHealthCheckFailing    = "HealthCheckFailing"
+	reasonValidationFailed      = "ValidationFailed"
+	reasonFailed                = "Failed"
+	reasonTeardownFailed        = "TeardownFailed"
+	reasonPriorityClassNotFound = "PriorityClassNotFound"
+	reasonImageDigestChanged    = "ImageDigestChanged"
+	reasonCanaryPassed          = "CanaryPassed"
+)
+
+// recordEvent records an Event on the instance, when the reconciler has a recorder.
+func (r *LlamaStackDistributionReconciler) recordEvent(instance *llamav1alpha1.LlamaStackDistribution, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(instance, eventType, reason, message)
+}
+
+// recordDeploymentApplied records the creation or the update of the server Deployment.
+func (r *LlamaStackDistributionReconciler) recordDeploymentApplied(instance *llamav1alpha1.LlamaStackDistribution,
+	result controllerutil.OperationResult) {
+	switch result { //nolint:exhaustive // unchanged Deployments are not reported
+	case controllerutil.OperationResultCreated:
+		r.recordEvent(instance, corev1.EventTypeNormal, reasonDeploymentCreated, fmt.Sprintf("Created Deployment %s", instance.Name))
+	case controllerutil.OperationResultUpdated:
+		r.recordEvent(instance, corev1.EventTypeNormal, reasonDeploymentUpdated, fmt.Sprintf("Updated Deployment %s", instance.Name))
+	}
+}
+
+// recordPhaseFailed records the transition of the instance to the Failed phase, with the reconcile error causing
+// it. Failing health checks degrade the instance rather than failing it, and are recorded as HealthCheckFailing.
+func (r *LlamaStackDistributionReconciler) recordPhaseFailed(instance *llamav1alpha1.LlamaStackDistribution,
+	previousPhase llamav1alpha1.DistributionPhase, reconcileErr error) {
+	if previousPhase == llamav1alpha1.LlamaStackDistributionPhaseFailed || instance.Status.Phase != llamav1alpha1.LlamaStackDistributionPhaseFailed {
+		return
+	}
+
+	message := fmt.Sprintf("Resource reconciliation failed: %v", reconcileErr)
+	if isInvalidSpec(reconcileErr) {
+		message = fmt.Sprintf("Spec validation failed: %v", reconcileErr)
+	}
+	r.recordEvent(instance, corev1.EventTypeWarning, reasonFailed, message)
+}
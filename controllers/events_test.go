@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestRecordDeploymentApplied(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{Recorder: recorder}
+	instance := newHealthCheckTestInstance(nil)
+
+	r.recordDeploymentApplied(instance, controllerutil.OperationResultCreated)
+	r.recordDeploymentApplied(instance, controllerutil.OperationResultNone)
+	r.recordDeploymentApplied(instance, controllerutil.OperationResultUpdated)
+	require.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Normal DeploymentCreated Created Deployment test-instance", <-recorder.Events)
+	assert.Equal(t, "Normal DeploymentUpdated Updated Deployment test-instance", <-recorder.Events)
+
+	// Reconcilers without a recorder don't record Events
+	(&LlamaStackDistributionReconciler{}).recordDeploymentApplied(instance, controllerutil.OperationResultCreated)
+}
+
+func TestRecordPhaseFailed(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{Recorder: recorder}
+	instance := newHealthCheckTestInstance(nil)
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseFailed
+
+	r.recordPhaseFailed(instance, llamav1alpha1.LlamaStackDistributionPhaseReady, errors.New("failed to apply manifests"))
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning Failed Resource reconciliation failed: failed to apply manifests", <-recorder.Events)
+
+	// Only the transition to Failed is recorded
+	r.recordPhaseFailed(instance, llamav1alpha1.LlamaStackDistributionPhaseFailed, errors.New("failed to apply manifests"))
+	assert.Empty(t, recorder.Events)
+
+	// Invalid specs are reported as validation failures
+	r.recordPhaseFailed(instance, llamav1alpha1.LlamaStackDistributionPhaseReady, &invalidSpecError{err: errors.New("failed to validate ports")})
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning Failed Spec validation failed: failed to validate ports", <-recorder.Events)
+}
+
+func TestRecordHealthCheckFailing(t *testing.T) {
+	status := http.StatusOK
+	r := newHealthCheckTestReconciler(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	instance := newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{MaxAttempts: ptr.To(int32(1))})
+
+	r.performHealthChecks(context.Background(), instance)
+	require.True(t, IsConditionTrue(&instance.Status, ConditionTypeHealthCheck))
+
+	status = http.StatusServiceUnavailable
+	r.performHealthChecks(context.Background(), instance)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning HealthCheckFailing "+MessageHealthCheckFailed, <-recorder.Events)
+
+	// A failing health check is only reported when it starts failing
+	r.performHealthChecks(context.Background(), instance)
+	assert.Empty(t, recorder.Events)
+}
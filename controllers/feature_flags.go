@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// networkPolicyEnabled returns the EnableNetworkPolicy feature flag, which is reloaded when the operator
+// config ConfigMap changes.
+func (r *LlamaStackDistributionReconciler) networkPolicyEnabled() bool {
+	r.featureFlagsMu.RLock()
+	defer r.featureFlagsMu.RUnlock()
+	return r.EnableNetworkPolicy
+}
+
+// isOperatorConfig returns true when the object is the operator config ConfigMap read at startup.
+func (r *LlamaStackDistributionReconciler) isOperatorConfig(obj client.Object) bool {
+	return r.operatorConfigName.Name != "" &&
+		obj.GetName() == r.operatorConfigName.Name && obj.GetNamespace() == r.operatorConfigName.Namespace
+}
+
+// reloadFeatureFlags parses again the feature flags of the operator config ConfigMap, and returns reconcile
+// requests for every LlamaStackDistribution when they changed. A deleted ConfigMap restores the default flags,
+// an invalid one keeps the current flags.
+func (r *LlamaStackDistributionReconciler) reloadFeatureFlags(ctx context.Context, _ client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx).WithValues("configMap", r.operatorConfigName)
+
+	configMap, err := getOperatorConfig(ctx, r.Client, r.operatorConfigName, false)
+	if err != nil {
+		logger.Error(err, "failed to reload the feature flags of the operator config")
+		return nil
+	}
+	enableNetworkPolicy, err := parseFeatureFlags(configMap.Data)
+	if err != nil {
+		logger.Error(err, "failed to reload the feature flags of the operator config, keeping the current flags")
+		return nil
+	}
+
+	r.featureFlagsMu.Lock()
+	changed := r.EnableNetworkPolicy != enableNetworkPolicy
+	r.EnableNetworkPolicy = enableNetworkPolicy
+	r.featureFlagsMu.Unlock()
+	if !changed {
+		return nil
+	}
+
+	logger.Info("Feature flags changed, reconciling all LlamaStackDistributions", "enableNetworkPolicy", enableNetworkPolicy)
+	llamaStacks := llamav1alpha1.LlamaStackDistributionList{}
+	if err := r.List(ctx, &llamaStacks); err != nil {
+		logger.Error(err, "failed to list LlamaStackDistributions after the feature flags changed")
+		return nil
+	}
+	return r.convertToReconcileRequests(llamaStacks)
+}
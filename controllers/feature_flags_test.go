@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/featureflags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReloadFeatureFlags(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+
+	configMapName := types.NamespacedName{Name: operatorConfigData, Namespace: "llama-stack-operator"}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: configMapName.Name, Namespace: configMapName.Namespace},
+		Data:       map[string]string{featureflags.FeatureFlagsKey: "enableNetworkPolicy:\n  enabled: true\n"},
+	}
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap, instance).Build()
+	r := &LlamaStackDistributionReconciler{Client: c, Scheme: scheme, operatorConfigName: configMapName}
+
+	assert.True(t, r.isOperatorConfig(configMap))
+	assert.False(t, r.isOperatorConfig(instance))
+
+	// Enabling the flag reconciles every instance
+	requests := r.reloadFeatureFlags(context.Background(), configMap)
+	assert.True(t, r.networkPolicyEnabled())
+	require.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: "llsd", Namespace: "default"}, requests[0].NamespacedName)
+
+	// Unchanged flags reconcile nothing
+	assert.Empty(t, r.reloadFeatureFlags(context.Background(), configMap))
+
+	// Invalid flags keep the current ones
+	configMap.Data[featureflags.FeatureFlagsKey] = "enableNetworkPolicy: ["
+	require.NoError(t, c.Update(context.Background(), configMap))
+	assert.Empty(t, r.reloadFeatureFlags(context.Background(), configMap))
+	assert.True(t, r.networkPolicyEnabled())
+
+	// A deleted ConfigMap restores the defaults
+	require.NoError(t, c.Delete(context.Background(), configMap))
+	assert.Len(t, r.reloadFeatureFlags(context.Background(), configMap), 1)
+	assert.Equal(t, featureflags.NetworkPolicyDefaultValue, r.networkPolicyEnabled())
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LlamaStackDistributionFinalizer holds the deletion of the LlamaStackDistributions until the state they left
+// outside of their owned resources is cleaned up. Owned resources are still deleted by garbage collection.
+const LlamaStackDistributionFinalizer = "llamastack.io/finalizer"
+
+// TeardownHook cleans up state of a deleted LlamaStackDistribution that garbage collection doesn't cover,
+// e.g. its registration in an external model gateway. A failing hook is retried with backoff, and holds the
+// deletion until it succeeds.
+type TeardownHook func(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error
+
+// ensureFinalizer adds the finalizer to the instance when its deletion needs a teardown, and removes it
+// otherwise, so that the instances with nothing to clean up can still be deleted once the operator is uninstalled.
+func (r *LlamaStackDistributionReconciler) ensureFinalizer(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	needed, err := r.needsTeardown(ctx, instance)
+	if err != nil {
+		return err
+	}
+	var changed bool
+	if needed {
+		changed = controllerutil.AddFinalizer(instance, LlamaStackDistributionFinalizer)
+	} else {
+		changed = controllerutil.RemoveFinalizer(instance, LlamaStackDistributionFinalizer)
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update finalizer: %w", err)
+	}
+	return nil
+}
+
+// needsTeardown returns whether the deletion of the instance needs a teardown, i.e. teardown hooks are configured
+// or cluster-scoped resources were rendered for it. The resources rendered by the first reconcile are only seen
+// by the next one, and the orphan sweep removes them if the instance is deleted in between.
+func (r *LlamaStackDistributionReconciler) needsTeardown(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) (bool, error) {
+	if len(r.TeardownHooks) > 0 {
+		return true, nil
+	}
+	bindings, err := r.listClusterScopedResources(ctx, instance)
+	if err != nil {
+		return false, err
+	}
+	return len(bindings.Items) > 0, nil
+}
+
+// finalize runs the teardown of a deleted instance, the cluster-scoped resources first and then the teardown
+// hooks in order, and removes the finalizer once they all succeeded.
+func (r *LlamaStackDistributionReconciler) finalize(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(instance, LlamaStackDistributionFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx)
+	logger.Info("LlamaStackDistribution is being deleted, running teardown")
+
+	hooks := append([]TeardownHook{r.deleteClusterScopedResources}, r.TeardownHooks...)
+	for _, hook := range hooks {
+		if err := hook(ctx, instance); err != nil {
+			r.recordEvent(instance, corev1.EventTypeWarning, reasonTeardownFailed, fmt.Sprintf("Teardown failed: %v", err))
+			return ctrl.Result{}, fmt.Errorf("failed to tear down LlamaStackDistribution: %w", err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(instance, LlamaStackDistributionFinalizer)
+	if err := r.Update(ctx, instance); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	logger.Info("Teardown complete, removed finalizer")
+	return ctrl.Result{}, nil
+}
+
+// deleteClusterScopedResources deletes the cluster-scoped resources rendered for the instance. They can't be
+// owned by the namespaced instance, so garbage collection leaves them behind, and only the orphan sweep would
+// eventually remove them.
+func (r *LlamaStackDistributionReconciler) deleteClusterScopedResources(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	bindings, err := r.listClusterScopedResources(ctx, instance)
+	if err != nil {
+		return err
+	}
+	for i := range bindings.Items {
+		if err := r.Delete(ctx, &bindings.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ClusterRoleBinding %s: %w", bindings.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// listClusterScopedResources lists the cluster-scoped resources rendered for the instance.
+func (r *LlamaStackDistributionReconciler) listClusterScopedResources(ctx context.Context,
+	instance *llamav1alpha1.LlamaStackDistribution) (*rbacv1.ClusterRoleBindingList, error) {
+	bindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.List(ctx, bindings, client.MatchingLabels{
+		deploy.ManagedByLabelKey:         deploy.ManagedByLabelValue,
+		deploy.InstanceLabelKey:          instance.Name,
+		deploy.InstanceNamespaceLabelKey: instance.Namespace,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+	return bindings, nil
+}
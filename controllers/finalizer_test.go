@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestFinalize(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "llsd-default-crb",
+			Labels: map[string]string{
+				deploy.ManagedByLabelKey:         deploy.ManagedByLabelValue,
+				deploy.InstanceLabelKey:          "llsd",
+				deploy.InstanceNamespaceLabelKey: "default",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, binding).Build()
+
+	hookErr := errors.New("gateway unavailable")
+	var calls int
+	recorder := record.NewFakeRecorder(1)
+	r := &LlamaStackDistributionReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: recorder,
+		TeardownHooks: []TeardownHook{func(context.Context, *llamav1alpha1.LlamaStackDistribution) error {
+			calls++
+			return hookErr
+		}},
+	}
+	ctx := context.Background()
+
+	require.NoError(t, r.ensureFinalizer(ctx, instance))
+	require.NoError(t, r.ensureFinalizer(ctx, instance), "adding the finalizer again is a no-op")
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(instance), instance))
+	assert.Equal(t, []string{LlamaStackDistributionFinalizer}, instance.Finalizers)
+
+	require.NoError(t, c.Delete(ctx, instance))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(instance), instance))
+	require.False(t, instance.DeletionTimestamp.IsZero())
+
+	// A failing hook holds the deletion
+	_, err := r.finalize(ctx, instance)
+	require.ErrorIs(t, err, hookErr)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "Warning TeardownFailed Teardown failed: gateway unavailable", <-recorder.Events)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(instance), instance))
+	assert.True(t, controllerutil.ContainsFinalizer(instance, LlamaStackDistributionFinalizer))
+
+	// Once the hooks succeed the finalizer is removed, releasing the instance
+	hookErr = nil
+	_, err = r.finalize(ctx, instance)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	err = c.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+	assert.True(t, k8serrors.IsNotFound(err), "the instance should be deleted, got %v", err)
+	err = c.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+	assert.True(t, k8serrors.IsNotFound(err), "the ClusterRoleBinding should be deleted, got %v", err)
+}
+
+func TestEnsureFinalizerOnlyWhenTeardownIsNeeded(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Finalizers = []string{LlamaStackDistributionFinalizer}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build()
+	r := &LlamaStackDistributionReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	// Without anything to clean up, the finalizer of an existing instance is removed
+	require.NoError(t, r.ensureFinalizer(ctx, instance))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(instance), instance))
+	assert.Empty(t, instance.Finalizers)
+
+	// A cluster-scoped resource rendered for the instance needs the finalizer
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "llsd-default-crb",
+			Labels: map[string]string{
+				deploy.ManagedByLabelKey:         deploy.ManagedByLabelValue,
+				deploy.InstanceLabelKey:          "llsd",
+				deploy.InstanceNamespaceLabelKey: "default",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+	}
+	require.NoError(t, c.Create(ctx, binding))
+	require.NoError(t, r.ensureFinalizer(ctx, instance))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(instance), instance))
+	assert.Equal(t, []string{LlamaStackDistributionFinalizer}, instance.Finalizers)
+	resourceVersion := instance.ResourceVersion
+	require.NoError(t, r.ensureFinalizer(ctx, instance))
+	assert.Equal(t, resourceVersion, instance.ResourceVersion, "keeping the finalizer doesn't update the instance")
+}
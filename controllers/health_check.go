@@ -0,0 +1,536 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// httpClientTimeout bounds every request made to the LlamaStack server.
+	httpClientTimeout = 5 * time.Second
+	// httpClientMaxIdleConnsPerHost keeps enough idle connections per server for the health, providers
+	// and version requests of a reconcile, so that the next reconcile reuses them.
+	httpClientMaxIdleConnsPerHost = 4
+	// maxDrainedBodyBytes bounds the unread response body drained to reuse a connection.
+	maxDrainedBodyBytes = 64 << 10
+	// maxProviderPages bounds the pages of the providers endpoint followed, against servers looping over pages.
+	maxProviderPages = 20
+	// defaultHealthCheckMaxAttempts is the number of times a failing server request is sent when not configured.
+	defaultHealthCheckMaxAttempts = 3
+	// healthCheckRetryBackoff is the delay before the first retry of a server request, doubled on each retry.
+	healthCheckRetryBackoff = 100 * time.Millisecond
+	// degradedRequeueInterval is how often a Degraded distribution is probed again when no health check
+	// interval is configured, so that it goes back to Ready once the server recovers.
+	degradedRequeueInterval = 30 * time.Second
+	// defaultHealthCheckMaxRedirects is the number of redirects followed when no limit is configured.
+	defaultHealthCheckMaxRedirects = 10
+	// defaultHealthCheckEndpoint is the path probed when no health endpoints are configured.
+	defaultHealthCheckEndpoint = "/v1/health"
+	// providersEndpoint is the path listing the providers of the server.
+	providersEndpoint = "/v1/providers"
+	// versionEndpoint is the path reporting the version of the server.
+	versionEndpoint = "/v1/version"
+	// modelsEndpoint is the path listing the models served by the server.
+	modelsEndpoint = "/v1/models"
+	// providerHealthError is the health status of a provider failing its own health check.
+	providerHealthError = "Error"
+	// reasonProviderAdded is the reason of the Event recorded when a provider appears in the distribution.
+	reasonProviderAdded = "ProviderAdded"
+	// reasonProviderRemoved is the reason of the Event recorded when a provider disappears from the distribution.
+	reasonProviderRemoved = "ProviderRemoved"
+)
+
+// clusterNoProxy lists the in-cluster domains that are always reached without the proxy,
+// in addition to the NO_PROXY environment variable.
+var clusterNoProxy = []string{".svc", ".cluster.local"}
+
+// newHTTPClient returns the client used to probe the LlamaStack servers. It is shared by all reconciles so
+// that they reuse connections; requests are bound by the client timeout and by their context. It honors the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables, but never proxies in-cluster Services.
+func newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = newProxyFunc(httpproxy.FromEnvironment())
+	transport.MaxIdleConnsPerHost = httpClientMaxIdleConnsPerHost
+	return &http.Client{Timeout: httpClientTimeout, Transport: transport}
+}
+
+// withHealthCheckTimeout returns the client with the request timeout of the instance, if configured. The copy
+// shares the transport of the client, so that the connections are still reused.
+func withHealthCheckTimeout(httpClient *http.Client, instance *llamav1alpha1.LlamaStackDistribution) *http.Client {
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck == nil || healthCheck.Timeout == nil || healthCheck.Timeout.Duration <= 0 {
+		return httpClient
+	}
+	timeoutClient := *httpClient
+	timeoutClient.Timeout = healthCheck.Timeout.Duration
+	return &timeoutClient
+}
+
+// withHealthCheckRetries returns the client retrying the failing requests of the instance. The copy shares the
+// transport of the client, so that the connections are still reused.
+func withHealthCheckRetries(httpClient *http.Client, instance *llamav1alpha1.LlamaStackDistribution) *http.Client {
+	attempts := defaultHealthCheckMaxAttempts
+	if healthCheck := instance.Spec.Server.HealthCheck; healthCheck != nil && healthCheck.MaxAttempts != nil {
+		attempts = int(*healthCheck.MaxAttempts)
+	}
+	if attempts <= 1 {
+		return httpClient
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	retryClient := *httpClient
+	retryClient.Transport = &retryTransport{base: base, attempts: attempts, backoff: healthCheckRetryBackoff}
+	return &retryClient
+}
+
+// retryTransport sends the requests again with exponential backoff when they fail to reach the server or are
+// answered with a 5xx status, so that a transient failure doesn't change the status. The retries stop with the
+// context of the request.
+type retryTransport struct {
+	base     http.RoundTripper
+	attempts int
+	backoff  time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		retryable := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt >= t.attempts || req.Context().Err() != nil || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		if resp != nil {
+			closeResponseBody(resp.Body)
+		}
+		log.FromContext(req.Context()).V(1).Info("retrying server request", "url", req.URL.Redacted(), "attempt", attempt, "error", err)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// getHealthCheckInterval returns how often a running distribution is probed again, zero when not configured.
+func getHealthCheckInterval(instance *llamav1alpha1.LlamaStackDistribution) time.Duration {
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck == nil || healthCheck.Interval == nil || healthCheck.Interval.Duration <= 0 {
+		return 0
+	}
+	return healthCheck.Interval.Duration
+}
+
+// getDegradedRequeue returns how soon a Degraded distribution is probed again, zero for the other phases.
+func getDegradedRequeue(instance *llamav1alpha1.LlamaStackDistribution) time.Duration {
+	if instance.Status.Phase != llamav1alpha1.LlamaStackDistributionPhaseDegraded {
+		return 0
+	}
+	if interval := getHealthCheckInterval(instance); interval > 0 {
+		return min(interval, degradedRequeueInterval)
+	}
+	return degradedRequeueInterval
+}
+
+// closeResponseBody drains and closes a response body, so that its connection goes back to the pool
+// even when the body was not read, e.g. for an unexpected status code.
+func closeResponseBody(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainedBodyBytes))
+	_ = body.Close()
+}
+
+// newProxyFunc returns a transport proxy function for the proxy config, with the in-cluster domains added to NO_PROXY.
+func newProxyFunc(config *httpproxy.Config) func(*http.Request) (*url.URL, error) {
+	noProxy := clusterNoProxy
+	if config.NoProxy != "" {
+		noProxy = append([]string{config.NoProxy}, clusterNoProxy...)
+	}
+	config.NoProxy = strings.Join(noProxy, ",")
+
+	proxyFunc := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// checkHealth probes every health endpoint of the instance, all of them must report a healthy status.
+// It returns an error when an endpoint can't be reached, and false with a message naming the unhealthy
+// endpoints otherwise.
+func (r *LlamaStackDistributionReconciler) checkHealth(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) (bool, string, error) {
+	endpoints := getHealthCheckEndpoints(instance)
+	var unhealthy []string
+	for _, endpoint := range endpoints {
+		healthy, err := r.checkHealthEndpoint(ctx, instance, endpoint)
+		if err != nil {
+			if len(endpoints) > 1 {
+				err = fmt.Errorf("failed to check health endpoint %s: %w", endpoint, err)
+			}
+			return false, "", err
+		}
+		if !healthy {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+
+	switch {
+	case len(unhealthy) == 0:
+		return true, "", nil
+	case len(endpoints) == 1:
+		return false, MessageHealthCheckFailed, nil
+	default:
+		return false, fmt.Sprintf("%s: %d/%d endpoints unhealthy: %s", MessageHealthCheckFailed,
+			len(unhealthy), len(endpoints), strings.Join(unhealthy, ", ")), nil
+	}
+}
+
+// getHealthCheckEndpoints returns the paths probed by the health check.
+func getHealthCheckEndpoints(instance *llamav1alpha1.LlamaStackDistribution) []string {
+	if healthCheck := instance.Spec.Server.HealthCheck; healthCheck != nil && len(healthCheck.Endpoints) > 0 {
+		return healthCheck.Endpoints
+	}
+	return []string{defaultHealthCheckEndpoint}
+}
+
+// getHealthCheckURL returns the URL of a health endpoint, on the health check port when one is configured.
+// Ports other than the server port are reached through the Service exposing them.
+func (r *LlamaStackDistributionReconciler) getHealthCheckURL(instance *llamav1alpha1.LlamaStackDistribution, endpoint string) *url.URL {
+	u := r.getServerURL(instance, endpoint)
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck == nil || healthCheck.Port == nil || *healthCheck.Port == deploy.GetServicePort(instance) {
+		return u
+	}
+
+	serviceName := deploy.GetServiceName(instance)
+	for _, port := range instance.GetPortsByExposure(llamav1alpha1.PortExposureInternal) {
+		if port.Port == *healthCheck.Port {
+			serviceName = deploy.GetInternalServiceName(instance)
+		}
+	}
+	u.Host = getServiceHost(serviceName, instance.Namespace, *healthCheck.Port)
+	return u
+}
+
+// validateHealthCheck checks that the health check port is a port of the server container, exposed by a Service.
+func validateHealthCheck(instance *llamav1alpha1.LlamaStackDistribution) error {
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck == nil || healthCheck.Port == nil {
+		return nil
+	}
+	for _, port := range getContainerPorts(instance) {
+		if port.ContainerPort == *healthCheck.Port {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to validate health check: port %d is not a port of the server container", *healthCheck.Port)
+}
+
+// checkHealthEndpoint makes an HTTP request to a health endpoint.
+// It returns an error when the endpoint can't be reached, and false when it reports an unhealthy status.
+func (r *LlamaStackDistributionReconciler) checkHealthEndpoint(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	endpoint string) (bool, error) {
+	logger := log.FromContext(ctx)
+	u := r.getHealthCheckURL(instance, endpoint)
+
+	req, err := newHealthCheckRequest(ctx, instance, u.String())
+	if err != nil {
+		return false, err
+	}
+
+	httpClient, err := r.getServerHTTPClient(ctx, instance)
+	if err != nil {
+		return false, err
+	}
+	// Copy the client so the redirect policy only applies to this instance's health check
+	healthClient := *httpClient
+	healthClient.CheckRedirect = getHealthCheckRedirectPolicy(instance)
+
+	resp, err := healthClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to make health check request: %w", err)
+	}
+	defer closeResponseBody(resp.Body)
+
+	if err := getAPIAuthError(endpoint, resp.StatusCode); err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		logger.Info("health endpoint reported unhealthy status", "endpoint", endpoint, "statusCode", resp.StatusCode,
+			"location", resp.Header.Get("Location"))
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// newHealthCheckRequest builds the health check request using the configured method.
+// GET is used by default, POST requests carry the configured static body.
+func newHealthCheckRequest(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution, url string) (*http.Request, error) {
+	method := http.MethodGet
+	var body io.Reader
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck != nil && healthCheck.Method != "" {
+		method = string(healthCheck.Method)
+	}
+	if method == http.MethodPost && healthCheck.Body != "" {
+		body = strings.NewReader(healthCheck.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create health check request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// getHealthCheckRedirectPolicy returns the redirect handling for the health check of an instance.
+// By default redirects are followed up to defaultHealthCheckMaxRedirects.
+func getHealthCheckRedirectPolicy(instance *llamav1alpha1.LlamaStackDistribution) func(*http.Request, []*http.Request) error {
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck != nil && healthCheck.RedirectPolicy == llamav1alpha1.RedirectPolicyReject {
+		// Return the redirect response itself so it is reported as unhealthy
+		return func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	maxRedirects := defaultHealthCheckMaxRedirects
+	if healthCheck != nil && healthCheck.MaxRedirects != nil {
+		maxRedirects = int(*healthCheck.MaxRedirects)
+	}
+	return func(_ *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("failed to follow redirects: stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+}
+
+// performHealthChecks probes the server once the deployment is ready and refreshes
+// the phase, health condition, providers, models and version accordingly.
+func (r *LlamaStackDistributionReconciler) performHealthChecks(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
+	logger := log.FromContext(ctx)
+	wasHealthy := IsConditionTrue(&instance.Status, ConditionTypeHealthCheck)
+
+	healthy, message, err := r.checkHealth(ctx, instance)
+	switch {
+	case isAPITokenError(err):
+		// The operator can't authenticate to the server at all, which no rollout or scale down will fix
+		logger.Info("health check wasn't sent", "error", err.Error())
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseDegraded
+		SetHealthCheckCondition(instance, false, fmt.Sprintf("Health check failed: %v", err))
+	case (err != nil || !healthy) && IsScalingDown(&instance.Status):
+		// Terminating replicas may still answer while the deployment scales down, keep the phase until it settles
+		logger.Info("health check failed while the deployment is scaling down", "error", err)
+		SetHealthCheckCondition(instance, false, "Health check failed while the deployment is scaling down")
+	case (err != nil || !healthy) && isWarmingUp(instance, time.Now()):
+		// Failures right after a rollout are expected while the new pods warm up
+		logger.Info("health check failed during the post-rollout warm-up", "error", err)
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
+		SetHealthCheckCondition(instance, false, "Health check failed during the post-rollout warm-up")
+	case isAPIAuthError(err):
+		// The server is up but rejects the credentials of the operator, so its health is unknown
+		logger.Info("health check was rejected", "error", err.Error())
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseDegraded
+		SetHealthCheckCondition(instance, false, fmt.Sprintf("Health check failed: %v", err))
+	case err != nil:
+		// The server may still be starting, keep waiting for it
+		logger.Error(err, "failed to check health")
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
+		SetHealthCheckCondition(instance, false, fmt.Sprintf("Health check failed: %v", err))
+	case !healthy:
+		// The server is up but reports unhealthy, Failed is kept for the errors of the reconcile
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseDegraded
+		SetHealthCheckCondition(instance, false, message)
+	default:
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+		SetHealthCheckCondition(instance, true, MessageHealthCheckPassed)
+	}
+	if condition := GetCondition(&instance.Status, ConditionTypeHealthCheck); wasHealthy && condition.Status != metav1.ConditionTrue {
+		r.recordEvent(instance, corev1.EventTypeWarning, reasonHealthCheckFailing, condition.Message)
+	}
+
+	providers, err := r.getProviderInfo(ctx, instance)
+	if err != nil {
+		// The last known providers are kept, the condition reports that their health is unknown
+		logger.Error(err, "failed to get provider info, keeping the last known provider list")
+		SetProvidersHealthyCondition(instance, false, fmt.Sprintf("Provider health is unknown: %v", err))
+		checkRequiredProviders(instance, nil, fmt.Sprintf("Required providers are unknown: %v", err))
+	} else {
+		r.recordProviderChanges(instance, instance.Status.DistributionConfig.Providers, providers)
+		instance.Status.DistributionConfig.Providers = providers
+		setProvidersHealth(instance, providers)
+		if instance.Status.Phase == llamav1alpha1.LlamaStackDistributionPhaseReady &&
+			IsConditionFalse(&instance.Status, ConditionTypeProvidersHealthy) {
+			instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseDegraded
+		}
+		checkRequiredProviders(instance, providers, "")
+	}
+
+	models, err := r.getModelInfo(ctx, instance)
+	if err != nil {
+		logger.Error(err, "failed to get model info, clearing model list")
+		instance.Status.DistributionConfig.Models = nil
+	} else {
+		instance.Status.DistributionConfig.Models = models
+	}
+
+	version, err := r.getVersionInfo(ctx, instance)
+	if err != nil {
+		logger.Error(err, "failed to get version info from API endpoint")
+		// Don't clear the version if we cant fetch it - keep the existing one
+	} else {
+		instance.Status.Version.LlamaStackServerVersion = version
+		logger.V(1).Info("Updated LlamaStack version from API endpoint", "version", version)
+		r.checkVersionSkew(ctx, instance, version)
+	}
+}
+
+// setProvidersHealth sets the providers healthy condition from the health reported by each provider, listing
+// the providers reporting an error. Providers that don't implement a health check are considered healthy.
+func setProvidersHealth(instance *llamav1alpha1.LlamaStackDistribution, providers []llamav1alpha1.ProviderInfo) {
+	var unhealthy []string
+	for _, provider := range providers {
+		if provider.Health.Status != providerHealthError {
+			continue
+		}
+		description := fmt.Sprintf("%s (%s)", provider.ProviderID, provider.API)
+		if provider.Health.Message != "" {
+			description += ": " + provider.Health.Message
+		}
+		unhealthy = append(unhealthy, description)
+	}
+	if len(unhealthy) == 0 {
+		SetProvidersHealthyCondition(instance, true, "")
+		return
+	}
+	SetProvidersHealthyCondition(instance, false, "Unhealthy providers: "+strings.Join(unhealthy, "; "))
+}
+
+// checkRequiredProviders sets the required providers available condition, listing the required providers the
+// server doesn't report, and keeps a healthy distribution Initializing until they are all available. When the
+// providers are unknown, unknownMessage is reported instead. The condition is not reported without required providers.
+func checkRequiredProviders(instance *llamav1alpha1.LlamaStackDistribution, providers []llamav1alpha1.ProviderInfo, unknownMessage string) {
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck == nil || len(healthCheck.RequiredProviders) == 0 {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, ConditionTypeRequiredProvidersAvailable)
+		return
+	}
+
+	message := unknownMessage
+	if message == "" {
+		available := make(map[string]bool, len(providers))
+		for _, provider := range providers {
+			available[provider.ProviderID] = true
+		}
+		var missing []string
+		for _, providerID := range healthCheck.RequiredProviders {
+			if !available[providerID] {
+				missing = append(missing, providerID)
+			}
+		}
+		if len(missing) == 0 {
+			SetRequiredProvidersAvailableCondition(instance, true, "")
+			return
+		}
+		message = "Missing required providers: " + strings.Join(missing, ", ")
+	}
+
+	SetRequiredProvidersAvailableCondition(instance, false, message)
+	if instance.Status.Phase == llamav1alpha1.LlamaStackDistributionPhaseReady {
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
+	}
+}
+
+// isWarmingUp returns true during the configured warm-up period following the last rollout.
+func isWarmingUp(instance *llamav1alpha1.LlamaStackDistribution, now time.Time) bool {
+	healthCheck := instance.Spec.Server.HealthCheck
+	if healthCheck == nil || healthCheck.WarmupPeriod == nil || instance.Status.Rollout == nil {
+		return false
+	}
+	return now.Before(instance.Status.Rollout.StartTime.Add(healthCheck.WarmupPeriod.Duration))
+}
+
+// recordProviderChanges emits an Event for every provider added to or removed from the distribution,
+// so that watchers can follow the provider composition without diffing the status on each reconcile.
+// The first observation of the providers, without a previous one, is not reported as additions.
+func (r *LlamaStackDistributionReconciler) recordProviderChanges(instance *llamav1alpha1.LlamaStackDistribution,
+	previous, current []llamav1alpha1.ProviderInfo) {
+	if r.Recorder == nil || previous == nil {
+		return
+	}
+
+	added, removed := diffProviders(previous, current)
+	for _, provider := range added {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, reasonProviderAdded,
+			"Provider %s (%s) added for API %s", provider.ProviderID, provider.ProviderType, provider.API)
+	}
+	for _, provider := range removed {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, reasonProviderRemoved,
+			"Provider %s (%s) removed for API %s", provider.ProviderID, provider.ProviderType, provider.API)
+	}
+}
+
+// diffProviders returns the providers of current missing from previous, and the providers of previous
+// missing from current. Providers are identified by their API and provider ID.
+func diffProviders(previous, current []llamav1alpha1.ProviderInfo) ([]llamav1alpha1.ProviderInfo, []llamav1alpha1.ProviderInfo) {
+	providerKey := func(p llamav1alpha1.ProviderInfo) string { return p.API + "/" + p.ProviderID }
+	missingFrom := func(providers, others []llamav1alpha1.ProviderInfo) []llamav1alpha1.ProviderInfo {
+		keys := make(map[string]struct{}, len(others))
+		for _, p := range others {
+			keys[providerKey(p)] = struct{}{}
+		}
+		var missing []llamav1alpha1.ProviderInfo
+		for _, p := range providers {
+			if _, ok := keys[providerKey(p)]; !ok {
+				missing = append(missing, p)
+			}
+		}
+		return missing
+	}
+	return missingFrom(current, previous), missingFrom(previous, current)
+}
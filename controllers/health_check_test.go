@@ -0,0 +1,672 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http/httpproxy"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+)
+
+// rewriteHostTransport sends every request to the test server, regardless of the in-cluster service host.
+type rewriteHostTransport struct {
+	target *url.URL
+	// base sends the rewritten requests, http.DefaultTransport when nil
+	base http.RoundTripper
+}
+
+func (t *rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	if t.base != nil {
+		return t.base.RoundTrip(req)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newHealthCheckTestReconciler(t *testing.T, handler http.Handler) *LlamaStackDistributionReconciler {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return &LlamaStackDistributionReconciler{
+		httpClient: &http.Client{Transport: &rewriteHostTransport{target: target}},
+	}
+}
+
+func newHealthCheckTestInstance(healthCheck *llamav1alpha1.HealthCheckSpec) *llamav1alpha1.LlamaStackDistribution {
+	return &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: "test-namespace",
+		},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				HealthCheck: healthCheck,
+			},
+		},
+	}
+}
+
+func TestCheckHealthRedirects(t *testing.T) {
+	// /v1/health redirects twice before reaching a healthy endpoint
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/health", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/gateway/health", http.StatusFound)
+	})
+	mux.HandleFunc("/gateway/health", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/real/health", http.StatusFound)
+	})
+	mux.HandleFunc("/real/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name          string
+		healthCheck   *llamav1alpha1.HealthCheckSpec
+		expectHealthy bool
+		expectError   bool
+	}{
+		{
+			name:          "follows redirects by default",
+			healthCheck:   nil,
+			expectHealthy: true,
+		},
+		{
+			name: "follows redirects within the limit",
+			healthCheck: &llamav1alpha1.HealthCheckSpec{
+				RedirectPolicy: llamav1alpha1.RedirectPolicyFollow,
+				MaxRedirects:   ptr.To(int32(2)),
+			},
+			expectHealthy: true,
+		},
+		{
+			name: "fails when exceeding the redirect limit",
+			healthCheck: &llamav1alpha1.HealthCheckSpec{
+				MaxRedirects: ptr.To(int32(1)),
+			},
+			expectError: true,
+		},
+		{
+			name: "treats redirects as unhealthy when rejected",
+			healthCheck: &llamav1alpha1.HealthCheckSpec{
+				RedirectPolicy: llamav1alpha1.RedirectPolicyReject,
+			},
+			expectHealthy: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newHealthCheckTestReconciler(t, mux)
+			healthy, _, err := r.checkHealth(context.Background(), newHealthCheckTestInstance(tc.healthCheck))
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectHealthy, healthy)
+		})
+	}
+}
+
+func TestCheckHealthMethod(t *testing.T) {
+	var gotMethod, gotBody, gotContentType string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		gotMethod, gotBody, gotContentType = r.Method, string(body), r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name                string
+		healthCheck         *llamav1alpha1.HealthCheckSpec
+		expectedMethod      string
+		expectedBody        string
+		expectedContentType string
+	}{
+		{
+			name:           "defaults to GET",
+			healthCheck:    nil,
+			expectedMethod: http.MethodGet,
+		},
+		{
+			name:           "HEAD",
+			healthCheck:    &llamav1alpha1.HealthCheckSpec{Method: llamav1alpha1.HealthCheckMethodHead},
+			expectedMethod: http.MethodHead,
+		},
+		{
+			name:           "POST without body",
+			healthCheck:    &llamav1alpha1.HealthCheckSpec{Method: llamav1alpha1.HealthCheckMethodPost},
+			expectedMethod: http.MethodPost,
+		},
+		{
+			name: "POST with static body",
+			healthCheck: &llamav1alpha1.HealthCheckSpec{
+				Method: llamav1alpha1.HealthCheckMethodPost,
+				Body:   `{"deep": true}`,
+			},
+			expectedMethod:      http.MethodPost,
+			expectedBody:        `{"deep": true}`,
+			expectedContentType: "application/json",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotMethod, gotBody, gotContentType = "", "", ""
+			r := newHealthCheckTestReconciler(t, handler)
+			healthy, _, err := r.checkHealth(context.Background(), newHealthCheckTestInstance(tc.healthCheck))
+			require.NoError(t, err)
+			assert.True(t, healthy)
+			assert.Equal(t, tc.expectedMethod, gotMethod)
+			assert.Equal(t, tc.expectedBody, gotBody)
+			assert.Equal(t, tc.expectedContentType, gotContentType)
+		})
+	}
+}
+
+func TestRecordProviderChanges(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{Recorder: recorder}
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "llsd", Namespace: "default"},
+	}
+	ollama := llamav1alpha1.ProviderInfo{API: "inference", ProviderID: "ollama", ProviderType: "remote::ollama"}
+	vllm := llamav1alpha1.ProviderInfo{API: "inference", ProviderID: "vllm", ProviderType: "remote::vllm"}
+	faiss := llamav1alpha1.ProviderInfo{API: "vector_io", ProviderID: "faiss", ProviderType: "inline::faiss"}
+
+	// The first observation has nothing to be compared with
+	r.recordProviderChanges(instance, nil, []llamav1alpha1.ProviderInfo{ollama, faiss})
+	assert.Empty(t, recorder.Events)
+
+	// Unchanged providers do not emit events, even when health or config differ
+	unhealthyOllama := ollama
+	unhealthyOllama.Health = llamav1alpha1.ProviderHealthStatus{Status: "Error"}
+	r.recordProviderChanges(instance, []llamav1alpha1.ProviderInfo{ollama}, []llamav1alpha1.ProviderInfo{unhealthyOllama})
+	assert.Empty(t, recorder.Events)
+
+	r.recordProviderChanges(instance, []llamav1alpha1.ProviderInfo{ollama, faiss}, []llamav1alpha1.ProviderInfo{ollama, vllm})
+	require.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Normal ProviderAdded Provider vllm (remote::vllm) added for API inference", <-recorder.Events)
+	assert.Equal(t, "Normal ProviderRemoved Provider faiss (inline::faiss) removed for API vector_io", <-recorder.Events)
+}
+
+func TestProviderEventsAcrossUnavailableServer(t *testing.T) {
+	available := true
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/health", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"status": "OK"}`)
+	})
+	mux.HandleFunc("/v1/providers", func(w http.ResponseWriter, _ *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data": [{"api": "inference", "provider_id": "ollama", "health": {"status": "OK"}}]}`)
+	})
+	recorder := record.NewFakeRecorder(10)
+	r := newHealthCheckTestReconciler(t, mux)
+	r.Recorder = recorder
+	ctx := context.Background()
+	instance := newHealthCheckTestInstance(nil)
+
+	// The first observation isn't reported as additions
+	r.performHealthChecks(ctx, instance)
+	require.Len(t, instance.Status.DistributionConfig.Providers, 1)
+	assert.Empty(t, recorder.Events)
+
+	// The last known providers are kept while they can't be listed, so the recovery emits no Events
+	available = false
+	r.performHealthChecks(ctx, instance)
+	require.Len(t, instance.Status.DistributionConfig.Providers, 1)
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeProvidersHealthy))
+	available = true
+	r.performHealthChecks(ctx, instance)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeProvidersHealthy))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestNewProxyFunc(t *testing.T) {
+	proxyFunc := newProxyFunc(&httpproxy.Config{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    "internal.example.com",
+	})
+
+	tests := []struct {
+		url          string
+		expectsProxy bool
+	}{
+		{url: "http://llsd-service.default.svc.cluster.local:8321/v1/health", expectsProxy: false},
+		{url: "http://llsd-service.default.svc:8321/v1/health", expectsProxy: false},
+		{url: "https://api.internal.example.com/v1/models", expectsProxy: false},
+		{url: "https://inference.example.org/v1/models", expectsProxy: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			proxyURL, err := proxyFunc(req)
+			require.NoError(t, err)
+			if tc.expectsProxy {
+				require.NotNil(t, proxyURL)
+				assert.Equal(t, "proxy.example.com:3128", proxyURL.Host)
+			} else {
+				assert.Nil(t, proxyURL)
+			}
+		})
+	}
+}
+
+func TestPerformHealthChecksWhileScalingDown(t *testing.T) {
+	r := newHealthCheckTestReconciler(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	instance := newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{MaxAttempts: ptr.To(int32(1))})
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	SetDeploymentScalingDownCondition(instance, "Deployment is scaling down: 3 replicas running, 1 desired")
+
+	r.performHealthChecks(context.Background(), instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseReady, instance.Status.Phase,
+		"a failed health check should not fail the instance while scaling down")
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeHealthCheck))
+
+	SetDeploymentReadyCondition(instance, true, MessageDeploymentReady)
+	r.performHealthChecks(context.Background(), instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseDegraded, instance.Status.Phase,
+		"a failed health check should degrade the instance once the deployment settled")
+}
+
+func TestPerformHealthChecksDuringWarmup(t *testing.T) {
+	r := newHealthCheckTestReconciler(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	instance := newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{
+		WarmupPeriod: &metav1.Duration{Duration: time.Minute},
+		MaxAttempts:  ptr.To(int32(1)),
+	})
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "llama-stack", Image: "test-image:1"}}
+	require.NoError(t, trackRollout(instance, deployment, time.Now()))
+
+	r.performHealthChecks(context.Background(), instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseInitializing, instance.Status.Phase,
+		"a failed health check should be expected warm-up right after a rollout")
+
+	// The same template keeps the rollout start, so the window ends
+	instance.Status.Rollout.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	require.NoError(t, trackRollout(instance, deployment, time.Now()))
+	r.performHealthChecks(context.Background(), instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseDegraded, instance.Status.Phase,
+		"a failed health check should degrade the instance after the warm-up period")
+
+	// A new template starts a new window
+	deployment.Spec.Template.Spec.Containers[0].Image = "test-image:2"
+	require.NoError(t, trackRollout(instance, deployment, time.Now()))
+	r.performHealthChecks(context.Background(), instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseInitializing, instance.Status.Phase)
+}
+
+func TestCheckHealthEndpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/v1/inference/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	r := newHealthCheckTestReconciler(t, mux)
+
+	tests := []struct {
+		name            string
+		endpoints       []string
+		expectedHealthy bool
+		expectedMessage string
+	}{
+		{
+			name:            "defaults to /v1/health",
+			expectedHealthy: true,
+		},
+		{
+			name:            "all endpoints healthy",
+			endpoints:       []string{"/v1/health"},
+			expectedHealthy: true,
+		},
+		{
+			name:            "single unhealthy endpoint",
+			endpoints:       []string{"/v1/inference/health"},
+			expectedMessage: MessageHealthCheckFailed,
+		},
+		{
+			name:            "one of several endpoints unhealthy",
+			endpoints:       []string{"/v1/health", "/v1/inference/health"},
+			expectedMessage: "Health check failed: 1/2 endpoints unhealthy: /v1/inference/health",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{Endpoints: tc.endpoints})
+			healthy, message, err := r.checkHealth(context.Background(), instance)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHealthy, healthy)
+			assert.Equal(t, tc.expectedMessage, message)
+		})
+	}
+}
+
+func TestGetHealthCheckURL(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{}
+	instance := newHealthCheckTestInstance(nil)
+	assert.Equal(t, "http://test-instance-service.test-namespace.svc.cluster.local:8321/v1/health",
+		r.getHealthCheckURL(instance, defaultHealthCheckEndpoint).String())
+
+	instance.Spec.Server.ContainerSpec.Ports = []llamav1alpha1.PortSpec{
+		{Name: "admin", Port: 9000, Exposure: llamav1alpha1.PortExposureInternal},
+		{Name: "metrics", Port: 9090},
+	}
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Port: ptr.To(int32(9000))}
+	require.NoError(t, validateHealthCheck(instance))
+	assert.Equal(t, "http://test-instance-internal-service.test-namespace.svc.cluster.local:9000/healthz",
+		r.getHealthCheckURL(instance, "/healthz").String())
+
+	instance.Spec.Server.HealthCheck.Port = ptr.To(int32(9090))
+	assert.Equal(t, "http://test-instance-service.test-namespace.svc.cluster.local:9090/healthz",
+		r.getHealthCheckURL(instance, "/healthz").String())
+
+	instance.Spec.Server.HealthCheck.Port = ptr.To(int32(9999))
+	require.EqualError(t, validateHealthCheck(instance), "failed to validate health check: port 9999 is not a port of the server container")
+}
+
+func TestHTTPClientReusesConnections(t *testing.T) {
+	var newConnections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// An unhealthy status with a body the health check doesn't read
+		http.Error(w, "starting", http.StatusServiceUnavailable)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			newConnections.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	client := newHTTPClient()
+	client.Transport = &rewriteHostTransport{target: target, base: client.Transport}
+	r := &LlamaStackDistributionReconciler{httpClient: client}
+	instance := newHealthCheckTestInstance(nil)
+
+	for range 3 {
+		healthy, _, err := r.checkHealth(context.Background(), instance)
+		require.NoError(t, err)
+		assert.False(t, healthy)
+	}
+	assert.Equal(t, int32(1), newConnections.Load(), "health checks should reuse the connection")
+
+	// The shared client still honors the context of each request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = r.checkHealth(ctx, instance)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPerformHealthChecksUnhealthyProviders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/health", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"status": "OK"}`)
+	})
+	mux.HandleFunc("/v1/providers", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"data": [{"api": "inference", "provider_id": "ollama", "health": {"status": "Error"}}]}`)
+	})
+	r := newHealthCheckTestReconciler(t, mux)
+
+	instance := newHealthCheckTestInstance(nil)
+	r.performHealthChecks(context.Background(), instance)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseDegraded, instance.Status.Phase,
+		"a healthy server with unhealthy providers should be degraded")
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeHealthCheck))
+	assert.Equal(t, degradedRequeueInterval, getDegradedRequeue(instance))
+}
+
+func TestGetProviderInfoPagination(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/providers", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Get("cursor") == "page-2":
+			_, _ = io.WriteString(w, `{"data": [{"api": "safety", "provider_id": "llama-guard"}], "next": "/v1/providers?page=3"}`)
+		case r.URL.Query().Get("page") == "3":
+			_, _ = io.WriteString(w, `{"data": [{"api": "agents", "provider_id": "meta-reference"}]}`)
+		default:
+			_, _ = io.WriteString(w, `{"data": [{"api": "inference", "provider_id": "ollama"}], "next_cursor": "page-2"}`)
+		}
+	})
+	mux.HandleFunc("/v1/foreign/providers", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"data": [], "next": "http://other.example.com/v1/providers"}`)
+	})
+	r := newHealthCheckTestReconciler(t, mux)
+
+	providers, err := r.getProviderInfo(context.Background(), newHealthCheckTestInstance(nil))
+	require.NoError(t, err)
+	ids := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		ids = append(ids, provider.ProviderID)
+	}
+	assert.Equal(t, []string{"ollama", "llama-guard", "meta-reference"}, ids)
+
+	// A server looping over pages is cut off at the page limit
+	loop := newHealthCheckTestReconciler(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"data": [{"api": "inference", "provider_id": "ollama"}], "next": "/v1/providers"}`)
+	}))
+	providers, err = loop.getProviderInfo(context.Background(), newHealthCheckTestInstance(nil))
+	require.NoError(t, err)
+	assert.Len(t, providers, maxProviderPages)
+
+	// Next pages on another host are not followed
+	u := r.getServerURL(newHealthCheckTestInstance(nil), "/v1/foreign/providers")
+	response, err := r.getProviderPage(context.Background(), r.httpClient, u)
+	require.NoError(t, err)
+	_, err = getNextProviderPage(u, response)
+	require.Error(t, err)
+}
+
+func TestGetModelInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"data": [
+			{"identifier": "llama3.2:1b", "provider_id": "ollama", "provider_resource_id": "llama3.2:1b", "model_type": "llm", "type": "model"},
+			{"identifier": "all-MiniLM-L6-v2", "provider_id": "sentence-transformers", "model_type": "embedding"}
+		]}`)
+	})
+	r := newHealthCheckTestReconciler(t, mux)
+
+	models, err := r.getModelInfo(context.Background(), newHealthCheckTestInstance(nil))
+	require.NoError(t, err)
+	assert.Equal(t, []llamav1alpha1.ModelInfo{
+		{Identifier: "llama3.2:1b", ProviderID: "ollama", ProviderResourceID: "llama3.2:1b", ModelType: "llm"},
+		{Identifier: "all-MiniLM-L6-v2", ProviderID: "sentence-transformers", ModelType: "embedding"},
+	}, models)
+
+	// Servers without the models endpoint fail the request
+	missing := newHealthCheckTestReconciler(t, http.NotFoundHandler())
+	_, err = missing.getModelInfo(context.Background(), newHealthCheckTestInstance(nil))
+	require.Error(t, err)
+}
+
+func TestSetProvidersHealth(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	status := &instance.Status
+	providers := []llamav1alpha1.ProviderInfo{
+		{API: "inference", ProviderID: "ollama", Health: llamav1alpha1.ProviderHealthStatus{Status: "OK"}},
+		{API: "vector_io", ProviderID: "faiss", Health: llamav1alpha1.ProviderHealthStatus{Status: "Not Implemented"}},
+	}
+	setProvidersHealth(instance, providers)
+	assert.True(t, IsConditionTrue(status, ConditionTypeProvidersHealthy))
+
+	providers = append(providers,
+		llamav1alpha1.ProviderInfo{API: "vector_io", ProviderID: "milvus", Health: llamav1alpha1.ProviderHealthStatus{
+			Status: "Error", Message: "connection refused",
+		}},
+		llamav1alpha1.ProviderInfo{API: "safety", ProviderID: "llama-guard", Health: llamav1alpha1.ProviderHealthStatus{Status: "Error"}},
+	)
+	setProvidersHealth(instance, providers)
+	condition := GetCondition(status, ConditionTypeProvidersHealthy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonProvidersUnhealthy, condition.Reason)
+	assert.Equal(t, "Unhealthy providers: milvus (vector_io): connection refused; llama-guard (safety)", condition.Message)
+}
+
+func TestCheckRequiredProviders(t *testing.T) {
+	providers := []llamav1alpha1.ProviderInfo{{API: "inference", ProviderID: "ollama"}, {API: "vector_io", ProviderID: "faiss"}}
+	instance := newHealthCheckTestInstance(nil)
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	checkRequiredProviders(instance, providers, "")
+	assert.Nil(t, GetCondition(&instance.Status, ConditionTypeRequiredProvidersAvailable), "the condition is only reported with required providers")
+
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{RequiredProviders: []string{"ollama", "vllm", "milvus"}}
+	checkRequiredProviders(instance, providers, "")
+	condition := GetCondition(&instance.Status, ConditionTypeRequiredProvidersAvailable)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonRequiredProvidersMissing, condition.Reason)
+	assert.Equal(t, "Missing required providers: vllm, milvus", condition.Message)
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseInitializing, instance.Status.Phase, "the distribution is not Ready without its providers")
+
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	checkRequiredProviders(instance, nil, "Required providers are unknown: connection refused")
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeRequiredProvidersAvailable))
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseInitializing, instance.Status.Phase)
+
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	instance.Spec.Server.HealthCheck.RequiredProviders = []string{"ollama"}
+	checkRequiredProviders(instance, providers, "")
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeRequiredProvidersAvailable))
+	assert.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseReady, instance.Status.Phase)
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+	r := newHealthCheckTestReconciler(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	healthy, _, err := r.checkHealth(context.Background(), newHealthCheckTestInstance(nil))
+	require.NoError(t, err)
+	assert.True(t, healthy)
+
+	instance := newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{Timeout: &metav1.Duration{Duration: 50 * time.Millisecond}})
+	_, _, err = r.checkHealth(context.Background(), instance)
+	require.Error(t, err, "the request should time out")
+
+	// The timeout doesn't change the shared client and keeps its connections
+	httpClient, err := r.getServerHTTPClient(context.Background(), instance)
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, httpClient.Timeout)
+	transport, ok := httpClient.Transport.(*retryTransport)
+	require.True(t, ok, "requests should be retried by default")
+	assert.Same(t, r.httpClient.Transport, transport.base)
+	assert.Zero(t, r.httpClient.Timeout)
+}
+
+func TestGetHealthCheckInterval(t *testing.T) {
+	assert.Zero(t, getHealthCheckInterval(newHealthCheckTestInstance(nil)))
+	assert.Zero(t, getHealthCheckInterval(newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{})))
+	instance := newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{Interval: &metav1.Duration{Duration: time.Minute}})
+	assert.Equal(t, time.Minute, getHealthCheckInterval(instance))
+}
+
+func TestGetDegradedRequeue(t *testing.T) {
+	instance := newHealthCheckTestInstance(nil)
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	assert.Zero(t, getDegradedRequeue(instance))
+
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseDegraded
+	assert.Equal(t, degradedRequeueInterval, getDegradedRequeue(instance))
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Interval: &metav1.Duration{Duration: 10 * time.Second}}
+	assert.Equal(t, 10*time.Second, getDegradedRequeue(instance))
+	instance.Spec.Server.HealthCheck.Interval.Duration = time.Hour
+	assert.Equal(t, degradedRequeueInterval, getDegradedRequeue(instance), "a Degraded distribution is probed at least every 30s")
+}
+
+func TestHealthCheckRetries(t *testing.T) {
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/health", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"probe": true}`, string(body), "retries should resend the request body")
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	r := newHealthCheckTestReconciler(t, mux)
+	instance := newHealthCheckTestInstance(&llamav1alpha1.HealthCheckSpec{Method: llamav1alpha1.HealthCheckMethodPost, Body: `{"probe": true}`})
+
+	// The server fails twice then succeeds, within the default attempts
+	healthy, _, err := r.checkHealth(context.Background(), instance)
+	require.NoError(t, err)
+	assert.True(t, healthy)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Without retries the first failure is reported
+	requests.Store(0)
+	instance.Spec.Server.HealthCheck.MaxAttempts = ptr.To(int32(1))
+	healthy, _, err = r.checkHealth(context.Background(), instance)
+	require.NoError(t, err)
+	assert.False(t, healthy)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// The backoff stops with the context
+	requests.Store(0)
+	instance.Spec.Server.HealthCheck.MaxAttempts = ptr.To(int32(10))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err = r.checkHealth(ctx, instance)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, requests.Load(), int32(10))
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// imageDigestCheckInterval bounds how often the registry is asked whether the tag of a pinned image moved.
+const imageDigestCheckInterval = 10 * time.Minute
+
+// imageDigestCache holds the digests last resolved for each image, so that the registry is not queried
+// on every reconcile.
+type imageDigestCache struct {
+	mu      sync.Mutex
+	digests map[string]cachedImageDigest
+}
+
+type cachedImageDigest struct {
+	digest     string
+	resolvedAt time.Time
+}
+
+// get returns the digest of the image resolved less than maxAge ago, if any.
+func (c *imageDigestCache) get(image string, maxAge time.Duration, now time.Time) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.digests[image]
+	if !ok || now.Sub(cached.resolvedAt) >= maxAge {
+		return "", false
+	}
+	return cached.digest, true
+}
+
+// set records the digest resolved for the image.
+func (c *imageDigestCache) set(image, digest string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.digests == nil {
+		c.digests = map[string]cachedImageDigest{}
+	}
+	c.digests[image] = cachedImageDigest{digest: digest, resolvedAt: now}
+}
+
+// resolveImageDigest returns the digest the tag of the image points to, resolved less than maxAge ago.
+func (r *LlamaStackDistributionReconciler) resolveImageDigest(ctx context.Context, image string, maxAge time.Duration) (string, error) {
+	now := time.Now()
+	if digest, ok := r.imageDigests.get(image, maxAge, now); ok {
+		return digest, nil
+	}
+	if r.ImageDigestResolver == nil {
+		return "", errors.New("failed to resolve image digest: no digest resolver configured")
+	}
+	digest, err := r.ImageDigestResolver.ResolveDigest(ctx, image)
+	if err != nil {
+		return "", err
+	}
+	r.imageDigests.set(image, digest, now)
+	return digest, nil
+}
+
+// pinImageDigest returns the server image pinned to its digest when the distribution uses digests. The digest
+// is resolved when the image is first deployed and recorded in the status, so that the pods keep running it
+// when the tag moves. Once pinned, a moved tag is only reported, with an Event and in the status.
+func (r *LlamaStackDistributionReconciler) pinImageDigest(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	image string) (string, error) {
+	config := &instance.Status.DistributionConfig
+	if !instance.Spec.Server.Distribution.UseDigest || strings.Contains(image, "@") {
+		config.PinnedImage, config.ImageDigest, config.LatestImageDigest = "", "", ""
+		return image, nil
+	}
+
+	if config.PinnedImage != image || config.ImageDigest == "" {
+		// The image changed, pin the digest its tag points to now
+		digest, err := r.resolveImageDigest(ctx, image, 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to pin image %s to its digest: %w", image, err)
+		}
+		config.PinnedImage, config.ImageDigest, config.LatestImageDigest = image, digest, ""
+		return registry.PinDigest(image, digest), nil
+	}
+
+	r.checkImageDigest(ctx, instance, image)
+	return getPinnedImage(instance, image), nil
+}
+
+// getPinnedImage returns the image pinned to the digest recorded in the status, or the image itself when it
+// isn't pinned yet.
+func getPinnedImage(instance *llamav1alpha1.LlamaStackDistribution, image string) string {
+	config := instance.Status.DistributionConfig
+	if !instance.Spec.Server.Distribution.UseDigest || strings.Contains(image, "@") ||
+		config.PinnedImage != image || config.ImageDigest == "" {
+		return image
+	}
+	return registry.PinDigest(image, config.ImageDigest)
+}
+
+// checkImageDigest records the digest the tag of the pinned image points to when it moved, and emits an
+// Event the first time the tag is seen on a new digest.
+func (r *LlamaStackDistributionReconciler) checkImageDigest(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution, image string) {
+	config := &instance.Status.DistributionConfig
+	latest, err := r.resolveImageDigest(ctx, image, imageDigestCheckInterval)
+	if err != nil {
+		// The pinned digest is still deployed, the check is retried on a later reconcile
+		log.FromContext(ctx).Error(err, "failed to check whether the tag of the pinned image moved", "image", image)
+		return
+	}
+
+	if latest == config.ImageDigest {
+		config.LatestImageDigest = ""
+		return
+	}
+	if latest != config.LatestImageDigest {
+		r.recordEvent(instance, corev1.EventTypeNormal, reasonImageDigestChanged,
+			fmt.Sprintf("Image %s moved to digest %s, the Deployment stays pinned to %s", image, latest, config.ImageDigest))
+	}
+	config.LatestImageDigest = latest
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+)
+
+// fakeDigestResolver resolves every image to its digest and counts the resolutions.
+type fakeDigestResolver struct {
+	digest string
+	err    error
+	calls  int
+}
+
+func (f *fakeDigestResolver) ResolveDigest(_ context.Context, _ string) (string, error) {
+	f.calls++
+	return f.digest, f.err
+}
+
+func TestPinImageDigest(t *testing.T) {
+	const image = "quay.io/org/llama-stack:latest"
+	resolver := &fakeDigestResolver{digest: "sha256:aaa"}
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{ImageDigestResolver: resolver, Recorder: recorder}
+	instance := createLSD("", image)
+
+	pinned, err := r.pinImageDigest(context.Background(), instance, image)
+	require.NoError(t, err)
+	assert.Equal(t, image, pinned, "digests are opt-in")
+	assert.Zero(t, resolver.calls)
+
+	instance.Spec.Server.Distribution.UseDigest = true
+	pinned, err = r.pinImageDigest(context.Background(), instance, image)
+	require.NoError(t, err)
+	assert.Equal(t, image+"@sha256:aaa", pinned)
+	assert.Equal(t, image, instance.Status.DistributionConfig.PinnedImage)
+	assert.Equal(t, "sha256:aaa", instance.Status.DistributionConfig.ImageDigest)
+
+	// The tag moved, the image stays pinned and the new digest is reported once
+	resolver.digest = "sha256:bbb"
+	r.imageDigests.set(image, "sha256:bbb", time.Now())
+	for range 2 {
+		pinned, err = r.pinImageDigest(context.Background(), instance, image)
+		require.NoError(t, err)
+		assert.Equal(t, image+"@sha256:aaa", pinned)
+	}
+	assert.Equal(t, "sha256:bbb", instance.Status.DistributionConfig.LatestImageDigest)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Normal ImageDigestChanged")
+	assert.Equal(t, 1, resolver.calls, "the cached digest is used")
+
+	// A failing check keeps the pinned digest
+	resolver.err = errors.New("registry unavailable")
+	r.imageDigests = imageDigestCache{}
+	pinned, err = r.pinImageDigest(context.Background(), instance, image)
+	require.NoError(t, err)
+	assert.Equal(t, image+"@sha256:aaa", pinned)
+
+	// A new image is pinned again, and fails when its digest can't be resolved
+	_, err = r.pinImageDigest(context.Background(), instance, "quay.io/org/llama-stack:1.0")
+	require.ErrorContains(t, err, "failed to pin image quay.io/org/llama-stack:1.0 to its digest")
+
+	// Images referenced by digest are used as is
+	pinned, err = r.pinImageDigest(context.Background(), instance, "quay.io/org/llama-stack@sha256:ccc")
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/org/llama-stack@sha256:ccc", pinned)
+	assert.Empty(t, instance.Status.DistributionConfig.ImageDigest)
+}
+
+func TestGetPinnedImage(t *testing.T) {
+	const image = "quay.io/org/llama-stack:latest"
+	instance := createLSD("", image)
+	instance.Status.DistributionConfig.PinnedImage = image
+	instance.Status.DistributionConfig.ImageDigest = "sha256:aaa"
+	assert.Equal(t, image, getPinnedImage(instance, image), "digests are opt-in")
+
+	instance.Spec.Server.Distribution.UseDigest = true
+	assert.Equal(t, image+"@sha256:aaa", getPinnedImage(instance, image))
+	assert.Equal(t, "quay.io/org/llama-stack:1.0", getPinnedImage(instance, "quay.io/org/llama-stack:1.0"),
+		"an image that isn't pinned yet is used as is")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultIngressPath is the path of the Ingress rule when none is configured.
+const defaultIngressPath = "/"
+
+// reconcileIngress creates or updates the Ingress exposing the server Service when one is requested, and
+// deletes it otherwise.
+func (r *LlamaStackDistributionReconciler) reconcileIngress(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	if instance.Spec.Server.Ingress == nil {
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+		return deploy.DeleteIfControlled(ctx, r.Client, instance, ingress, log.FromContext(ctx))
+	}
+	return deploy.ApplyIngress(ctx, r.Client, r.Scheme, instance, buildIngress(instance), log.FromContext(ctx))
+}
+
+// buildIngress returns the Ingress sending the requests matching its rule to the server port of the server Service.
+func buildIngress(instance *llamav1alpha1.LlamaStackDistribution) *networkingv1.Ingress {
+	ingressSpec := instance.Spec.Server.Ingress
+	path := ingressSpec.Path
+	if path == "" {
+		path = defaultIngressPath
+	}
+
+	rule := networkingv1.IngressRule{
+		Host: ingressSpec.Host,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{{
+					Path:     path,
+					PathType: ptr.To(ptr.Deref(ingressSpec.PathType, networkingv1.PathTypePrefix)),
+					Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: deploy.GetServiceName(instance),
+							Port: networkingv1.ServiceBackendPort{Name: llamav1alpha1.DefaultServicePortName},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+			Labels: deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+				deploy.InstanceLabelKey: instance.Name,
+			}),
+			Annotations: deploy.MergeLabels(instance.Spec.Annotations, ingressSpec.Annotations),
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ingressSpec.IngressClassName,
+			Rules:            []networkingv1.IngressRule{rule},
+		},
+	}
+	if ingressSpec.TLS != nil {
+		tls := networkingv1.IngressTLS{SecretName: ingressSpec.TLS.SecretName}
+		if ingressSpec.Host != "" {
+			tls.Hosts = []string{ingressSpec.Host}
+		}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+	return ingress
+}
+
+// updateIngressStatus reports whether the ingress controller assigned an address to the Ingress.
+func (r *LlamaStackDistributionReconciler) updateIngressStatus(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
+	if instance.Spec.Server.Ingress == nil {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, ConditionTypeIngressReady)
+		return
+	}
+
+	ingress := &networkingv1.Ingress{}
+	if err := r.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, ingress); err != nil {
+		SetIngressReadyCondition(instance, false, fmt.Sprintf("Failed to get Ingress: %v", err))
+		return
+	}
+	if len(ingress.Status.LoadBalancer.Ingress) == 0 {
+		SetIngressReadyCondition(instance, false, "Waiting for the ingress controller to assign an address to the Ingress")
+		return
+	}
+	SetIngressReadyCondition(instance, true, "")
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildIngress(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.Ingress = &llamav1alpha1.IngressSpec{}
+
+	ingress := buildIngress(instance)
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Empty(t, ingress.Spec.TLS)
+	require.Len(t, ingress.Spec.Rules, 1)
+	assert.Empty(t, ingress.Spec.Rules[0].Host)
+	require.Len(t, ingress.Spec.Rules[0].HTTP.Paths, 1)
+	path := ingress.Spec.Rules[0].HTTP.Paths[0]
+	assert.Equal(t, "/", path.Path)
+	assert.Equal(t, networkingv1.PathTypePrefix, ptr.Deref(path.PathType, ""))
+	assert.Equal(t, &networkingv1.IngressServiceBackend{
+		Name: "llsd-service",
+		Port: networkingv1.ServiceBackendPort{Name: llamav1alpha1.DefaultServicePortName},
+	}, path.Backend.Service)
+	assert.NotContains(t, (&LlamaStackDistributionReconciler{}).determineKindsToExclude(instance), "Service", "the Ingress needs the Service")
+
+	instance.Spec.Server.Ingress = &llamav1alpha1.IngressSpec{
+		Host:             "llsd.example.com",
+		IngressClassName: ptr.To("nginx"),
+		Annotations:      map[string]string{"nginx.ingress.kubernetes.io/proxy-read-timeout": "600"},
+		Path:             "/llama",
+		PathType:         ptr.To(networkingv1.PathTypeExact),
+		TLS:              &llamav1alpha1.IngressTLSSpec{SecretName: "llsd-tls"},
+	}
+	ingress = buildIngress(instance)
+	assert.Equal(t, "nginx", ptr.Deref(ingress.Spec.IngressClassName, ""))
+	assert.Equal(t, "600", ingress.Annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"])
+	assert.Equal(t, "llsd.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/llama", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, networkingv1.PathTypeExact, ptr.Deref(ingress.Spec.Rules[0].HTTP.Paths[0].PathType, ""))
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"llsd.example.com"}, SecretName: "llsd-tls"}}, ingress.Spec.TLS)
+}
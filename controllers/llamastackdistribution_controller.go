@@ -22,27 +22,40 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/audit"
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/cluster"
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/featureflags"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/registry"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -50,8 +63,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/kustomize/api/resmap"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
 
@@ -59,6 +74,10 @@ const (
 	operatorConfigData = "llama-stack-operator-config"
 	manifestsBasePath  = "manifests/base"
 
+	// ControllerName is the name the controller is registered under. It is used as the
+	// "controller" label on controller_runtime_* metrics and the "name" label on workqueue_* metrics.
+	ControllerName = "llamastackdistribution"
+
 	// CA Bundle related constants.
 	DefaultCABundleKey    = "ca-bundle.crt"
 	CABundleMountPath     = "/etc/ssl/certs/ca-bundle.crt"
@@ -69,8 +88,19 @@ const (
 	CABundleSourceVolName = "ca-bundle-source"
 	CABundleTempDir       = "/tmp/ca-bundle"
 
+	// Storage volume constants.
+	storageVolumeName          = "lls-storage"
+	storagePermissionsInitName = "update-pvc-permissions"
+
+	// Shared memory volume constants.
+	SharedMemoryVolumeName = "dshm"
+	SharedMemoryMountPath  = "/dev/shm"
+
 	// ODH/RHOAI well-known ConfigMap for trusted CA bundles.
 	odhTrustedCABundleConfigMap = "odh-trusted-ca-bundle"
+
+	// defaultStorageClassAnnotation marks the cluster default StorageClass.
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
 )
 
 // LlamaStackDistributionReconciler reconciles a LlamaStack object.
@@ -83,11 +113,47 @@ const (
 type LlamaStackDistributionReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
-	// Feature flags
+	// APIReader reads the objects the manager doesn't cache, the Secrets and the operator Namespace; nil reads
+	// them through the client
+	APIReader client.Reader
+	// Feature flags, reloaded when the operator config ConfigMap changes and guarded by featureFlagsMu
 	EnableNetworkPolicy bool
+	featureFlagsMu      sync.RWMutex
+	// operatorConfigName is the operator config ConfigMap watched for feature flag changes
+	operatorConfigName types.NamespacedName
+	// NetworkPolicyConfig customizes the created NetworkPolicies; nil uses the defaults
+	NetworkPolicyConfig *deploy.NetworkPolicyConfig
+	// LogCollectionConfig holds the annotations of pods enabling log collection; nil sets none
+	LogCollectionConfig *deploy.LogCollectionConfig
 	// Cluster info
 	ClusterInfo *cluster.ClusterInfo
-	httpClient  *http.Client
+	// ImageRegistryMirror rewrites resolved server images to point to a registry mirror
+	ImageRegistryMirror *registry.MirrorConfig
+	// ImageAllowlist restricts the custom server images to trusted registries; nil allows every image
+	ImageAllowlist *registry.AllowlistConfig
+	// ImageDigestResolver resolves the tags of the server images using digests
+	ImageDigestResolver registry.DigestResolver
+	// SpecAudit records spec changes to an audit sink; nil disables auditing
+	SpecAudit *audit.Config
+	// SupportedServerVersions is the range of server versions checked for skew; nil uses the defaults
+	SupportedServerVersions *ServerVersionRange
+	// InitializingRequeue is the requeue backoff of the instances in the Initializing phase; nil uses the defaults
+	InitializingRequeue *InitializingRequeueConfig
+	// TeardownHooks clean up the external state of the deleted LlamaStackDistributions, in order, before
+	// their finalizer is removed
+	TeardownHooks []TeardownHook
+	// Recorder emits Kubernetes Events for the managed LlamaStackDistributions
+	Recorder   record.EventRecorder
+	httpClient *http.Client
+	// tlsClients caches the HTTPS clients of the instances verifying the server certificate with their own
+	// settings, so that their connections are reused across reconciles
+	tlsClients tlsClientCache
+	// imageDigests caches the digests resolved for the server images using digests
+	imageDigests imageDigestCache
+	// initializingBackoff holds the next requeue interval of the instances in the Initializing phase
+	initializingBackoff requeueBackoff
+	// serviceMonitorsUnsupported logs once that metrics are requested on a cluster without the Prometheus Operator
+	serviceMonitorsUnsupported sync.Once
 }
 
 // hasUserConfigMap checks if the instance has a valid UserConfig with ConfigMapName.
@@ -167,20 +233,45 @@ func (r *LlamaStackDistributionReconciler) Reconcile(ctx context.Context, req ct
 
 	// Fetch the LlamaStack instance
 	instance, err := r.fetchInstance(ctx, req.NamespacedName)
+	if isThrottled(err) {
+		return r.requeueThrottled(ctx, err)
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	if instance == nil {
 		logger.Info("LlamaStackDistribution resource not found, skipping reconciliation")
+		r.initializingBackoff.reset(req.NamespacedName)
+		r.tlsClients.remove(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.finalize(ctx, instance)
+	}
+	if err := r.ensureFinalizer(ctx, instance); err != nil {
+		if isThrottled(err) {
+			return r.requeueThrottled(ctx, err)
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Audit the spec changes here rather than in the update predicate, so that the changes made while the
+	// operator was down are recorded too. A failure is retried by the next reconcile.
+	if err := r.RecordSpecChange(ctx, instance); err != nil {
+		logger.Error(err, "failed to record spec change to the audit sink")
+	}
+
 	// Reconcile all resources, storing the error for later.
 	reconcileErr := r.reconcileResources(ctx, instance)
 
 	// Update the status, passing in any reconciliation error.
-	if statusUpdateErr := r.updateStatus(ctx, instance, reconcileErr); statusUpdateErr != nil {
+	statusUpdateErr := r.updateStatus(ctx, instance, reconcileErr)
+	if isThrottled(reconcileErr) || isThrottled(statusUpdateErr) {
+		return r.requeueThrottled(ctx, reconcileErr, statusUpdateErr)
+	}
+	if statusUpdateErr != nil {
 		// Log the status update error, but prioritize the reconciliation error for return.
 		logger.Error(statusUpdateErr, "failed to update status")
 		if reconcileErr != nil {
@@ -196,13 +287,41 @@ func (r *LlamaStackDistributionReconciler) Reconcile(ctx context.Context, req ct
 
 	// Check if requeue is needed based on phase
 	if instance.Status.Phase == llamav1alpha1.LlamaStackDistributionPhaseInitializing {
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.nextInitializingRequeue(req.NamespacedName)}, nil
+	}
+	r.initializingBackoff.reset(req.NamespacedName)
+
+	// Come back when the stability period ends, the maintenance window opens for deferred changes, the canary
+	// pod of an upgrade is due for its next check, or the server is due for its next health check, sooner when
+	// it is Degraded
+	now := time.Now()
+	requeueAfter := getStabilityRemaining(instance, now)
+	for _, remaining := range []time.Duration{getMaintenanceWindowRemaining(instance, now), getCanaryRequeue(instance),
+		getHealthCheckInterval(instance), getDegradedRequeue(instance)} {
+		if remaining > 0 && (requeueAfter <= 0 || remaining < requeueAfter) {
+			requeueAfter = remaining
+		}
+	}
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	logger.Info("Successfully reconciled LlamaStackDistribution")
 	return ctrl.Result{}, nil
 }
 
+// requeueThrottled counts the requests throttled by the API server, and requeues the instance with backoff
+// without returning an error, so that transient throttling isn't reported as a reconcile failure.
+func (r *LlamaStackDistributionReconciler) requeueThrottled(ctx context.Context, errs ...error) (ctrl.Result, error) {
+	for _, err := range errs {
+		if isThrottled(err) {
+			throttledRequests.Inc()
+			log.FromContext(ctx).Info("the API server throttled a request, retrying with backoff", "error", err.Error())
+		}
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
 // fetchInstance retrieves the LlamaStackDistribution instance.
 func (r *LlamaStackDistributionReconciler) fetchInstance(ctx context.Context, namespacedName types.NamespacedName) (*llamav1alpha1.LlamaStackDistribution, error) {
 	logger := log.FromContext(ctx)
@@ -228,12 +347,14 @@ func (r *LlamaStackDistributionReconciler) determineKindsToExclude(instance *lla
 	}
 
 	// Exclude NetworkPolicy if the feature is disabled
-	if !r.EnableNetworkPolicy {
+	if !r.networkPolicyEnabled() {
 		kinds = append(kinds, "NetworkPolicy")
 	}
 
-	// Exclude Service if no ports are defined
-	if !instance.HasPorts() {
+	// Exclude Service if no ports are defined, unless a Route, an Ingress or a ServiceMonitor uses it, or when
+	// an existing Service is used instead
+	if instance.Spec.Server.ServiceOverride != nil ||
+		(!instance.HasPorts() && instance.Spec.Server.Route == nil && instance.Spec.Server.Ingress == nil && !metricsEnabled(instance)) {
 		kinds = append(kinds, "Service")
 	}
 
@@ -243,24 +364,52 @@ func (r *LlamaStackDistributionReconciler) determineKindsToExclude(instance *lla
 // reconcileManifestResources applies resources that are managed by the operator
 // based on the instance specification.
 func (r *LlamaStackDistributionReconciler) reconcileManifestResources(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
-	resMap, err := deploy.RenderManifest(filesys.MakeFsOnDisk(), manifestsBasePath, instance)
+	filteredResMap, err := r.renderManifestResources(instance)
 	if err != nil {
-		return fmt.Errorf("failed to render manifests: %w", err)
+		return err
 	}
 
-	kindsToExclude := r.determineKindsToExclude(instance)
-	filteredResMap, err := deploy.FilterExcludeKinds(resMap, kindsToExclude)
-	if err != nil {
-		return fmt.Errorf("failed to filter manifests: %w", err)
-	}
+	// Look the PVC up before applying the manifests, to report its creation
+	pvcMissing := instance.Spec.Server.Storage != nil && r.isPVCMissing(ctx, instance)
 
 	if err := deploy.ApplyResources(ctx, r.Client, r.Scheme, instance, filteredResMap); err != nil {
 		return fmt.Errorf("failed to apply manifests: %w", err)
 	}
 
+	if pvcMissing {
+		r.recordEvent(instance, corev1.EventTypeNormal, reasonPVCCreated, fmt.Sprintf("Created PersistentVolumeClaim %s", getPVCName(instance)))
+	}
 	return nil
 }
 
+// getPVCName returns the name of the PVC holding the server storage.
+func getPVCName(instance *llamav1alpha1.LlamaStackDistribution) string {
+	return instance.Name + "-pvc"
+}
+
+// isPVCMissing reports whether the PVC of the instance doesn't exist yet.
+func (r *LlamaStackDistributionReconciler) isPVCMissing(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) bool {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, types.NamespacedName{Name: getPVCName(instance), Namespace: instance.Namespace}, pvc)
+	return k8serrors.IsNotFound(err)
+}
+
+// renderManifestResources renders the manifest-based resources that apply to the instance.
+func (r *LlamaStackDistributionReconciler) renderManifestResources(instance *llamav1alpha1.LlamaStackDistribution) (*resmap.ResMap, error) {
+	resMap, err := deploy.RenderManifest(filesys.MakeFsOnDisk(), manifestsBasePath, instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render manifests: %w", err)
+	}
+
+	kindsToExclude := r.determineKindsToExclude(instance)
+	filteredResMap, err := deploy.FilterExcludeKinds(resMap, kindsToExclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter manifests: %w", err)
+	}
+
+	return filteredResMap, nil
+}
+
 // reconcileResources reconciles all resources for the LlamaStackDistribution instance.
 func (r *LlamaStackDistributionReconciler) reconcileResources(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
 	// Reconcile ConfigMaps
@@ -278,11 +427,61 @@ func (r *LlamaStackDistributionReconciler) reconcileResources(ctx context.Contex
 		return err
 	}
 
+	// Check the existing Service used instead of the operator one
+	if err := r.reconcileServiceOverride(ctx, instance); err != nil {
+		return err
+	}
+
+	// Reconcile the Service exposing internal-only ports
+	if err := r.reconcileInternalService(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile internal Service: %w", err)
+	}
+
 	// Reconcile the NetworkPolicy
 	if err := r.reconcileNetworkPolicy(ctx, instance); err != nil {
 		return fmt.Errorf("failed to reconcile NetworkPolicy: %w", err)
 	}
 
+	// Reconcile the Route exposing the Service
+	if err := r.reconcileRoute(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile Route: %w", err)
+	}
+
+	// Reconcile the Ingress exposing the Service
+	if err := r.reconcileIngress(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile Ingress: %w", err)
+	}
+
+	// Reconcile the ServiceMonitor scraping the Service
+	if err := r.reconcileServiceMonitor(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile ServiceMonitor: %w", err)
+	}
+
+	// Reconcile the PodDisruptionBudget of the server pods
+	if err := r.reconcilePodDisruptionBudget(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile PodDisruptionBudget: %w", err)
+	}
+
+	// Reconcile the ServiceAccount the pods run as when the operator is asked to create it
+	if err := r.reconcileServiceAccount(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile ServiceAccount: %w", err)
+	}
+
+	// Validate the ServiceAccount the pods will run as
+	if err := r.validateServiceAccount(ctx, instance); err != nil {
+		return err
+	}
+
+	// Refuse to bind host ports already bound by another instance in the host network
+	if err := r.validateHostNetworkPorts(ctx, instance); err != nil {
+		return err
+	}
+
+	// Refuse to create a Deployment whose selector overlaps with another one
+	if err := r.validateDeploymentSelector(ctx, instance); err != nil {
+		return err
+	}
+
 	// Reconcile the Deployment
 	if err := r.reconcileDeployment(ctx, instance); err != nil {
 		return fmt.Errorf("failed to reconcile Deployment: %w", err)
@@ -299,6 +498,11 @@ func (r *LlamaStackDistributionReconciler) reconcileConfigMaps(ctx context.Conte
 		}
 	}
 
+	// Reconcile the ConfigMap holding the inline run.yaml
+	if err := r.reconcileInlineUserConfigMap(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile inline user ConfigMap: %w", err)
+	}
+
 	// Reconcile the CA bundle ConfigMap if specified
 	if r.hasCABundleConfigMap(instance) {
 		if err := r.reconcileCABundleConfigMap(ctx, instance); err != nil {
@@ -309,16 +513,99 @@ func (r *LlamaStackDistributionReconciler) reconcileConfigMaps(ctx context.Conte
 	return nil
 }
 
+// validateServiceAccount ensures a ServiceAccount referenced through PodOverrides exists
+// in the instance namespace. The operator-managed ServiceAccount is created from the
+// manifests, so it is only checked when the user overrides it. An overriding ServiceAccount
+// created by the operator is checked as well, it has just been reconciled.
+func (r *LlamaStackDistributionReconciler) validateServiceAccount(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	if instance.Spec.Server.PodOverrides == nil || instance.Spec.Server.PodOverrides.ServiceAccountName == "" {
+		SetServiceAccountReadyCondition(instance, true, MessageServiceAccountReady)
+		return nil
+	}
+
+	saName := instance.Spec.Server.PodOverrides.ServiceAccountName
+	serviceAccount := &corev1.ServiceAccount{}
+	err := r.Get(ctx, types.NamespacedName{Name: saName, Namespace: instance.Namespace}, serviceAccount)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			message := fmt.Sprintf("ServiceAccount %s referenced in podOverrides not found in namespace %s", saName, instance.Namespace)
+			SetServiceAccountReadyCondition(instance, false, message)
+			return fmt.Errorf("failed to find ServiceAccount %s in namespace %s", saName, instance.Namespace)
+		}
+		return fmt.Errorf("failed to get ServiceAccount %s: %w", saName, err)
+	}
+
+	SetServiceAccountReadyCondition(instance, true, MessageServiceAccountReady)
+	return nil
+}
+
+// validateHostNetworkPorts ensures an instance running in the host network doesn't bind the same ports
+// as an older instance also running in the host network. Such pods could never be scheduled on the
+// same node, so the collision is reported up front and explicit distinct ports are required.
+func (r *LlamaStackDistributionReconciler) validateHostNetworkPorts(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	if !usesHostNetwork(instance) {
+		SetHostPortsAvailableCondition(instance, true, MessageHostPortsAvailable)
+		return nil
+	}
+
+	instances := &llamav1alpha1.LlamaStackDistributionList{}
+	if err := r.List(ctx, instances); err != nil {
+		return fmt.Errorf("failed to list LlamaStackDistributions: %w", err)
+	}
+
+	conflict, port := findHostPortConflict(instance, instances.Items)
+	if conflict != nil {
+		message := fmt.Sprintf("Port %d is already bound in the host network by LlamaStackDistribution %s/%s; "+
+			"set distinct ports in spec.server.containerSpec", port, conflict.Namespace, conflict.Name)
+		SetHostPortsAvailableCondition(instance, false, message)
+		return fmt.Errorf("failed to validate host network ports: port %d collides with %s/%s", port, conflict.Namespace, conflict.Name)
+	}
+
+	SetHostPortsAvailableCondition(instance, true, MessageHostPortsAvailable)
+	return nil
+}
+
+// validateDeploymentSelector checks that no other Deployment in the namespace selects the pods
+// of this instance, and that this instance would not select the pods of another Deployment.
+// Overlapping selectors make Deployments cross-claim pods, so reconciliation stops instead.
+func (r *LlamaStackDistributionReconciler) validateDeploymentSelector(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(instance.Namespace)); err != nil {
+		return fmt.Errorf("failed to list Deployments: %w", err)
+	}
+
+	conflict, err := findSelectorConflict(instance, deployments.Items)
+	if err != nil {
+		return err
+	}
+	if conflict != "" {
+		message := fmt.Sprintf("Deployment selector overlaps with Deployment %s in namespace %s", conflict, instance.Namespace)
+		SetSelectorValidCondition(instance, false, message)
+		return fmt.Errorf("failed to validate Deployment selector: overlaps with Deployment %s", conflict)
+	}
+
+	SetSelectorValidCondition(instance, true, MessageSelectorValid)
+	return nil
+}
+
 func (r *LlamaStackDistributionReconciler) reconcileStorage(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
-	// Reconcile the PVC if storage is configured
+	// Reconcile the PVC if storage is configured. The other manifests are applied with their exclusions
+	// by reconcileManifestResources
 	if instance.Spec.Server.Storage != nil {
 		resMap, err := deploy.RenderManifest(filesys.MakeFsOnDisk(), manifestsBasePath, instance)
 		if err != nil {
 			return fmt.Errorf("failed to render PVC manifests: %w", err)
 		}
+		resMap, err = deploy.FilterIncludeKinds(resMap, []string{"PersistentVolumeClaim"})
+		if err != nil {
+			return fmt.Errorf("failed to filter PVC manifests: %w", err)
+		}
 		if err := deploy.ApplyResources(ctx, r.Client, r.Scheme, instance, resMap); err != nil {
 			return fmt.Errorf("failed to apply PVC manifests: %w", err)
 		}
+		if err := r.expandPVC(ctx, instance); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -330,15 +617,37 @@ func (r *LlamaStackDistributionReconciler) SetupWithManager(ctx context.Context,
 	if err := r.createConfigMapFieldIndexer(ctx, mgr); err != nil {
 		return err
 	}
+	r.createReferencedObjectsFieldIndexers(ctx, mgr)
+
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(ControllerName)
+	}
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+
+	// Periodically clean up resources left behind by CRs that no longer exist
+	if err := mgr.Add(manager.RunnableFunc(r.runOrphanSweeper)); err != nil {
+		return fmt.Errorf("failed to add orphan sweeper: %w", err)
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(ControllerName).
 		For(&llamav1alpha1.LlamaStackDistribution{}, builder.WithPredicates(predicate.Funcs{
 			UpdateFunc: r.llamaStackUpdatePredicate(mgr),
 		})).
 		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.Pod{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.Service{}).
 		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&networkingv1.Ingress{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
 		Watches(
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.findLlamaStackDistributionsForConfigMap),
@@ -348,7 +657,32 @@ func (r *LlamaStackDistributionReconciler) SetupWithManager(ctx context.Context,
 				DeleteFunc: r.configMapDeletePredicate,
 			}),
 		).
-		Complete(r)
+		// Only the metadata of the Secrets is cached, their data is read through the API reader
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findLlamaStackDistributionsForSecret),
+			builder.OnlyMetadata,
+			builder.WithPredicates(r.secretPredicates()),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.reloadFeatureFlags),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.isOperatorConfig)),
+		)
+
+	// Routes and ServiceMonitors can only be watched on clusters serving their API
+	if r.routesSupported() {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(deploy.RouteGVK)
+		b = b.Owns(route)
+	}
+	if r.serviceMonitorsSupported() {
+		serviceMonitor := &unstructured.Unstructured{}
+		serviceMonitor.SetGroupVersionKind(deploy.ServiceMonitorGVK)
+		b = b.Owns(serviceMonitor)
+	}
+
+	return b.Complete(r)
 }
 
 // createConfigMapFieldIndexer creates a field indexer for ConfigMap references.
@@ -615,6 +949,11 @@ func (r *LlamaStackDistributionReconciler) manuallyCheckConfigMapReference(confi
 				return true
 			}
 		}
+
+		// Check the ConfigMaps referenced by the env and volumes of the pods
+		if configMaps, _ := getReferencedObjectNames(&ls); ls.Namespace == targetNamespace && slices.Contains(configMaps, targetName) {
+			return true
+		}
 	}
 
 	// no LlamaStackDistribution found that references the ConfigMap
@@ -628,6 +967,10 @@ func (r *LlamaStackDistributionReconciler) findLlamaStackDistributionsForConfigM
 	if !found {
 		// Fallback to manual search if field indexer returns no results
 		attachedLlamaStacks = r.performManualSearch(ctx, configMap)
+	} else {
+		// The user config and CA bundle indexes don't cover the ConfigMaps referenced by the pods
+		attachedLlamaStacks.Items = append(attachedLlamaStacks.Items,
+			r.findLlamaStackDistributionsReferencing(ctx, configMap, referencedConfigMapsIndexField)...)
 	}
 
 	// Convert to reconcile requests
@@ -724,7 +1067,9 @@ func (r *LlamaStackDistributionReconciler) doesLlamaStackReferenceConfigMap(ls l
 		}
 	}
 
-	return false
+	// Check the ConfigMaps referenced by the env and volumes of the pods
+	configMaps, _ := getReferencedObjectNames(&ls)
+	return ls.Namespace == targetNamespace && slices.Contains(configMaps, targetName)
 }
 
 // convertToReconcileRequests converts LlamaStackDistribution items to reconcile requests.
@@ -743,34 +1088,138 @@ func (r *LlamaStackDistributionReconciler) convertToReconcileRequests(attachedLl
 
 // reconcileDeployment manages the Deployment for the LlamaStack server.
 func (r *LlamaStackDistributionReconciler) reconcileDeployment(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
-	logger := log.FromContext(ctx)
-
-	// Validate distribution configuration
-	if err := r.validateDistribution(instance); err != nil {
+	reportPortsValid(instance)
+	image, err := r.resolveServerImage(instance)
+	if err != nil {
+		r.reportInvalidSpec(instance, err)
+		return err
+	}
+	SetSpecValidCondition(instance, true, MessageSpecValid)
+	image, err = r.pinImageDigest(ctx, instance, image)
+	if err != nil {
+		return err
+	}
+	deployment, err := r.buildDeployment(ctx, instance, image)
+	if err != nil {
+		r.reportInvalidSpec(instance, err)
+		return err
+	}
+	checkPrivilegedPorts(ctx, instance, &deployment.Spec.Template.Spec.Containers[0])
+	r.checkPriorityClass(ctx, instance)
+	if err := r.applyMaintenanceWindow(ctx, instance, deployment); err != nil {
+		return err
+	}
+	if err := r.applyRollbackPolicy(ctx, instance, deployment); err != nil {
+		return err
+	}
+	if proceed, err := r.reconcilePreStartJob(ctx, instance, deployment); err != nil || !proceed {
+		return err
+	}
+	if proceed, err := r.reconcileCanary(ctx, instance, deployment); err != nil || !proceed {
 		return err
 	}
+	if err := reportPodTemplate(instance, &deployment.Spec.Template); err != nil {
+		return err
+	}
+	if hasImageVolumes(deployment.Spec.Template.Annotations) {
+		withImageVolumes, err := r.toDesiredWithImageVolumes(deployment, "spec", "template")
+		if err != nil {
+			return err
+		}
+		result, err := deploy.ApplyUnstructuredDeployment(ctx, r.Client, r.Scheme, instance, withImageVolumes, log.FromContext(ctx))
+		if err != nil {
+			return err
+		}
+		r.recordDeploymentApplied(instance, result)
+	} else {
+		result, err := deploy.ApplyDeployment(ctx, r.Client, r.Scheme, instance, deployment, log.FromContext(ctx))
+		if err != nil {
+			return err
+		}
+		r.recordDeploymentApplied(instance, result)
+	}
+	return r.reconcileHPA(ctx, instance)
+}
+
+// toDesiredWithImageVolumes converts the object to an unstructured one, giving their source to the image
+// volumes of the pod template found at templatePath.
+func (r *LlamaStackDistributionReconciler) toDesiredWithImageVolumes(obj client.Object, templatePath ...string) (*unstructured.Unstructured, error) {
+	u, err := r.toDesiredUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := setImageVolumeSources(u, templatePath...); err != nil {
+		return nil, fmt.Errorf("failed to configure image volumes: %w", err)
+	}
+	return u, nil
+}
 
-	// Get the image either from the map or direct reference
-	resolvedImage, err := r.resolveImage(instance.Spec.Server.Distribution)
+// createWithImageVolumes creates the object, through an unstructured one when its pod template, found at
+// templatePath and annotated with templateAnnotations, mounts image volumes.
+func (r *LlamaStackDistributionReconciler) createWithImageVolumes(ctx context.Context, obj client.Object,
+	templateAnnotations map[string]string, templatePath ...string) error {
+	if !hasImageVolumes(templateAnnotations) {
+		return r.Create(ctx, obj)
+	}
+	u, err := r.toDesiredWithImageVolumes(obj, templatePath...)
 	if err != nil {
 		return err
 	}
+	return r.Create(ctx, u)
+}
+
+// resolveServerImage validates the distribution of the instance and returns its server image, either from
+// the distribution map or the direct reference. The image isn't pinned to its digest yet. Validation errors
+// are returned as invalid spec errors without touching the status, the reconcile loop reports them.
+func (r *LlamaStackDistributionReconciler) resolveServerImage(instance *llamav1alpha1.LlamaStackDistribution) (string, error) {
+	// Validate distribution configuration
+	if err := r.validateDistribution(instance); err != nil {
+		return "", &invalidSpecError{err: err}
+	}
+
+	return r.resolveImage(instance.Spec.Server.Distribution)
+}
+
+// reportInvalidSpec reports a validation error of the spec through the SpecValid condition and a
+// ValidationFailed Event. Other errors are left to the caller.
+func (r *LlamaStackDistributionReconciler) reportInvalidSpec(instance *llamav1alpha1.LlamaStackDistribution, err error) {
+	if !isInvalidSpec(err) {
+		return
+	}
+	SetSpecValidCondition(instance, false, err.Error())
+	r.recordEvent(instance, corev1.EventTypeWarning, reasonValidationFailed, err.Error())
+}
+
+// buildDeployment returns the desired Deployment running the image of the LlamaStack server.
+func (r *LlamaStackDistributionReconciler) buildDeployment(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	image string) (*appsv1.Deployment, error) {
+	logger := log.FromContext(ctx)
 
 	// Build container spec
-	container := buildContainerSpec(ctx, r, instance, resolvedImage)
+	container := buildContainerSpec(ctx, r, instance, image)
 
 	// Configure storage
 	podSpec := configurePodStorage(ctx, r, instance, container)
+	configureTopologySpread(instance, &podSpec, r.getReplicas(instance))
+	if err := validatePodOverrideVolumes(instance, &podSpec); err != nil {
+		return nil, &invalidSpecError{err: err}
+	}
 
 	// Set the service acc
-	// Prepare annotations for the pod template
+	// Prepare annotations for the pod template, the custom annotations first so the operator ones win
 	podAnnotations := make(map[string]string)
+	maps.Copy(podAnnotations, instance.Spec.Annotations)
+	if instance.Spec.Server.PodOverrides != nil {
+		maps.Copy(podAnnotations, instance.Spec.Server.PodOverrides.PodAnnotations)
+	}
 
 	// Add ConfigMap hash to trigger restarts when the ConfigMap changes
-	if r.hasUserConfigMap(instance) {
+	if hasInlineUserConfig(instance) {
+		podAnnotations["configmap.hash/user-config"] = getInlineUserConfigHash(instance)
+	} else if r.hasUserConfigMap(instance) {
 		configMapHash, err := r.getConfigMapHash(ctx, instance)
 		if err != nil {
-			return fmt.Errorf("failed to get ConfigMap hash for pod restart annotation: %w", err)
+			return nil, fmt.Errorf("failed to get ConfigMap hash for pod restart annotation: %w", err)
 		}
 		if configMapHash != "" {
 			podAnnotations["configmap.hash/user-config"] = configMapHash
@@ -784,7 +1233,7 @@ func (r *LlamaStackDistributionReconciler) reconcileDeployment(ctx context.Conte
 	if r.hasCABundleConfigMap(instance) {
 		caBundleHash, err := r.getCABundleConfigMapHash(ctx, instance)
 		if err != nil {
-			return fmt.Errorf("failed to get CA bundle ConfigMap hash for pod restart annotation: %w", err)
+			return nil, fmt.Errorf("failed to get CA bundle ConfigMap hash for pod restart annotation: %w", err)
 		}
 		if caBundleHash != "" {
 			podAnnotations["configmap.hash/ca-bundle"] = caBundleHash
@@ -794,63 +1243,127 @@ func (r *LlamaStackDistributionReconciler) reconcileDeployment(ctx context.Conte
 		}
 	}
 
+	// Add the checksum of the ConfigMaps and Secrets referenced by the env and volumes of the pods
+	referencedHash, err := r.getReferencedObjectsHash(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	if referencedHash != "" {
+		podAnnotations[referencedObjectsHashAnnotation] = referencedHash
+	}
+
+	setSafeToEvictAnnotation(instance, podAnnotations)
+	r.setLogCollectionAnnotations(ctx, instance, podAnnotations)
+	if err := addImageVolumes(instance, &podSpec, podAnnotations); err != nil {
+		return nil, err
+	}
+
 	// Create deployment object
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      instance.Name,
-			Namespace: instance.Namespace,
+			Name:        instance.Name,
+			Namespace:   instance.Namespace,
+			Labels:      deploy.MergeLabels(deploy.GetResourceLabels(instance), getPodSelectorLabels(instance)),
+			Annotations: deploy.GetResourceAnnotations(instance),
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &instance.Spec.Replicas,
+			Replicas: r.getDeploymentReplicas(instance),
+			Strategy: getDeploymentStrategy(instance),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					llamav1alpha1.DefaultLabelKey: llamav1alpha1.DefaultLabelValue,
-					"app.kubernetes.io/instance":  instance.Name,
-				},
+				MatchLabels: getPodSelectorLabels(instance),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						llamav1alpha1.DefaultLabelKey: llamav1alpha1.DefaultLabelValue,
-						"app.kubernetes.io/instance":  instance.Name,
-					},
+					Labels:      getPodTemplateLabels(instance),
 					Annotations: podAnnotations,
 				},
 				Spec: podSpec,
 			},
 		},
 	}
+	appliedHash, err := getPodTemplateHash(&deployment.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[appliedTemplateHashAnnotation] = appliedHash
 
-	return deploy.ApplyDeployment(ctx, r.Client, r.Scheme, instance, deployment, logger)
+	return deployment, nil
 }
 
 // getServerURL returns the URL for the LlamaStack server.
 func (r *LlamaStackDistributionReconciler) getServerURL(instance *llamav1alpha1.LlamaStackDistribution, path string) *url.URL {
-	serviceName := deploy.GetServiceName(instance)
-	port := deploy.GetServicePort(instance)
-
 	return &url.URL{
-		Scheme: "http",
-		Host:   fmt.Sprintf("%s.%s.svc.cluster.local:%d", serviceName, instance.Namespace, port),
+		Scheme: getServerScheme(instance),
+		Host:   getServiceHost(deploy.GetServiceName(instance), instance.Namespace, deploy.GetServicePort(instance)),
 		Path:   path,
 	}
 }
 
-// getProviderInfo makes an HTTP request to the providers endpoint.
+// getServiceHost returns the in-cluster host and port of a Service.
+func getServiceHost(serviceName, namespace string, port int32) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", serviceName, namespace, port)
+}
+
+// getProviderInfo lists the providers of the server, following the pages of paginated responses.
 func (r *LlamaStackDistributionReconciler) getProviderInfo(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) ([]llamav1alpha1.ProviderInfo, error) {
-	u := r.getServerURL(instance, "/v1/providers")
+	u := r.getServerURL(instance, providersEndpoint)
+
+	httpClient, err := r.getServerHTTPClient(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var providers []llamav1alpha1.ProviderInfo
+	for page := 0; page < maxProviderPages; page++ {
+		response, err := r.getProviderPage(ctx, httpClient, u)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, response.Data...)
 
+		next, err := getNextProviderPage(u, response)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			return providers, nil
+		}
+		u = next
+	}
+
+	log.FromContext(ctx).Info("providers endpoint returned too many pages, reporting the providers collected so far",
+		"maxPages", maxProviderPages, "providers", len(providers))
+	return providers, nil
+}
+
+// providersResponse is a page of the providers endpoint. Unpaginated responses only hold the data.
+type providersResponse struct {
+	Data []llamav1alpha1.ProviderInfo `json:"data"`
+	// Next is the URL, absolute or relative to the server, of the next page
+	Next string `json:"next,omitempty"`
+	// NextCursor is the cursor of the next page, sent back in the cursor query parameter
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// getProviderPage fetches a page of the providers endpoint.
+func (r *LlamaStackDistributionReconciler) getProviderPage(ctx context.Context, httpClient *http.Client, u *url.URL) (*providersResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create providers request: %w", err)
 	}
 
-	resp, err := r.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make providers request: %w", err)
 	}
-	defer resp.Body.Close()
+	defer closeResponseBody(resp.Body)
 
+	if err := getAPIAuthError(u.Path, resp.StatusCode); err != nil {
+		return nil, fmt.Errorf("failed to query providers endpoint: %w", err)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to query providers endpoint: returned status code %d", resp.StatusCode)
 	}
@@ -860,11 +1373,73 @@ func (r *LlamaStackDistributionReconciler) getProviderInfo(ctx context.Context,
 		return nil, fmt.Errorf("failed to read providers response: %w", err)
 	}
 
+	response := &providersResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal providers response: %w", err)
+	}
+	return response, nil
+}
+
+// getNextProviderPage returns the URL of the page following the current one, nil on the last page.
+// The next page must be served by the same server.
+func getNextProviderPage(current *url.URL, response *providersResponse) (*url.URL, error) {
+	switch {
+	case response.Next != "":
+		next, err := current.Parse(response.Next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next providers page %q: %w", response.Next, err)
+		}
+		if next.Host != current.Host {
+			return nil, fmt.Errorf("failed to follow next providers page: %s is not served by %s", next.Redacted(), current.Host)
+		}
+		return next, nil
+	case response.NextCursor != "":
+		next := *current
+		query := next.Query()
+		query.Set("cursor", response.NextCursor)
+		next.RawQuery = query.Encode()
+		return &next, nil
+	default:
+		return nil, nil
+	}
+}
+
+// getModelInfo makes an HTTP request to the models endpoint.
+func (r *LlamaStackDistributionReconciler) getModelInfo(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) ([]llamav1alpha1.ModelInfo, error) {
+	u := r.getServerURL(instance, modelsEndpoint)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create models request: %w", err)
+	}
+
+	httpClient, err := r.getServerHTTPClient(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make models request: %w", err)
+	}
+	defer closeResponseBody(resp.Body)
+
+	if err := getAPIAuthError(modelsEndpoint, resp.StatusCode); err != nil {
+		return nil, fmt.Errorf("failed to query models endpoint: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query models endpoint: returned status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models response: %w", err)
+	}
+
 	var response struct {
-		Data []llamav1alpha1.ProviderInfo `json:"data"`
+		Data []llamav1alpha1.ModelInfo `json:"data"`
 	}
 	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal providers response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal models response: %w", err)
 	}
 
 	return response.Data, nil
@@ -872,19 +1447,26 @@ func (r *LlamaStackDistributionReconciler) getProviderInfo(ctx context.Context,
 
 // getVersionInfo makes an HTTP request to the version endpoint.
 func (r *LlamaStackDistributionReconciler) getVersionInfo(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) (string, error) {
-	u := r.getServerURL(instance, "/v1/version")
+	u := r.getServerURL(instance, versionEndpoint)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create version request: %w", err)
 	}
 
-	resp, err := r.httpClient.Do(req)
+	httpClient, err := r.getServerHTTPClient(ctx, instance)
+	if err != nil {
+		return "", err
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to make version request: %w", err)
 	}
-	defer resp.Body.Close()
+	defer closeResponseBody(resp.Body)
 
+	if err := getAPIAuthError(versionEndpoint, resp.StatusCode); err != nil {
+		return "", fmt.Errorf("failed to query version endpoint: %w", err)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("failed to query version endpoint: returned status code %d", resp.StatusCode)
 	}
@@ -906,17 +1488,30 @@ func (r *LlamaStackDistributionReconciler) getVersionInfo(ctx context.Context, i
 
 // updateStatus refreshes the LlamaStack status.
 func (r *LlamaStackDistributionReconciler) updateStatus(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution, reconcileErr error) error {
-	logger := log.FromContext(ctx)
 	// Initialize OperatorVersion if not set
 	if instance.Status.Version.OperatorVersion == "" {
 		instance.Status.Version.OperatorVersion = os.Getenv("OPERATOR_VERSION")
 	}
 
+	previousPhase := instance.Status.Phase
+
+	if !isThrottled(reconcileErr) && GetCondition(&instance.Status, ConditionTypeThrottled) != nil {
+		SetThrottledCondition(instance, false, "")
+	}
+
 	// A reconciliation error is the highest priority. It overrides all other status checks.
-	if reconcileErr != nil {
+	switch {
+	case isThrottled(reconcileErr):
+		// Throttling is transient, keep the phase and conditions of the last reconcile until the retry
+		SetThrottledCondition(instance, true, fmt.Sprintf("The API server throttled the reconcile, retrying: %v", reconcileErr))
+	case isInvalidSpec(reconcileErr):
+		// Nothing is deployed for an invalid spec, the user has to fix it
 		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseFailed
-		SetDeploymentReadyCondition(&instance.Status, false, fmt.Sprintf("Resource reconciliation failed: %v", reconcileErr))
-	} else {
+		SetDeploymentInvalidSpecCondition(instance, fmt.Sprintf("Spec validation failed: %v", reconcileErr))
+	case reconcileErr != nil:
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseFailed
+		SetDeploymentReadyCondition(instance, false, fmt.Sprintf("Resource reconciliation failed: %v", reconcileErr))
+	default:
 		// If reconciliation was successful, proceed with detailed status checks.
 		deploymentReady, err := r.updateDeploymentStatus(ctx, instance)
 		if err != nil {
@@ -925,39 +1520,39 @@ func (r *LlamaStackDistributionReconciler) updateStatus(ctx context.Context, ins
 
 		r.updateStorageStatus(ctx, instance)
 		r.updateServiceStatus(ctx, instance)
+		r.updateAutoscalingStatus(ctx, instance)
+		r.updateRouteStatus(ctx, instance)
+		r.updateIngressStatus(ctx, instance)
+		r.updateMetricsStatus(ctx, instance)
 		r.updateDistributionConfig(instance)
 
 		if deploymentReady {
-			instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
-
-			providers, err := r.getProviderInfo(ctx, instance)
-			if err != nil {
-				logger.Error(err, "failed to get provider info, clearing provider list")
-				instance.Status.DistributionConfig.Providers = nil
-			} else {
-				instance.Status.DistributionConfig.Providers = providers
-			}
-
-			version, err := r.getVersionInfo(ctx, instance)
-			if err != nil {
-				logger.Error(err, "failed to get version info from API endpoint")
-				// Don't clear the version if we cant fetch it - keep the existing one
-			} else {
-				instance.Status.Version.LlamaStackServerVersion = version
-				logger.V(1).Info("Updated LlamaStack version from API endpoint", "version", version)
-			}
-
-			SetHealthCheckCondition(&instance.Status, true, MessageHealthCheckPassed)
+			r.performHealthChecks(ctx, instance)
 		} else {
-			// If not ready, health can't be checked. Set condition appropriately.
-			SetHealthCheckCondition(&instance.Status, false, "Deployment not ready")
-			instance.Status.DistributionConfig.Providers = nil // Clear providers
+			// If not ready, health can't be checked. Set condition appropriately. The last known providers are
+			// kept, so that the provider Events of the next observation only report actual changes
+			SetHealthCheckCondition(instance, false, "Deployment not ready")
+			instance.Status.DistributionConfig.Models = nil // Clear models
+			SetProvidersHealthyCondition(instance, false, "Deployment not ready")
+			checkRequiredProviders(instance, nil, "Deployment not ready")
 		}
 	}
 
+	r.recordPhaseFailed(instance, previousPhase, reconcileErr)
+	recordReadyDeparture(&instance.Status, previousPhase, time.Now())
+	r.updateStability(instance, time.Now())
+
+	// The generation is only observed once the status computed from it is stored. A throttled reconcile kept
+	// the status of the previous generation
+	observedGeneration := instance.Status.ObservedGeneration
+	if !isThrottled(reconcileErr) {
+		instance.Status.ObservedGeneration = instance.Generation
+	}
+
 	// Always update the status at the end of the function.
 	instance.Status.Version.LastUpdated = metav1.NewTime(metav1.Now().UTC())
 	if err := r.Status().Update(ctx, instance); err != nil {
+		instance.Status.ObservedGeneration = observedGeneration
 		return fmt.Errorf("failed to update status: %w", err)
 	}
 
@@ -972,50 +1567,165 @@ func (r *LlamaStackDistributionReconciler) updateDeploymentStatus(ctx context.Co
 	}
 
 	deploymentReady := false
+	replicas := r.getReplicas(instance)
+	if instance.Spec.Server.Autoscaling != nil && deploymentErr == nil {
+		// The HPA owns the replica count
+		replicas = ptr.Deref(deployment.Spec.Replicas, replicas)
+	}
+
+	readyReplicas := deployment.Status.ReadyReplicas
+	replicaStatuses, err := r.getReplicaStatuses(ctx, instance)
+	if err != nil {
+		// Per-replica details are informational only, keep the previous ones.
+		log.FromContext(ctx).Error(err, "failed to get replica statuses")
+	} else {
+		instance.Status.ReplicaStatuses = replicaStatuses
+		if len(instance.Spec.Server.SidecarContainers) > 0 {
+			// The pods are ready once their server container and readiness gates are, whatever the state of the sidecars
+			readyReplicas = countReadyReplicas(replicaStatuses)
+		}
+	}
 
 	switch {
 	case deploymentErr != nil: // This case covers when the deployment is not found
 		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhasePending
-		SetDeploymentReadyCondition(&instance.Status, false, MessageDeploymentPending)
-	case deployment.Status.ReadyReplicas == 0:
+		SetDeploymentReadyCondition(instance, false, MessageDeploymentPending)
+	case readyReplicas == 0:
 		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
-		SetDeploymentReadyCondition(&instance.Status, false, MessageDeploymentPending)
-	case deployment.Status.ReadyReplicas < instance.Spec.Replicas:
+		SetDeploymentReadyCondition(instance, false, MessageDeploymentPending)
+	case readyReplicas < replicas:
 		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
-		deploymentMessage := fmt.Sprintf("Deployment is scaling: %d/%d replicas ready", deployment.Status.ReadyReplicas, instance.Spec.Replicas)
-		SetDeploymentReadyCondition(&instance.Status, false, deploymentMessage)
-	case deployment.Status.ReadyReplicas > instance.Spec.Replicas:
-		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
-		deploymentMessage := fmt.Sprintf("Deployment is scaling down: %d/%d replicas ready", deployment.Status.ReadyReplicas, instance.Spec.Replicas)
-		SetDeploymentReadyCondition(&instance.Status, false, deploymentMessage)
+		deploymentMessage := fmt.Sprintf("Deployment is scaling: %d/%d replicas ready", readyReplicas, replicas)
+		SetDeploymentReadyCondition(instance, false, deploymentMessage)
+	case isDeploymentScalingDown(deployment, replicas):
+		// The desired replicas are serving, the extra replicas are terminating and may fail health checks
+		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+		deploymentReady = true
+		deploymentMessage := fmt.Sprintf("Deployment is scaling down: %d replicas running, %d desired", deployment.Status.Replicas, replicas)
+		SetDeploymentScalingDownCondition(instance, deploymentMessage)
 	default:
 		instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
 		deploymentReady = true
-		SetDeploymentReadyCondition(&instance.Status, true, MessageDeploymentReady)
+		SetDeploymentReadyCondition(instance, true, MessageDeploymentReady)
+	}
+	instance.Status.AvailableReplicas = readyReplicas
+	if deploymentErr == nil {
+		if err := trackRollout(instance, deployment, time.Now()); err != nil {
+			return false, err
+		}
 	}
-	instance.Status.AvailableReplicas = deployment.Status.ReadyReplicas
 	return deploymentReady, nil
 }
 
+// countReadyReplicas returns the number of ready replicas among the given ones.
+func countReadyReplicas(statuses []llamav1alpha1.ReplicaStatus) int32 {
+	var ready int32
+	for _, status := range statuses {
+		if status.Ready {
+			ready++
+		}
+	}
+	return ready
+}
+
+// getPodTemplateLabels returns the labels of the server pods of an instance.
+func getPodTemplateLabels(instance *llamav1alpha1.LlamaStackDistribution) map[string]string {
+	return deploy.MergeLabels(instance.Spec.Labels, deploy.GetPodLabels(instance), getPodSelectorLabels(instance))
+}
+
+// getPodSelectorLabels returns the labels that identify the server pods of an instance.
+func getPodSelectorLabels(instance *llamav1alpha1.LlamaStackDistribution) map[string]string {
+	return map[string]string{
+		llamav1alpha1.DefaultLabelKey: llamav1alpha1.DefaultLabelValue,
+		"app.kubernetes.io/instance":  instance.Name,
+	}
+}
+
+// getReplicaStatuses lists the server pods of the instance and reports the readiness of each one.
+func (r *LlamaStackDistributionReconciler) getReplicaStatuses(ctx context.Context,
+	instance *llamav1alpha1.LlamaStackDistribution) ([]llamav1alpha1.ReplicaStatus, error) {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(instance.Namespace),
+		client.MatchingLabels(getPodSelectorLabels(instance))); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	statuses := make([]llamav1alpha1.ReplicaStatus, 0, len(podList.Items))
+	for i := range podList.Items {
+		statuses = append(statuses, getReplicaStatus(&podList.Items[i], getContainerName(instance)))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].PodName < statuses[j].PodName
+	})
+	return statuses, nil
+}
+
 func (r *LlamaStackDistributionReconciler) updateStorageStatus(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
 	if instance.Spec.Server.Storage == nil {
 		return
 	}
 	pvc := &corev1.PersistentVolumeClaim{}
-	err := r.Get(ctx, types.NamespacedName{Name: instance.Name + "-pvc", Namespace: instance.Namespace}, pvc)
+	err := r.Get(ctx, types.NamespacedName{Name: getPVCName(instance), Namespace: instance.Namespace}, pvc)
 	if err != nil {
-		SetStorageReadyCondition(&instance.Status, false, fmt.Sprintf("Failed to get PVC: %v", err))
+		SetStorageReadyCondition(instance, false, fmt.Sprintf("Failed to get PVC: %v", err))
 		return
 	}
 
 	ready := pvc.Status.Phase == corev1.ClaimBound
+	if !ready && pvc.Status.Phase == corev1.ClaimPending && r.waitsForFirstConsumer(ctx, pvc) {
+		SetStorageWaitingForConsumerCondition(instance)
+		return
+	}
+
 	var message string
 	if ready {
 		message = MessageStorageReady
+		if expansion := getPVCExpansion(pvc); expansion != "" {
+			message = expansion
+		}
 	} else {
 		message = fmt.Sprintf("PVC is not bound: %s", pvc.Status.Phase)
 	}
-	SetStorageReadyCondition(&instance.Status, ready, message)
+	SetStorageReadyCondition(instance, ready, message)
+}
+
+// waitsForFirstConsumer reports whether the StorageClass of the PVC, or the default StorageClass
+// when the PVC does not name one, delays binding until a pod using the PVC is scheduled.
+func (r *LlamaStackDistributionReconciler) waitsForFirstConsumer(ctx context.Context, pvc *corev1.PersistentVolumeClaim) bool {
+	logger := log.FromContext(ctx)
+
+	storageClass, err := r.getStorageClass(ctx, pvc.Spec.StorageClassName)
+	if err != nil {
+		logger.V(1).Info("failed to resolve the StorageClass of the PVC", "pvc", pvc.Name, "error", err.Error())
+		return false
+	}
+	if storageClass == nil || storageClass.VolumeBindingMode == nil {
+		return false
+	}
+	return *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer
+}
+
+// getStorageClass returns the named StorageClass, or the cluster default StorageClass when name is unset.
+// It returns nil when no default StorageClass exists.
+func (r *LlamaStackDistributionReconciler) getStorageClass(ctx context.Context, name *string) (*storagev1.StorageClass, error) {
+	if name != nil && *name != "" {
+		storageClass := &storagev1.StorageClass{}
+		if err := r.Get(ctx, types.NamespacedName{Name: *name}, storageClass); err != nil {
+			return nil, fmt.Errorf("failed to get StorageClass %s: %w", *name, err)
+		}
+		return storageClass, nil
+	}
+
+	storageClasses := &storagev1.StorageClassList{}
+	if err := r.List(ctx, storageClasses); err != nil {
+		return nil, fmt.Errorf("failed to list StorageClasses: %w", err)
+	}
+	for i := range storageClasses.Items {
+		if storageClasses.Items[i].Annotations[defaultStorageClassAnnotation] == "true" {
+			return &storageClasses.Items[i], nil
+		}
+	}
+	return nil, nil
 }
 
 func (r *LlamaStackDistributionReconciler) updateServiceStatus(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
@@ -1024,13 +1734,101 @@ func (r *LlamaStackDistributionReconciler) updateServiceStatus(ctx context.Conte
 		logger.Info("No ports defined, skipping service status update")
 		return
 	}
+
 	service := &corev1.Service{}
-	err := r.Get(ctx, types.NamespacedName{Name: instance.Name + "-service", Namespace: instance.Namespace}, service)
+	err := r.Get(ctx, types.NamespacedName{Name: deploy.GetServiceName(instance), Namespace: instance.Namespace}, service)
 	if err != nil {
-		SetServiceReadyCondition(&instance.Status, false, fmt.Sprintf("Failed to get Service: %v", err))
+		SetServiceReadyCondition(instance, false, fmt.Sprintf("Failed to get Service: %v", err))
 		return
 	}
-	SetServiceReadyCondition(&instance.Status, true, MessageServiceReady)
+	services := []llamav1alpha1.ServiceStatus{getServiceStatus(service, llamav1alpha1.PortExposurePublic)}
+
+	if len(instance.GetPortsByExposure(llamav1alpha1.PortExposureInternal)) > 0 {
+		internalService := &corev1.Service{}
+		err = r.Get(ctx, types.NamespacedName{Name: deploy.GetInternalServiceName(instance), Namespace: instance.Namespace}, internalService)
+		if err != nil {
+			SetServiceReadyCondition(instance, false, fmt.Sprintf("Failed to get internal Service: %v", err))
+			return
+		}
+		services = append(services, getServiceStatus(internalService, llamav1alpha1.PortExposureInternal))
+	}
+
+	instance.Status.Services = services
+	SetServiceReadyCondition(instance, true, MessageServiceReady)
+}
+
+// getServiceStatus summarizes a Service exposing the server.
+func getServiceStatus(service *corev1.Service, exposure llamav1alpha1.PortExposure) llamav1alpha1.ServiceStatus {
+	status := llamav1alpha1.ServiceStatus{
+		Name:     service.Name,
+		Exposure: exposure,
+		Type:     service.Spec.Type,
+		Headless: service.Spec.ClusterIP == corev1.ClusterIPNone,
+	}
+	for _, port := range service.Spec.Ports {
+		status.Ports = append(status.Ports, port.Port)
+		if port.NodePort != 0 {
+			status.NodePorts = append(status.NodePorts, port.NodePort)
+		}
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			status.ExternalAddresses = append(status.ExternalAddresses, ingress.IP)
+		} else if ingress.Hostname != "" {
+			status.ExternalAddresses = append(status.ExternalAddresses, ingress.Hostname)
+		}
+	}
+	return status
+}
+
+// updateStability tracks how long the distribution has been Ready, and reports it Stable once it stayed
+// Ready for the configured stability period. Leaving the Ready phase restarts the period.
+func (r *LlamaStackDistributionReconciler) updateStability(instance *llamav1alpha1.LlamaStackDistribution, now time.Time) {
+	if instance.Status.Phase != llamav1alpha1.LlamaStackDistributionPhaseReady {
+		instance.Status.ReadySince = nil
+	} else if instance.Status.ReadySince == nil {
+		instance.Status.ReadySince = &metav1.Time{Time: now}
+	}
+
+	stableAfter := getStableAfter(instance)
+	if stableAfter == 0 {
+		return
+	}
+
+	if instance.Status.ReadySince == nil {
+		SetStableCondition(instance, false, "Distribution is not Ready")
+		return
+	}
+	if now.Sub(instance.Status.ReadySince.Time) < stableAfter {
+		SetStableCondition(instance, false, fmt.Sprintf("Distribution is Ready since %s, stable after %s",
+			instance.Status.ReadySince.UTC().Format(time.RFC3339), stableAfter))
+		return
+	}
+
+	if IsConditionTrue(&instance.Status, ConditionTypeStable) {
+		return
+	}
+	SetStableCondition(instance, true, MessageStable)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, ReasonStable, "Distribution stayed Ready for %s", stableAfter)
+	}
+}
+
+// getStableAfter returns the stability period of the instance, zero when it is not configured.
+func getStableAfter(instance *llamav1alpha1.LlamaStackDistribution) time.Duration {
+	if healthCheck := instance.Spec.Server.HealthCheck; healthCheck != nil && healthCheck.StableAfter != nil {
+		return healthCheck.StableAfter.Duration
+	}
+	return 0
+}
+
+// getStabilityRemaining returns how long the Ready instance still has to stay Ready to be Stable.
+func getStabilityRemaining(instance *llamav1alpha1.LlamaStackDistribution, now time.Time) time.Duration {
+	stableAfter := getStableAfter(instance)
+	if stableAfter == 0 || instance.Status.ReadySince == nil || IsConditionTrue(&instance.Status, ConditionTypeStable) {
+		return 0
+	}
+	return instance.Status.ReadySince.Add(stableAfter).Sub(now)
 }
 
 func (r *LlamaStackDistributionReconciler) updateDistributionConfig(instance *llamav1alpha1.LlamaStackDistribution) {
@@ -1038,34 +1836,201 @@ func (r *LlamaStackDistributionReconciler) updateDistributionConfig(instance *ll
 	var activeDistribution string
 	if instance.Spec.Server.Distribution.Name != "" {
 		activeDistribution = instance.Spec.Server.Distribution.Name
+		SetDistributionSourceCondition(instance, true, MessageDistributionCatalog)
 	} else if instance.Spec.Server.Distribution.Image != "" {
 		activeDistribution = "custom"
+		SetDistributionSourceCondition(instance, false, MessageDistributionCustom)
 	}
 	instance.Status.DistributionConfig.ActiveDistribution = activeDistribution
 }
 
-// reconcileNetworkPolicy manages the NetworkPolicy for the LlamaStack server.
-func (r *LlamaStackDistributionReconciler) reconcileNetworkPolicy(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+// getNetworkPolicyPorts returns the ingress ports of the server: the server port, always TCP as it serves
+// the HTTP API, and all additional ports with their protocol.
+func getNetworkPolicyPorts(instance *llamav1alpha1.LlamaStackDistribution) []networkingv1.NetworkPolicyPort {
+	ports := []networkingv1.NetworkPolicyPort{
+		{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port: &intstr.IntOrString{
+				IntVal: deploy.GetServicePort(instance),
+			},
+		},
+	}
+	for _, port := range instance.Spec.Server.ContainerSpec.Ports {
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(port.GetProtocol()),
+			Port: &intstr.IntOrString{
+				IntVal: port.Port,
+			},
+		})
+	}
+	return ports
+}
+
+// reconcileInternalService manages the ClusterIP Service exposing the internal ports of the server,
+// such as metrics, separately from the server Service. It is removed when no internal port is declared.
+func (r *LlamaStackDistributionReconciler) reconcileInternalService(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
 	logger := log.FromContext(ctx)
-	networkPolicy := &networkingv1.NetworkPolicy{
+	service := buildInternalService(instance)
+	if len(service.Spec.Ports) == 0 {
+		return deploy.DeleteServiceIfExists(ctx, r.Client, instance, service, logger)
+	}
+
+	return deploy.ApplyService(ctx, r.Client, r.Scheme, instance, service, logger)
+}
+
+// buildInternalService returns the desired Service exposing the internal-only ports.
+// The Service has no ports when the instance has no internal ports.
+func buildInternalService(instance *llamav1alpha1.LlamaStackDistribution) *corev1.Service {
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      instance.Name + "-network-policy",
+			Name:      deploy.GetInternalServiceName(instance),
 			Namespace: instance.Namespace,
 		},
 	}
 
+	internalPorts := instance.GetPortsByExposure(llamav1alpha1.PortExposureInternal)
+	if len(internalPorts) == 0 {
+		return service
+	}
+
+	service.Labels = deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+		deploy.InstanceLabelKey: instance.Name,
+	})
+	service.Annotations = deploy.GetResourceAnnotations(instance)
+	service.Spec = corev1.ServiceSpec{
+		Type:     corev1.ServiceTypeClusterIP,
+		Selector: getPodSelectorLabels(instance),
+	}
+	for _, port := range internalPorts {
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.Port,
+			TargetPort: intstr.FromInt32(port.Port),
+			Protocol:   port.GetProtocol(),
+		})
+	}
+
+	return service
+}
+
+// reconcileNetworkPolicy manages the NetworkPolicy for the LlamaStack server.
+func (r *LlamaStackDistributionReconciler) reconcileNetworkPolicy(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	logger := log.FromContext(ctx)
+
 	// If feature is disabled, delete the NetworkPolicy if it exists
-	if !r.EnableNetworkPolicy {
+	if !r.networkPolicyEnabled() {
+		networkPolicy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      instance.Name + "-network-policy",
+				Namespace: instance.Namespace,
+			},
+		}
 		return deploy.HandleDisabledNetworkPolicy(ctx, r.Client, networkPolicy, logger)
 	}
 
-	port := deploy.GetServicePort(instance)
+	// Invalid peers are reported by the validation of the spec, keep the current NetworkPolicy until they are fixed
+	if err := validateNetworkPolicy(instance); err != nil {
+		logger.Info("skipping NetworkPolicy update", "error", err.Error())
+		return nil
+	}
 
-	// get operator namespace
+	networkPolicy, err := r.buildNetworkPolicy(instance)
+	if err != nil {
+		return err
+	}
+
+	if err := deploy.ApplyNetworkPolicy(ctx, r.Client, r.Scheme, instance, networkPolicy, logger); err != nil {
+		return err
+	}
+	return r.validateOperatorNamespaceLabels(ctx, instance)
+}
+
+// validateOperatorNamespaceLabels checks that the operator namespace carries the label the NetworkPolicy
+// matches to allow the health checks, and adds it when the operator config asks for it. A missing label
+// doesn't fail the reconcile, but the server is then unreachable from the operator.
+func (r *LlamaStackDistributionReconciler) validateOperatorNamespaceLabels(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
 	operatorNamespace, err := deploy.GetOperatorNamespace()
 	if err != nil {
 		return fmt.Errorf("failed to get operator namespace: %w", err)
 	}
+	// Read through the API reader, the cached client would start an informer caching every Namespace
+	namespace := &corev1.Namespace{}
+	if err := r.getAPIReader().Get(ctx, types.NamespacedName{Name: operatorNamespace}, namespace); err != nil {
+		if k8serrors.IsNotFound(err) {
+			SetOperatorNamespaceNotFoundCondition(instance, fmt.Sprintf("Operator namespace %s not found", operatorNamespace))
+			return nil
+		}
+		return fmt.Errorf("failed to get operator namespace: %w", err)
+	}
+
+	selector := r.NetworkPolicyConfig.OperatorNamespaceSelector(operatorNamespace)
+	missing := getMissingLabels(namespace.Labels, selector)
+	if len(missing) == 0 {
+		SetNetworkPolicyValidCondition(instance, true, MessageNetworkPolicyValid)
+		return nil
+	}
+	labels := make([]string, 0, len(missing))
+	for key, value := range missing {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	message := fmt.Sprintf("Operator namespace %s lacks the label %s matched by the NetworkPolicy, "+
+		"so the NetworkPolicy blocks the operator health checks", operatorNamespace, strings.Join(labels, ","))
+
+	if r.NetworkPolicyConfig.ShouldLabelOperatorNamespace() {
+		log.FromContext(ctx).Info("labeling operator namespace for NetworkPolicy", "namespace", operatorNamespace, "labels", missing)
+		patch := client.MergeFrom(namespace.DeepCopy())
+		if namespace.Labels == nil {
+			namespace.Labels = map[string]string{}
+		}
+		maps.Copy(namespace.Labels, missing)
+		err := r.Patch(ctx, namespace, patch)
+		switch {
+		case err == nil:
+			SetNetworkPolicyValidCondition(instance, true, MessageNetworkPolicyValid)
+			return nil
+		case k8serrors.IsForbidden(err):
+			// The patch permission is only granted by the opt-in namespace-labeler RBAC overlay
+			message += "; labelOperatorNamespace requires the namespace-labeler RBAC overlay to be deployed"
+		default:
+			return fmt.Errorf("failed to label operator namespace: %w", err)
+		}
+	}
+	SetNetworkPolicyValidCondition(instance, false, message)
+	return nil
+}
+
+// getMissingLabels returns the labels of the selector that the labels don't hold.
+func getMissingLabels(labels, selector map[string]string) map[string]string {
+	missing := map[string]string{}
+	for key, value := range selector {
+		if labels[key] != value {
+			missing[key] = value
+		}
+	}
+	return missing
+}
+
+// buildNetworkPolicy returns the desired NetworkPolicy restricting ingress to the LlamaStack server.
+func (r *LlamaStackDistributionReconciler) buildNetworkPolicy(instance *llamav1alpha1.LlamaStackDistribution) (*networkingv1.NetworkPolicy, error) {
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name + "-network-policy",
+			Namespace: instance.Namespace,
+			Labels: deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+				deploy.InstanceLabelKey: instance.Name,
+			}),
+			Annotations: deploy.GetResourceAnnotations(instance),
+		},
+	}
+
+	ports := getNetworkPolicyPorts(instance)
+
+	// get operator namespace
+	operatorNamespace, err := deploy.GetOperatorNamespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator namespace: %w", err)
+	}
 
 	networkPolicy.Spec = networkingv1.NetworkPolicySpec{
 		PodSelector: metav1.LabelSelector{
@@ -1077,51 +2042,42 @@ func (r *LlamaStackDistributionReconciler) reconcileNetworkPolicy(ctx context.Co
 		PolicyTypes: []networkingv1.PolicyType{
 			networkingv1.PolicyTypeIngress,
 		},
-		Ingress: []networkingv1.NetworkPolicyIngressRule{
-			{
-				From: []networkingv1.NetworkPolicyPeer{
-					{ // to match all pods in all namespaces
-						PodSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								"app.kubernetes.io/part-of": llamav1alpha1.DefaultContainerName,
-							},
-						},
-						NamespaceSelector: &metav1.LabelSelector{}, // Empty namespaceSelector to match all namespaces
-					},
-				},
-				Ports: []networkingv1.NetworkPolicyPort{
-					{
-						Protocol: (*corev1.Protocol)(ptr.To("TCP")),
-						Port: &intstr.IntOrString{
-							IntVal: port,
+	}
+	if !restrictsIngress(instance) {
+		networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{ // to match all pods in all namespaces
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app.kubernetes.io/part-of": llamav1alpha1.DefaultContainerName,
 						},
 					},
+					NamespaceSelector: &metav1.LabelSelector{}, // Empty namespaceSelector to match all namespaces
 				},
 			},
-			{
-				From: []networkingv1.NetworkPolicyPeer{
-					{ // to match all pods in matched namespace
-						PodSelector: &metav1.LabelSelector{},
-						NamespaceSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								"kubernetes.io/metadata.name": operatorNamespace,
-							},
-						},
-					},
-				},
-				Ports: []networkingv1.NetworkPolicyPort{
-					{
-						Protocol: (*corev1.Protocol)(ptr.To("TCP")),
-						Port: &intstr.IntOrString{
-							IntVal: port,
-						},
-					},
+			Ports: ports,
+		})
+	}
+	networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{ // to match all pods in matched namespace
+				PodSelector: &metav1.LabelSelector{},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: r.NetworkPolicyConfig.OperatorNamespaceSelector(operatorNamespace),
 				},
 			},
 		},
+		Ports: ports,
+	})
+	if rule := getAllowedIngressRule(instance, ports); rule != nil {
+		networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, *rule)
+	}
+	if egress := getEgressRules(instance); egress != nil {
+		networkPolicy.Spec.PolicyTypes = append(networkPolicy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		networkPolicy.Spec.Egress = egress
 	}
 
-	return deploy.ApplyNetworkPolicy(ctx, r.Client, r.Scheme, instance, networkPolicy, logger)
+	return networkPolicy, nil
 }
 
 // reconcileUserConfigMap validates that the referenced ConfigMap exists.
@@ -1395,9 +2351,107 @@ func parseFeatureFlags(configMapData map[string]string) (bool, error) {
 	return flags.EnableNetworkPolicy.Enabled, nil
 }
 
+// parseImageRegistryMirror extracts and parses the image registry mirror configuration from ConfigMap data.
+func parseImageRegistryMirror(configMapData map[string]string) (*registry.MirrorConfig, error) {
+	mirrorYAML, exists := configMapData[registry.MirrorConfigKey]
+	if !exists || strings.TrimSpace(mirrorYAML) == "" {
+		return nil, nil
+	}
+
+	mirrorConfig := &registry.MirrorConfig{}
+	if err := yaml.Unmarshal([]byte(mirrorYAML), mirrorConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse image registry mirror: %w", err)
+	}
+
+	return mirrorConfig, nil
+}
+
+// parseImageAllowlist extracts and validates the image allowlist from ConfigMap data.
+func parseImageAllowlist(configMapData map[string]string) (*registry.AllowlistConfig, error) {
+	allowlistYAML, exists := configMapData[registry.AllowlistConfigKey]
+	if !exists || strings.TrimSpace(allowlistYAML) == "" {
+		return nil, nil
+	}
+
+	allowlistConfig := &registry.AllowlistConfig{}
+	if err := yaml.Unmarshal([]byte(allowlistYAML), allowlistConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse image allowlist: %w", err)
+	}
+	if err := allowlistConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	return allowlistConfig, nil
+}
+
+// parseNetworkPolicyConfig extracts and parses the NetworkPolicy configuration from ConfigMap data.
+func parseNetworkPolicyConfig(configMapData map[string]string) (*deploy.NetworkPolicyConfig, error) {
+	networkPolicyYAML, exists := configMapData[deploy.NetworkPolicyConfigKey]
+	if !exists || strings.TrimSpace(networkPolicyYAML) == "" {
+		return nil, nil
+	}
+
+	networkPolicyConfig := &deploy.NetworkPolicyConfig{}
+	if err := yaml.Unmarshal([]byte(networkPolicyYAML), networkPolicyConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse network policy config: %w", err)
+	}
+
+	return networkPolicyConfig, nil
+}
+
+// parseLogCollectionConfig extracts and parses the log collection configuration from ConfigMap data.
+func parseLogCollectionConfig(configMapData map[string]string) (*deploy.LogCollectionConfig, error) {
+	logCollectionYAML, exists := configMapData[deploy.LogCollectionConfigKey]
+	if !exists || strings.TrimSpace(logCollectionYAML) == "" {
+		return nil, nil
+	}
+
+	logCollectionConfig := &deploy.LogCollectionConfig{}
+	if err := yaml.Unmarshal([]byte(logCollectionYAML), logCollectionConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse log collection config: %w", err)
+	}
+
+	return logCollectionConfig, nil
+}
+
+// getOperatorConfig fetches the operator config ConfigMap. When the ConfigMap doesn't exist it is
+// created with default feature flags if createIfMissing is set, otherwise the defaults are used
+// without writing anything to the cluster.
+func getOperatorConfig(ctx context.Context, client client.Client, configMapName types.NamespacedName,
+	createIfMissing bool) (*corev1.ConfigMap, error) {
+	logger := log.FromContext(ctx)
+	configMap := &corev1.ConfigMap{}
+
+	err := client.Get(ctx, configMapName, configMap)
+	if err == nil {
+		return configMap, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get ConfigMap: %w", err)
+	}
+
+	// ConfigMap doesn't exist, build it with defaults
+	configMap, err = createDefaultConfigMap(configMapName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate default configMap: %w", err)
+	}
+
+	if !createIfMissing {
+		logger.Info("operator config ConfigMap not found and creation is disabled, using default feature flags",
+			"configMap", configMapName)
+		return configMap, nil
+	}
+
+	if err = client.Create(ctx, configMap); err != nil {
+		return nil, fmt.Errorf("failed to create ConfigMap: %w", err)
+	}
+	return configMap, nil
+}
+
 // NewLlamaStackDistributionReconciler creates a new reconciler with default image mappings.
+// createOperatorConfig controls whether the operator config ConfigMap is created when it is missing.
 func NewLlamaStackDistributionReconciler(ctx context.Context, client client.Client, scheme *runtime.Scheme,
-	clusterInfo *cluster.ClusterInfo) (*LlamaStackDistributionReconciler, error) {
+	clusterInfo *cluster.ClusterInfo, createOperatorConfig bool) (*LlamaStackDistributionReconciler, error) {
 	// get operator namespace
 	operatorNamespace, err := deploy.GetOperatorNamespace()
 	if err != nil {
@@ -1405,28 +2459,15 @@ func NewLlamaStackDistributionReconciler(ctx context.Context, client client.Clie
 	}
 
 	// Get the ConfigMap
-	// If the ConfigMap doesn't exist, create it with default feature flags
+	// If the ConfigMap doesn't exist, fall back to default feature flags (creating it if allowed)
 	// If the ConfigMap exists, parse the feature flags from the Configmap
-	configMap := &corev1.ConfigMap{}
 	configMapName := types.NamespacedName{
 		Name:      operatorConfigData,
 		Namespace: operatorNamespace,
 	}
-
-	if err = client.Get(ctx, configMapName, configMap); err != nil {
-		if !k8serrors.IsNotFound(err) {
-			return nil, fmt.Errorf("failed to get ConfigMap: %w", err)
-		}
-
-		// ConfigMap doesn't exist, create it with defaults
-		configMap, err = createDefaultConfigMap(configMapName)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate default configMap: %w", err)
-		}
-
-		if err = client.Create(ctx, configMap); err != nil {
-			return nil, fmt.Errorf("failed to create ConfigMap: %w", err)
-		}
+	configMap, err := getOperatorConfig(ctx, client, configMapName, createOperatorConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse feature flags from ConfigMap
@@ -1434,12 +2475,56 @@ func NewLlamaStackDistributionReconciler(ctx context.Context, client client.Clie
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse feature flags: %w", err)
 	}
+
+	imageRegistryMirror, err := parseImageRegistryMirror(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+
+	imageAllowlist, err := parseImageAllowlist(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+
+	specAudit, err := parseSpecAudit(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+
+	networkPolicyConfig, err := parseNetworkPolicyConfig(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+
+	logCollectionConfig, err := parseLogCollectionConfig(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+
+	supportedServerVersions, err := parseSupportedServerVersions(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+
+	initializingRequeue, err := parseInitializingRequeue(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
 	return &LlamaStackDistributionReconciler{
-		Client:              client,
-		Scheme:              scheme,
-		EnableNetworkPolicy: enableNetworkPolicy,
-		ClusterInfo:         clusterInfo,
-		httpClient:          &http.Client{Timeout: 5 * time.Second},
+		Client:                  client,
+		Scheme:                  scheme,
+		EnableNetworkPolicy:     enableNetworkPolicy,
+		NetworkPolicyConfig:     networkPolicyConfig,
+		LogCollectionConfig:     logCollectionConfig,
+		ClusterInfo:             clusterInfo,
+		ImageRegistryMirror:     imageRegistryMirror,
+		ImageAllowlist:          imageAllowlist,
+		ImageDigestResolver:     registry.NewDigestClient(newHTTPClient()),
+		SpecAudit:               specAudit,
+		SupportedServerVersions: supportedServerVersions,
+		InitializingRequeue:     initializingRequeue,
+		httpClient:              newHTTPClient(),
+		operatorConfigName:      configMapName,
 	}, nil
 }
 
@@ -6,25 +6,36 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
 	controllers "github.com/llamastack/llama-stack-k8s-operator/controllers"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/audit"
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/cluster"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
 )
 
 // testenvNamespaceCounter is used to generate unique namespace names for test isolation.
@@ -374,17 +385,32 @@ func TestLlamaStackProviderAndVersionInfo(t *testing.T) {
 		Version: expectedLlamaStackVersionInfo,
 	}
 
+	// define the data structure for the mock models response
+	modelData := struct {
+		Data []llamav1alpha1.ModelInfo `json:"data"`
+	}{
+		Data: []llamav1alpha1.ModelInfo{
+			{Identifier: "llama3.2:1b", ProviderID: expectedProviderID, ModelType: "llm"},
+		},
+	}
+
 	// create the mock http client that uses our custom roundtripper
 	mockClient := &http.Client{
 		Transport: &mockRoundTripper{
 			// simulate the RoundTrip logic to handle different API paths
 			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/v1/health" {
+					return newMockAPIResponse(t, map[string]string{"status": "OK"}), nil
+				}
 				if req.URL.Path == "/v1/providers" {
 					return newMockAPIResponse(t, providerData), nil
 				}
 				if req.URL.Path == "/v1/version" {
 					return newMockAPIResponse(t, versionData), nil
 				}
+				if req.URL.Path == "/v1/models" {
+					return newMockAPIResponse(t, modelData), nil
+				}
 				return &http.Response{
 					StatusCode: http.StatusNotFound,
 					Body:       io.NopCloser(strings.NewReader("")),
@@ -449,10 +475,23 @@ func TestLlamaStackProviderAndVersionInfo(t *testing.T) {
 	require.Equal(t, expectedProviderID, actualProvider.ProviderID, "provider ID should match the mock response")
 	require.Equal(t, "OK", actualProvider.Health.Status, "provider health should match the mock response")
 	require.NotEmpty(t, actualProvider.Config, "provider config should be populated")
+	// validate model info
+	require.Equal(t, modelData.Data, updatedInstance.Status.DistributionConfig.Models, "models should match the mock response")
 	// validate llama stack version
 	require.Equal(t, expectedLlamaStackVersionInfo,
 		updatedInstance.Status.Version.LlamaStackServerVersion,
 		"server version should match the mock response")
+	// validate health check
+	require.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseReady, updatedInstance.Status.Phase)
+	require.True(t, controllers.IsConditionTrue(&updatedInstance.Status, controllers.ConditionTypeHealthCheck),
+		"health check condition should be true")
+	require.True(t, controllers.IsConditionTrue(&updatedInstance.Status, controllers.ConditionTypeProvidersHealthy),
+		"providers healthy condition should be true")
+	// validate the observed generation
+	require.Equal(t, updatedInstance.Generation, updatedInstance.Status.ObservedGeneration)
+	for _, condition := range updatedInstance.Status.Conditions {
+		require.Equal(t, updatedInstance.Generation, condition.ObservedGeneration, condition.Type)
+	}
 }
 
 func TestNetworkPolicyConfiguration(t *testing.T) {
@@ -507,3 +546,723 @@ func TestNetworkPolicyConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkPolicyOperatorNamespaceLabels(t *testing.T) {
+	// --- arrange ---
+	operatorNamespace := createTestNamespace(t, "test-np-operator")
+	t.Setenv("OPERATOR_NAMESPACE", operatorNamespace.Name)
+	namespace := createTestNamespace(t, "test-np-labels")
+	instance := NewDistributionBuilder().
+		WithName("np-labels").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	reconciler.EnableNetworkPolicy = true
+	reconciler.NetworkPolicyConfig = &deploy.NetworkPolicyConfig{OperatorNamespaceLabelKey: "team", OperatorNamespaceLabelValue: "llama"}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act: the label is missing ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	updated := &llamav1alpha1.LlamaStackDistribution{}
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, updated))
+	require.True(t, controllers.IsConditionFalse(&updated.Status, controllers.ConditionTypeNetworkPolicyValid),
+		"a missing operator namespace label should be reported")
+
+	// --- act: the operator labels its namespace ---
+	reconciler.NetworkPolicyConfig.LabelOperatorNamespace = true
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	labeled := &corev1.Namespace{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: operatorNamespace.Name}, labeled))
+	require.Equal(t, "llama", labeled.Labels["team"])
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, updated))
+	require.True(t, controllers.IsConditionTrue(&updated.Status, controllers.ConditionTypeNetworkPolicyValid))
+}
+
+func TestPreStartJobHoldsRollout(t *testing.T) {
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-prestart-job")
+	instance := NewDistributionBuilder().
+		WithName("prestart-job").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	instance.Spec.Server.PreStartJob = &llamav1alpha1.PreStartJobSpec{Command: []string{"llama", "stack", "migrate"}}
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act: the Job is created before the Deployment ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	jobs := &batchv1.JobList{}
+	require.NoError(t, k8sClient.List(context.Background(), jobs, client.InNamespace(namespace.Name)))
+	require.Len(t, jobs.Items, 1)
+	job := &jobs.Items[0]
+	require.Equal(t, []string{"llama", "stack", "migrate"}, job.Spec.Template.Spec.Containers[0].Command)
+	AssertResourceOwnedByInstance(t, job, instance)
+	deployment := &appsv1.Deployment{}
+	err = k8sClient.Get(context.Background(), req.NamespacedName, deployment)
+	require.True(t, apierrors.IsNotFound(err), "the Deployment should wait for the pre-start Job")
+
+	// --- act: the Job fails ---
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	require.NoError(t, k8sClient.Status().Update(context.Background(), job))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	updated := &llamav1alpha1.LlamaStackDistribution{}
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, updated))
+	condition := controllers.GetCondition(&updated.Status, controllers.ConditionTypePreStartJobComplete)
+	require.NotNil(t, condition)
+	require.Equal(t, controllers.ReasonPreStartJobFailed, condition.Reason)
+	err = k8sClient.Get(context.Background(), req.NamespacedName, deployment)
+	require.True(t, apierrors.IsNotFound(err), "the rollout should hold after a failed pre-start Job")
+
+	// --- act: the Job succeeds ---
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	require.NoError(t, k8sClient.Status().Update(context.Background(), job))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	waitForResource(t, k8sClient, namespace.Name, instance.Name, deployment)
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, updated))
+	require.True(t, controllers.IsConditionTrue(&updated.Status, controllers.ConditionTypePreStartJobComplete))
+}
+
+func TestAutoscalingConfiguration(t *testing.T) {
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-autoscaling")
+	instance := NewDistributionBuilder().
+		WithName("autoscaling").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	instance.Spec.Server.Autoscaling = &llamav1alpha1.AutoscalingSpec{MaxReplicas: 3}
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	waitForResource(t, k8sClient, namespace.Name, instance.Name, hpa)
+	require.Equal(t, int32(3), hpa.Spec.MaxReplicas)
+	require.Equal(t, instance.Name, hpa.Spec.ScaleTargetRef.Name)
+	AssertResourceOwnedByInstance(t, hpa, instance)
+
+	// --- act: autoscaling is disabled ---
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, instance))
+	instance.Spec.Server.Autoscaling = nil
+	require.NoError(t, k8sClient.Update(context.Background(), instance))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	err = k8sClient.Get(context.Background(), req.NamespacedName, hpa)
+	require.True(t, apierrors.IsNotFound(err), "the HorizontalPodAutoscaler should be deleted")
+	updated := &llamav1alpha1.LlamaStackDistribution{}
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, updated))
+	require.Nil(t, controllers.GetCondition(&updated.Status, controllers.ConditionTypeAutoscalingReady))
+}
+
+func TestIngressConfiguration(t *testing.T) {
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-ingress")
+	instance := NewDistributionBuilder().
+		WithName("ingress").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	instance.Spec.Server.Ingress = &llamav1alpha1.IngressSpec{
+		Host: "llama.example.com",
+		TLS:  &llamav1alpha1.IngressTLSSpec{SecretName: "llama-tls"},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	ingress := &networkingv1.Ingress{}
+	waitForResource(t, k8sClient, namespace.Name, instance.Name, ingress)
+	require.Len(t, ingress.Spec.Rules, 1)
+	require.Equal(t, "llama.example.com", ingress.Spec.Rules[0].Host)
+	require.Equal(t, instance.Name+"-service", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	require.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"llama.example.com"}, SecretName: "llama-tls"}}, ingress.Spec.TLS)
+	AssertResourceOwnedByInstance(t, ingress, instance)
+	updated := &llamav1alpha1.LlamaStackDistribution{}
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, updated))
+	require.True(t, controllers.IsConditionFalse(&updated.Status, controllers.ConditionTypeIngressReady),
+		"IngressReady should be false until the ingress controller assigns an address")
+
+	// --- act: the Ingress is removed ---
+	instance = updated
+	instance.Spec.Server.Ingress = nil
+	require.NoError(t, k8sClient.Update(context.Background(), instance))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	err = k8sClient.Get(context.Background(), req.NamespacedName, ingress)
+	require.True(t, apierrors.IsNotFound(err), "the Ingress should be deleted")
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, updated))
+	require.Nil(t, controllers.GetCondition(&updated.Status, controllers.ConditionTypeIngressReady))
+}
+
+func TestPodDisruptionBudgetConfiguration(t *testing.T) {
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-pdb")
+	instance := NewDistributionBuilder().
+		WithName("pdb").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	instance.Spec.Server.PodDisruptionBudget = &llamav1alpha1.PodDisruptionBudgetSpec{
+		MinAvailable:   ptr.To(intstr.FromInt32(1)),
+		MaxUnavailable: ptr.To(intstr.FromString("50%")),
+	}
+	require.Error(t, k8sClient.Create(context.Background(), instance), "minAvailable and maxUnavailable are mutually exclusive")
+	instance.Spec.Server.PodDisruptionBudget.MaxUnavailable = nil
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	pdb := &policyv1.PodDisruptionBudget{}
+	waitForResource(t, k8sClient, namespace.Name, instance.Name, pdb)
+	require.Equal(t, ptr.To(intstr.FromInt32(1)), pdb.Spec.MinAvailable)
+	require.Equal(t, instance.Name, pdb.Spec.Selector.MatchLabels[deploy.InstanceLabelKey])
+	AssertResourceOwnedByInstance(t, pdb, instance)
+
+	// --- act: the PodDisruptionBudget is removed ---
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, instance))
+	instance.Spec.Server.PodDisruptionBudget = nil
+	require.NoError(t, k8sClient.Update(context.Background(), instance))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	err = k8sClient.Get(context.Background(), req.NamespacedName, pdb)
+	require.True(t, apierrors.IsNotFound(err), "the PodDisruptionBudget should be deleted")
+}
+
+func TestInlineUserConfigConfiguration(t *testing.T) {
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-inline-config")
+	instance := NewDistributionBuilder().
+		WithName("inline-config").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{Inline: "version: '2'\nimage_name: starter\n"}
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	configMap := &corev1.ConfigMap{}
+	waitForResource(t, k8sClient, namespace.Name, instance.Name+"-user-config", configMap)
+	require.Equal(t, "version: '2'\nimage_name: starter\n", configMap.Data["run.yaml"])
+	AssertResourceOwnedByInstance(t, configMap, instance)
+	deployment := &appsv1.Deployment{}
+	waitForResource(t, k8sClient, namespace.Name, instance.Name, deployment)
+	initialHash := deployment.Spec.Template.Annotations["configmap.hash/user-config"]
+	require.NotEmpty(t, initialHash)
+
+	// --- act: the run.yaml changes ---
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, instance))
+	instance.Spec.Server.UserConfig.Inline = "version: '2'\nimage_name: remote-vllm\n"
+	require.NoError(t, k8sClient.Update(context.Background(), instance))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: configMap.Name, Namespace: namespace.Name}, configMap))
+	require.Equal(t, "version: '2'\nimage_name: remote-vllm\n", configMap.Data["run.yaml"])
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, deployment))
+	require.NotEqual(t, initialHash, deployment.Spec.Template.Annotations["configmap.hash/user-config"], "the pods should be rolled out")
+}
+
+func TestReferencedObjectsRollout(t *testing.T) {
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-referenced-objects")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: namespace.Name},
+		StringData: map[string]string{"token": "initial"},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), secret))
+	instance := NewDistributionBuilder().
+		WithName("referenced-objects").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	instance.Spec.Server.ContainerSpec.EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}}},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	deployment := &appsv1.Deployment{}
+	waitForResource(t, k8sClient, namespace.Name, instance.Name, deployment)
+	initialHash := deployment.Spec.Template.Annotations["llamastack.io/referenced-objects-hash"]
+	require.NotEmpty(t, initialHash)
+
+	// --- act: the Secret changes ---
+	secret.StringData = map[string]string{"token": "rotated"}
+	require.NoError(t, k8sClient.Update(context.Background(), secret))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, deployment))
+	require.NotEqual(t, initialHash, deployment.Spec.Template.Annotations["llamastack.io/referenced-objects-hash"],
+		"the pods should be rolled out")
+}
+
+func TestServiceAccountValidation(t *testing.T) {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-sa-validation")
+	instance := NewDistributionBuilder().
+		WithName("sa-validation").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		WithServiceAccountName("missing-sa").
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// --- assert ---
+	require.Error(t, err, "reconciliation should fail when the referenced ServiceAccount is missing")
+
+	updatedInstance := &llamav1alpha1.LlamaStackDistribution{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name, updatedInstance)
+	require.True(t, controllers.IsConditionFalse(&updatedInstance.Status, controllers.ConditionTypeServiceAccountReady),
+		"ServiceAccountReady condition should be false")
+	require.Equal(t, llamav1alpha1.LlamaStackDistributionPhaseFailed, updatedInstance.Status.Phase)
+
+	deployment := &appsv1.Deployment{}
+	err = k8sClient.Get(context.Background(), req.NamespacedName, deployment)
+	require.True(t, apierrors.IsNotFound(err), "deployment should not be created for a missing ServiceAccount")
+
+	// --- act: create the ServiceAccount and reconcile again ---
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing-sa", Namespace: namespace.Name},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), serviceAccount))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	waitForResourceWithKeyAndCondition(t, k8sClient, req.NamespacedName, updatedInstance, func() bool {
+		return controllers.IsConditionTrue(&updatedInstance.Status, controllers.ConditionTypeServiceAccountReady)
+	}, "ServiceAccountReady condition should become true once the ServiceAccount exists")
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name, deployment)
+	require.Equal(t, "missing-sa", deployment.Spec.Template.Spec.ServiceAccountName)
+}
+
+func TestOperatorConfigCreation(t *testing.T) {
+	tests := []struct {
+		name                 string
+		createOperatorConfig bool
+		expectCreated        bool
+	}{
+		{
+			name:                 "creates the ConfigMap when missing",
+			createOperatorConfig: true,
+			expectCreated:        true,
+		},
+		{
+			name:                 "only reads the ConfigMap when creation is disabled",
+			createOperatorConfig: false,
+			expectCreated:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- arrange ---
+			namespace := createTestNamespace(t, "test-operator-config")
+			t.Setenv("OPERATOR_NAMESPACE", namespace.Name)
+
+			// --- act ---
+			reconciler, err := controllers.NewLlamaStackDistributionReconciler(context.Background(), k8sClient,
+				scheme.Scheme, &cluster.ClusterInfo{}, tt.createOperatorConfig)
+
+			// --- assert ---
+			require.NoError(t, err)
+			require.False(t, reconciler.EnableNetworkPolicy, "default feature flags should be used")
+
+			configMap := &corev1.ConfigMap{}
+			err = k8sClient.Get(context.Background(),
+				types.NamespacedName{Name: "llama-stack-operator-config", Namespace: namespace.Name}, configMap)
+			if tt.expectCreated {
+				require.NoError(t, err, "operator config ConfigMap should be created")
+			} else {
+				require.True(t, apierrors.IsNotFound(err), "operator config ConfigMap should not be created")
+			}
+		})
+	}
+}
+
+func TestSweepOrphanedResources(t *testing.T) {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-orphan-sweep")
+	instance := NewDistributionBuilder().
+		WithName("sweep-live").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	managedLabels := func(instanceName string) map[string]string {
+		return map[string]string{
+			"app.kubernetes.io/managed-by":     "llama-stack-operator",
+			"app.kubernetes.io/instance":       instanceName,
+			"llamastack.io/instance-namespace": namespace.Name,
+		}
+	}
+	liveSA := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name: "sweep-live-sa", Namespace: namespace.Name, Labels: managedLabels(instance.Name),
+	}}
+	orphanedSA := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name: "sweep-renamed-sa", Namespace: namespace.Name, Labels: managedLabels("sweep-renamed"),
+	}}
+	unmanagedSA := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name: "user-sa", Namespace: namespace.Name, Labels: map[string]string{"app.kubernetes.io/instance": "sweep-renamed"},
+	}}
+	for _, sa := range []*corev1.ServiceAccount{liveSA, orphanedSA, unmanagedSA} {
+		require.NoError(t, k8sClient.Create(context.Background(), sa))
+	}
+
+	// --- act ---
+	require.NoError(t, createTestReconciler().SweepOrphanedResources(context.Background()))
+
+	// --- assert ---
+	require.Eventually(t, func() bool {
+		err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(orphanedSA), &corev1.ServiceAccount{})
+		return apierrors.IsNotFound(err)
+	}, testTimeout, testInterval, "orphaned ServiceAccount should be deleted")
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(liveSA), &corev1.ServiceAccount{}),
+		"ServiceAccount of an existing instance should be kept")
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unmanagedSA), &corev1.ServiceAccount{}),
+		"ServiceAccount not managed by the operator should be kept")
+}
+
+func TestInternalServiceConfiguration(t *testing.T) {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-internal-service")
+	instance := NewDistributionBuilder().
+		WithName("internal-svc").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		WithPort(llamav1alpha1.DefaultServerPort).
+		WithAdditionalPorts(
+			llamav1alpha1.PortSpec{Name: "grpc", Port: 9000, Exposure: llamav1alpha1.PortExposurePublic},
+			llamav1alpha1.PortSpec{Name: "metrics", Port: 9090, Exposure: llamav1alpha1.PortExposureInternal},
+			llamav1alpha1.PortSpec{Name: "stream", Port: 9500, Exposure: llamav1alpha1.PortExposurePublic, Protocol: corev1.ProtocolUDP},
+		).
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	// --- act ---
+	ReconcileDistribution(t, instance, false)
+
+	// --- assert ---
+	service := &corev1.Service{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name+"-service", service)
+	internalService := &corev1.Service{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name+"-internal-service", internalService)
+	deployment := &appsv1.Deployment{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name, deployment)
+
+	servicePorts := map[string]int32{}
+	for _, port := range service.Spec.Ports {
+		servicePorts[port.Name] = port.Port
+		if port.Name == "stream" {
+			require.Equal(t, corev1.ProtocolUDP, port.Protocol, "Service port should keep the declared protocol")
+		}
+	}
+	require.Equal(t, map[string]int32{"http": llamav1alpha1.DefaultServerPort, "grpc": 9000, "stream": 9500}, servicePorts,
+		"server Service should expose the server port and the public ports")
+
+	require.Equal(t, corev1.ServiceTypeClusterIP, internalService.Spec.Type)
+	require.Len(t, internalService.Spec.Ports, 1)
+	require.Equal(t, "metrics", internalService.Spec.Ports[0].Name)
+	require.Equal(t, int32(9090), internalService.Spec.Ports[0].Port)
+	AssertServiceAndDeploymentSelectorsAlign(t, internalService, deployment)
+	AssertResourceOwnedByInstance(t, internalService, instance)
+
+	updatedInstance := &llamav1alpha1.LlamaStackDistribution{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name, updatedInstance)
+	require.Len(t, updatedInstance.Status.Services, 2, "status should report both Services")
+	require.Equal(t, llamav1alpha1.PortExposurePublic, updatedInstance.Status.Services[0].Exposure)
+	require.Equal(t, llamav1alpha1.PortExposureInternal, updatedInstance.Status.Services[1].Exposure)
+
+	// --- act: remove the internal port ---
+	ports := updatedInstance.Spec.Server.ContainerSpec.Ports
+	updatedInstance.Spec.Server.ContainerSpec.Ports = []llamav1alpha1.PortSpec{ports[0], ports[2]}
+	require.NoError(t, k8sClient.Update(context.Background(), updatedInstance))
+	ReconcileDistribution(t, instance, false)
+
+	// --- assert ---
+	require.Eventually(t, func() bool {
+		err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(internalService), &corev1.Service{})
+		return apierrors.IsNotFound(err)
+	}, testTimeout, testInterval, "internal Service should be deleted once no internal port is declared")
+}
+
+func TestDeploymentSelectorConflict(t *testing.T) {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-selector-conflict")
+	instance := NewDistributionBuilder().
+		WithName("selector-conflict").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	// a foreign Deployment whose selector would claim the pods of the instance
+	greedyLabels := map[string]string{llamav1alpha1.DefaultLabelKey: llamav1alpha1.DefaultLabelValue}
+	greedy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "greedy", Namespace: namespace.Name},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: greedyLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: greedyLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: testImage}},
+				},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), greedy))
+
+	reconciler := createTestReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}
+
+	// --- act ---
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// --- assert ---
+	require.Error(t, err, "reconciliation should fail when the selector overlaps with another Deployment")
+
+	updatedInstance := &llamav1alpha1.LlamaStackDistribution{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name, updatedInstance)
+	condition := controllers.GetCondition(&updatedInstance.Status, controllers.ConditionTypeSelectorValid)
+	require.NotNil(t, condition, "SelectorValid condition should be set")
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, controllers.ReasonSelectorConflict, condition.Reason)
+	require.Contains(t, condition.Message, "greedy")
+
+	deployment := &appsv1.Deployment{}
+	err = k8sClient.Get(context.Background(), req.NamespacedName, deployment)
+	require.True(t, apierrors.IsNotFound(err), "deployment should not be created while the selector conflicts")
+
+	// --- act: remove the conflicting Deployment and reconcile again ---
+	require.NoError(t, k8sClient.Delete(context.Background(), greedy))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// --- assert ---
+	waitForResourceWithKeyAndCondition(t, k8sClient, req.NamespacedName, updatedInstance, func() bool {
+		return controllers.IsConditionTrue(&updatedInstance.Status, controllers.ConditionTypeSelectorValid)
+	}, "SelectorValid condition should become true once the conflict is resolved")
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name, deployment)
+}
+
+func TestStorageStatusWaitForFirstConsumer(t *testing.T) {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// --- arrange ---
+	bindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "wait-for-consumer",
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		},
+		Provisioner:       "kubernetes.io/no-provisioner",
+		VolumeBindingMode: &bindingMode,
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), storageClass))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), storageClass) })
+
+	namespace := createTestNamespace(t, "test-storage-wffc")
+	instance := NewDistributionBuilder().
+		WithName("storage-wffc").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		WithStorage(DefaultTestStorage()).
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	// --- act ---
+	ReconcileDistribution(t, instance, false)
+
+	// --- assert ---
+	// No volume provisioner runs in envtest, so the PVC stays Pending.
+	pvc := &corev1.PersistentVolumeClaim{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name+"-pvc", pvc)
+	require.Equal(t, corev1.ClaimPending, pvc.Status.Phase)
+
+	updatedInstance := &llamav1alpha1.LlamaStackDistribution{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name, updatedInstance)
+	condition := controllers.GetCondition(&updatedInstance.Status, controllers.ConditionTypeStorageReady)
+	require.NotNil(t, condition, "StorageReady condition should be set")
+	require.Equal(t, metav1.ConditionUnknown, condition.Status,
+		"a Pending PVC waiting for its first consumer should not be reported as a failure")
+	require.Equal(t, controllers.ReasonStorageWaitingForConsumer, condition.Reason)
+}
+
+func TestRecordSpecChangeConfigMap(t *testing.T) {
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-spec-audit")
+	instance := NewDistributionBuilder().
+		WithName("spec-audit").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+
+	reconciler := createTestReconciler()
+	reconciler.SpecAudit = &audit.Config{Sink: audit.SinkConfigMap, MaxEntries: 2}
+
+	// --- act ---
+	require.NoError(t, reconciler.RecordSpecChange(context.Background(), instance))
+	for _, replicas := range []int32{2, 3, 4} {
+		instance.Spec.Replicas = ptr.To(replicas)
+		require.NoError(t, k8sClient.Update(context.Background(), instance))
+		require.NoError(t, reconciler.RecordSpecChange(context.Background(), instance))
+	}
+
+	// --- assert ---
+	configMap := &corev1.ConfigMap{}
+	waitForResource(t, k8sClient, instance.Namespace, instance.Name+audit.ConfigMapSuffix, configMap)
+	AssertResourceOwnedByInstance(t, configMap, instance)
+	require.Len(t, configMap.Data, 2, "the audit ConfigMap should keep at most maxEntries records")
+
+	generations := []int64{}
+	for _, value := range configMap.Data {
+		var record audit.Record
+		require.NoError(t, json.Unmarshal([]byte(value), &record))
+		require.Equal(t, instance.Name, record.Name)
+		require.NotEmpty(t, record.Manager)
+		require.Contains(t, record.Diff, "Replicas")
+		generations = append(generations, record.Generation)
+	}
+	require.ElementsMatch(t, []int64{instance.Generation - 1, instance.Generation}, generations,
+		"the oldest record should be dropped")
+}
+
+func TestManifestsHandler(t *testing.T) {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// --- arrange ---
+	namespace := createTestNamespace(t, "test-manifests")
+	instance := NewDistributionBuilder().
+		WithName("manifests").
+		WithNamespace(namespace.Name).
+		WithDistribution("starter").
+		WithPort(llamav1alpha1.DefaultServerPort).
+		Build()
+	require.NoError(t, k8sClient.Create(context.Background(), instance))
+	t.Cleanup(func() { _ = k8sClient.Delete(context.Background(), instance) })
+	handler := &controllers.ManifestsHandler{Reconciler: createTestReconciler()}
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	// --- act ---
+	response := serve(http.MethodGet, controllers.ManifestsPath+namespace.Name+"/"+instance.Name)
+
+	// --- assert ---
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	require.Equal(t, "application/yaml", response.Header().Get("Content-Type"))
+	kinds := map[string]string{}
+	for _, document := range strings.Split(response.Body.String(), "---\n") {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		require.NoError(t, yaml.Unmarshal([]byte(document), &obj.Object))
+		kinds[obj.GetKind()] = obj.GetName()
+		require.Empty(t, obj.GetOwnerReferences(), "rendered objects should not be applied")
+	}
+	require.Equal(t, instance.Name, kinds["Deployment"])
+	require.Equal(t, instance.Name+"-service", kinds["Service"])
+
+	deployment := &appsv1.Deployment{}
+	err := k8sClient.Get(context.Background(), types.NamespacedName{Name: instance.Name, Namespace: namespace.Name}, deployment)
+	require.True(t, apierrors.IsNotFound(err), "rendering should not create the Deployment")
+
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, controllers.ManifestsPath+namespace.Name+"/missing").Code)
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodGet, controllers.ManifestsPath+namespace.Name).Code)
+	require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, controllers.ManifestsPath+namespace.Name+"/"+instance.Name).Code)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// maxMaintenanceWindowDuration is the longest a maintenance window stays open.
+	maxMaintenanceWindowDuration = 24 * time.Hour
+	// daysPerWeek is the number of days searched for the next opening of a maintenance window.
+	daysPerWeek = 7
+)
+
+// maintenanceWindow is a parsed MaintenanceWindowSpec.
+type maintenanceWindow struct {
+	days     []time.Weekday
+	start    time.Time
+	duration time.Duration
+	location *time.Location
+}
+
+// parseMaintenanceWindow validates the maintenance window of the spec.
+func parseMaintenanceWindow(spec *llamav1alpha1.MaintenanceWindowSpec) (*maintenanceWindow, error) {
+	start, err := time.Parse("15:04", spec.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse maintenance window start %q: %w", spec.Start, err)
+	}
+	if spec.Duration.Duration <= 0 || spec.Duration.Duration > maxMaintenanceWindowDuration {
+		return nil, fmt.Errorf("failed to validate maintenance window: duration %s must be positive and at most %s",
+			spec.Duration.Duration, maxMaintenanceWindowDuration)
+	}
+	location, err := time.LoadLocation(spec.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load maintenance window time zone %q: %w", spec.TimeZone, err)
+	}
+
+	window := &maintenanceWindow{start: start, duration: spec.Duration.Duration, location: location}
+	for _, day := range spec.Days {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return nil, err
+		}
+		window.days = append(window.days, weekday)
+	}
+	return window, nil
+}
+
+// parseWeekday returns the day of the week named by the maintenance day.
+func parseWeekday(day llamav1alpha1.MaintenanceDay) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if weekday.String() == string(day) {
+			return weekday, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("failed to validate maintenance window: unknown day %q", day)
+}
+
+// state reports whether the window is open at the given time and, when it is closed, when it opens next.
+func (w *maintenanceWindow) state(now time.Time) (bool, time.Time) {
+	local := now.In(w.location)
+	// Start from the previous day, whose window may still be open past midnight
+	for offset := -1; offset <= daysPerWeek; offset++ {
+		opening := time.Date(local.Year(), local.Month(), local.Day()+offset, w.start.Hour(), w.start.Minute(), 0, 0, w.location)
+		if len(w.days) > 0 && !slices.Contains(w.days, opening.Weekday()) {
+			continue
+		}
+		if !now.Before(opening) && now.Before(opening.Add(w.duration)) {
+			return true, time.Time{}
+		}
+		if opening.After(now) {
+			return false, opening
+		}
+	}
+	return false, time.Time{}
+}
+
+// validateMaintenanceWindow checks the maintenance window of the instance, if any.
+func validateMaintenanceWindow(instance *llamav1alpha1.LlamaStackDistribution) error {
+	if instance.Spec.MaintenanceWindow == nil {
+		return nil
+	}
+	_, err := parseMaintenanceWindow(instance.Spec.MaintenanceWindow)
+	return err
+}
+
+// applyMaintenanceWindow keeps the pod template of the live Deployment in the desired one while the maintenance
+// window of the instance is closed, so that changes restarting the server pods wait for the window to open.
+func (r *LlamaStackDistributionReconciler) applyMaintenanceWindow(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	desired *appsv1.Deployment) error {
+	if instance.Spec.MaintenanceWindow == nil {
+		if GetCondition(&instance.Status, ConditionTypeTemplateApplied) != nil {
+			SetTemplateAppliedCondition(instance, true, "")
+		}
+		return nil
+	}
+
+	live := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to fetch deployment for maintenance window: %w", err)
+		}
+		// Nothing runs yet, so creating the Deployment disrupts nothing
+		live = nil
+	}
+
+	wasDeferred := IsConditionFalse(&instance.Status, ConditionTypeTemplateApplied)
+	deferred, err := deferTemplateChanges(instance, live, desired, time.Now())
+	if err != nil {
+		return err
+	}
+	if deferred && !wasDeferred {
+		log.FromContext(ctx).Info("deferring pod template changes until the maintenance window opens", "deployment", desired.Name)
+	}
+	return nil
+}
+
+// deferTemplateChanges replaces the pod template of the desired Deployment with the live one when the live
+// Deployment doesn't run it outside of the maintenance window, and reports whether it did. The live Deployment
+// keeps its applied hash, so that the changes stay deferred until the window opens.
+func deferTemplateChanges(instance *llamav1alpha1.LlamaStackDistribution, live, desired *appsv1.Deployment, now time.Time) (bool, error) {
+	window, err := parseMaintenanceWindow(instance.Spec.MaintenanceWindow)
+	if err != nil {
+		return false, err
+	}
+	open, opensAt := window.state(now)
+	if open || live == nil {
+		SetTemplateAppliedCondition(instance, true, "")
+		return false, nil
+	}
+	desiredHash, err := getAppliedTemplateHash(desired)
+	if err != nil {
+		return false, err
+	}
+	applied, err := isTemplateApplied(live, &desired.Spec.Template, desiredHash)
+	if err != nil {
+		return false, err
+	}
+	if applied {
+		SetTemplateAppliedCondition(instance, true, "")
+		return false, nil
+	}
+
+	desired.Spec.Template = *live.Spec.Template.DeepCopy()
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	setAppliedTemplateHashAnnotation(annotations, live.Annotations[appliedTemplateHashAnnotation])
+	desired.SetAnnotations(annotations)
+	SetTemplateAppliedCondition(instance, false,
+		fmt.Sprintf("Pod template changes are deferred until the maintenance window opens at %s", opensAt.UTC().Format(time.RFC3339)))
+	return true, nil
+}
+
+// getMaintenanceWindowRemaining returns how long the deferred pod template changes of the instance still wait
+// for the maintenance window to open.
+func getMaintenanceWindowRemaining(instance *llamav1alpha1.LlamaStackDistribution, now time.Time) time.Duration {
+	if instance.Spec.MaintenanceWindow == nil || !IsConditionFalse(&instance.Status, ConditionTypeTemplateApplied) {
+		return 0
+	}
+	window, err := parseMaintenanceWindow(instance.Spec.MaintenanceWindow)
+	if err != nil {
+		return 0
+	}
+	_, opensAt := window.state(now)
+	if opensAt.IsZero() {
+		return 0
+	}
+	return opensAt.Sub(now)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindowState(t *testing.T) {
+	// Saturday and Sunday from 22:00 to 02:00 in Paris
+	window, err := parseMaintenanceWindow(&llamav1alpha1.MaintenanceWindowSpec{
+		Days:     []llamav1alpha1.MaintenanceDay{"Saturday", "Sunday"},
+		Start:    "22:00",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+		TimeZone: "Europe/Paris",
+	})
+	require.NoError(t, err)
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		now             time.Time
+		expectedOpen    bool
+		expectedOpensAt time.Time
+	}{
+		{
+			name:            "closed on a weekday",
+			now:             time.Date(2025, time.June, 4, 23, 0, 0, 0, paris),
+			expectedOpensAt: time.Date(2025, time.June, 7, 22, 0, 0, 0, paris),
+		},
+		{
+			name:         "open on Saturday evening",
+			now:          time.Date(2025, time.June, 7, 23, 0, 0, 0, paris),
+			expectedOpen: true,
+		},
+		{
+			name:         "still open after midnight",
+			now:          time.Date(2025, time.June, 9, 1, 30, 0, 0, paris),
+			expectedOpen: true,
+		},
+		{
+			name:            "closed once the Sunday window ends",
+			now:             time.Date(2025, time.June, 9, 2, 0, 0, 0, paris),
+			expectedOpensAt: time.Date(2025, time.June, 14, 22, 0, 0, 0, paris),
+		},
+		{
+			name:            "start is in the window time zone",
+			now:             time.Date(2025, time.June, 7, 19, 0, 0, 0, time.UTC),
+			expectedOpensAt: time.Date(2025, time.June, 7, 22, 0, 0, 0, paris),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			open, opensAt := window.state(tc.now)
+			assert.Equal(t, tc.expectedOpen, open)
+			assert.True(t, tc.expectedOpensAt.Equal(opensAt), "expected %s, got %s", tc.expectedOpensAt, opensAt)
+		})
+	}
+}
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	for _, spec := range []llamav1alpha1.MaintenanceWindowSpec{
+		{Start: "24:00", Duration: metav1.Duration{Duration: time.Hour}},
+		{Start: "02:00"},
+		{Start: "02:00", Duration: metav1.Duration{Duration: 25 * time.Hour}},
+		{Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus_Mons"},
+		{Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}, Days: []llamav1alpha1.MaintenanceDay{"Caturday"}},
+	} {
+		_, err := parseMaintenanceWindow(&spec)
+		require.Error(t, err, "expected %+v to be rejected", spec)
+	}
+}
+
+func TestDeferTemplateChanges(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{
+		ImageVolumes: []llamav1alpha1.ImageVolumeSpec{{Name: "models", Reference: "models:v1", MountPath: "/models"}},
+	}
+	// Daily from 02:00 to 04:00 UTC
+	instance.Spec.MaintenanceWindow = &llamav1alpha1.MaintenanceWindowSpec{Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+	closed := time.Date(2025, time.June, 4, 12, 0, 0, 0, time.UTC)
+
+	live := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: newRollbackTestTemplate("llama-stack:old")}}
+	live.Spec.Template.Annotations = map[string]string{}
+	require.NoError(t, addImageVolumes(instance, &live.Spec.Template.Spec, live.Spec.Template.Annotations))
+
+	t.Run("creation is not deferred", func(t *testing.T) {
+		desired := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: newRollbackTestTemplate("llama-stack:new")}}
+		deferred, err := deferTemplateChanges(instance, nil, desired, closed)
+		require.NoError(t, err)
+		assert.False(t, deferred)
+		assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeTemplateApplied))
+	})
+
+	t.Run("changes are deferred while the window is closed", func(t *testing.T) {
+		desired := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: newRollbackTestTemplate("llama-stack:new")}}
+		deferred, err := deferTemplateChanges(instance, live, desired, closed)
+		require.NoError(t, err)
+		assert.True(t, deferred)
+		assert.Equal(t, "llama-stack:old", desired.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, live.Spec.Template.Spec.Volumes, desired.Spec.Template.Spec.Volumes)
+		assert.Equal(t, live.Spec.Template.Annotations, desired.Spec.Template.Annotations,
+			"the deferred template keeps its image volumes")
+		desired.Spec.Template.Spec.Volumes = nil
+		assert.Len(t, live.Spec.Template.Spec.Volumes, 1, "live deployment must not be modified")
+
+		condition := GetCondition(&instance.Status, ConditionTypeTemplateApplied)
+		require.NotNil(t, condition)
+		assert.Equal(t, ReasonMaintenanceWindowClosed, condition.Reason)
+		assert.Contains(t, condition.Message, "2025-06-05T02:00:00Z")
+		assert.Equal(t, 14*time.Hour, getMaintenanceWindowRemaining(instance, closed))
+	})
+
+	t.Run("removed fields are deferred while the window is closed", func(t *testing.T) {
+		current := newRollbackTestTemplate("llama-stack:old")
+		current.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "INFERENCE_MODEL", Value: "granite"}}
+		currentHash, err := getPodTemplateHash(&current)
+		require.NoError(t, err)
+		annotated := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{appliedTemplateHashAnnotation: currentHash}},
+			Spec:       appsv1.DeploymentSpec{Template: current},
+		}
+
+		// The desired template is a subset of the live one
+		desired := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: newRollbackTestTemplate("llama-stack:old")}}
+		deferred, err := deferTemplateChanges(instance, annotated, desired, closed)
+		require.NoError(t, err)
+		assert.True(t, deferred)
+		assert.Len(t, desired.Spec.Template.Spec.Containers[0].Env, 1)
+		assert.Equal(t, currentHash, desired.Annotations[appliedTemplateHashAnnotation], "the live applied hash is kept")
+
+		desired = &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: *current.DeepCopy()}}
+		deferred, err = deferTemplateChanges(instance, annotated, desired, closed)
+		require.NoError(t, err)
+		assert.False(t, deferred, "the applied template isn't a change")
+	})
+
+	t.Run("changes are applied once the window opens", func(t *testing.T) {
+		desired := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: newRollbackTestTemplate("llama-stack:new")}}
+		deferred, err := deferTemplateChanges(instance, live, desired, time.Date(2025, time.June, 5, 2, 30, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.False(t, deferred)
+		assert.Equal(t, "llama-stack:new", desired.Spec.Template.Spec.Containers[0].Image)
+		assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeTemplateApplied))
+		assert.Zero(t, getMaintenanceWindowRemaining(instance, closed))
+	})
+}
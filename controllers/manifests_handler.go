@@ -0,0 +1,224 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestsPath is the path, on the metrics server, of the endpoint serving the desired objects of an instance
+// as GET <ManifestsPath><namespace>/<name>.
+const ManifestsPath = "/manifests/"
+
+// RenderDesiredObjects returns the objects the operator manages for the instance, as they would be applied,
+// without changing anything in the cluster, recording Events or updating the status of the instance. Owner
+// references and the desired-state hash annotation are only added when the objects are applied, and are not
+// part of the returned objects.
+func (r *LlamaStackDistributionReconciler) RenderDesiredObjects(ctx context.Context,
+	instance *llamav1alpha1.LlamaStackDistribution) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	if hasInlineUserConfig(instance) {
+		u, err := r.toDesiredUnstructured(buildInlineUserConfigMap(instance))
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, u)
+	}
+
+	resMap, err := r.renderManifestResources(instance)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range (*resMap).Resources() {
+		objMap, err := res.Map()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert resource %s/%s: %w", res.GetKind(), res.GetName(), err)
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: objMap})
+	}
+
+	// The image is rendered with the digest already pinned, without resolving it again
+	image, err := r.resolveServerImage(instance)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := r.buildDeployment(ctx, instance, getPinnedImage(instance, image))
+	if err != nil {
+		return nil, err
+	}
+	typed, err := r.buildOptionalObjects(instance, deployment)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range typed {
+		u, err := r.toDesiredUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, u)
+	}
+	// Routes and ServiceMonitors are built unstructured, their types aren't in the scheme
+	if r.routesSupported() && instance.Spec.Server.Route != nil {
+		objects = append(objects, buildRoute(instance))
+	}
+	if r.serviceMonitorsSupported() && metricsEnabled(instance) {
+		objects = append(objects, buildServiceMonitor(instance))
+	}
+
+	u, err := r.toDesiredWithImageVolumes(deployment, "spec", "template")
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, u)
+	if instance.Spec.Server.Autoscaling != nil {
+		hpa, err := r.toDesiredUnstructured(buildHorizontalPodAutoscaler(instance))
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, hpa)
+	}
+	return objects, nil
+}
+
+// buildOptionalObjects returns the typed objects that are only reconciled when the spec or the operator
+// config asks for them, under the same conditions as their reconcile functions.
+func (r *LlamaStackDistributionReconciler) buildOptionalObjects(instance *llamav1alpha1.LlamaStackDistribution,
+	deployment *appsv1.Deployment) ([]client.Object, error) {
+	var objects []client.Object
+	if service := buildInternalService(instance); len(service.Spec.Ports) > 0 {
+		objects = append(objects, service)
+	}
+	if r.networkPolicyEnabled() {
+		networkPolicy, err := r.buildNetworkPolicy(instance)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, networkPolicy)
+	}
+	if instance.Spec.Server.Ingress != nil {
+		objects = append(objects, buildIngress(instance))
+	}
+	if instance.Spec.Server.PodDisruptionBudget != nil {
+		objects = append(objects, buildPodDisruptionBudget(instance))
+	}
+	if createsServiceAccount(instance) {
+		objects = append(objects, buildServiceAccount(instance))
+		if role, roleBinding := buildServiceAccountRole(instance); len(role.Rules) > 0 {
+			objects = append(objects, role, roleBinding)
+		}
+	}
+	if instance.Spec.Server.PreStartJob != nil {
+		job, err := buildPreStartJob(instance, &deployment.Spec.Template)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, job)
+	}
+	return objects, nil
+}
+
+// toDesiredUnstructured converts a typed object to an unstructured one with its kind set,
+// dropping the empty status and creation timestamp of objects that were never persisted.
+func (r *LlamaStackDistributionReconciler) toDesiredUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kind of %T: %w", obj, err)
+	}
+	objMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+
+	u := &unstructured.Unstructured{Object: objMap}
+	u.SetGroupVersionKind(gvk)
+	unstructured.RemoveNestedField(u.Object, "status")
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	return u, nil
+}
+
+// ManifestsHandler serves the desired objects of a LlamaStackDistribution as a multi-document YAML stream,
+// so that GitOps tools can diff them against the cluster.
+type ManifestsHandler struct {
+	// Reconciler renders the objects. Requests fail with 503 until it is set.
+	Reconciler *LlamaStackDistributionReconciler
+}
+
+// ServeHTTP renders the objects of the LlamaStackDistribution named in the request path.
+func (h *ManifestsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Reconciler == nil {
+		http.Error(w, "the controller is not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	namespace, name, found := strings.Cut(strings.TrimPrefix(req.URL.Path, ManifestsPath), "/")
+	if !found || namespace == "" || name == "" || strings.Contains(name, "/") {
+		http.Error(w, "expected "+ManifestsPath+"<namespace>/<name>", http.StatusBadRequest)
+		return
+	}
+
+	ctx := req.Context()
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	if err := h.Reconciler.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, instance); err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.FromContext(ctx).Error(err, "failed to get LlamaStackDistribution", "namespace", namespace, "name", name)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	objects, err := h.Reconciler.RenderDesiredObjects(ctx, instance)
+	if err != nil {
+		// The spec can't be rendered, e.g. an unknown distribution name
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	var out bytes.Buffer
+	for _, obj := range objects {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		out.WriteString("---\n")
+		out.Write(data)
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(out.Bytes())
+}
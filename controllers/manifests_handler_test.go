@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRenderDesiredObjects(t *testing.T) {
+	instance := createLSD("ollama", "")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{Inline: "version: 2\n"}
+	instance.Spec.Server.Route = &llamav1alpha1.RouteSpec{}
+	instance.Spec.Server.Ingress = &llamav1alpha1.IngressSpec{Host: "llsd.example.com"}
+	instance.Spec.Server.Metrics = &llamav1alpha1.MetricsSpec{Enabled: true}
+	instance.Spec.Server.PodDisruptionBudget = &llamav1alpha1.PodDisruptionBudgetSpec{MinAvailable: ptr.To(intstr.FromInt32(1))}
+	instance.Spec.Server.Autoscaling = &llamav1alpha1.AutoscalingSpec{MaxReplicas: 3}
+	instance.Spec.Server.PreStartJob = &llamav1alpha1.PreStartJobSpec{Command: []string{"llama", "stack", "migrate"}}
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		ServiceAccountName:   "llsd-server",
+		CreateServiceAccount: true,
+		GrantConfigAccess:    true,
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	clusterInfo := setupTestClusterInfo(nil)
+	clusterInfo.RoutesSupported = true
+	clusterInfo.ServiceMonitorsSupported = true
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &LlamaStackDistributionReconciler{Client: c, Scheme: scheme, ClusterInfo: clusterInfo}
+
+	objects, err := r.RenderDesiredObjects(context.Background(), instance)
+	require.NoError(t, err)
+	rendered := map[string][]string{}
+	for _, obj := range objects {
+		rendered[obj.GetKind()] = append(rendered[obj.GetKind()], obj.GetName())
+		assert.Empty(t, obj.GetOwnerReferences(), "rendered objects should not be applied")
+	}
+	assert.Contains(t, rendered["ConfigMap"], "llsd-user-config")
+	assert.Equal(t, []string{"llsd"}, rendered["Route"])
+	assert.Equal(t, []string{"llsd"}, rendered["Ingress"])
+	assert.Equal(t, []string{"llsd"}, rendered["ServiceMonitor"])
+	assert.Equal(t, []string{"llsd"}, rendered["PodDisruptionBudget"])
+	assert.Equal(t, []string{"llsd"}, rendered["HorizontalPodAutoscaler"])
+	assert.Contains(t, rendered["ServiceAccount"], "llsd-server")
+	assert.Equal(t, []string{"llsd-server"}, rendered["Role"])
+	assert.Equal(t, []string{"llsd-server"}, rendered["RoleBinding"])
+	assert.Len(t, rendered["Job"], 1)
+	assert.Equal(t, []string{"llsd"}, rendered["Deployment"])
+
+	deployments := &appsv1.DeploymentList{}
+	require.NoError(t, c.List(context.Background(), deployments))
+	assert.Empty(t, deployments.Items, "rendering should not create anything")
+}
+
+func TestRenderDesiredObjectsHasNoSideEffects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{
+		Client:      fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:      scheme,
+		ClusterInfo: setupTestClusterInfo(nil),
+		Recorder:    recorder,
+	}
+
+	invalid := createLSD("unknown", "")
+	invalid.Name = "llsd"
+	invalid.Namespace = "default"
+	_, err := r.RenderDesiredObjects(context.Background(), invalid)
+	require.Error(t, err)
+	assert.True(t, isInvalidSpec(err))
+
+	privileged := createLSD("ollama", "")
+	privileged.Name = "llsd"
+	privileged.Namespace = "default"
+	privileged.Spec.Server.ContainerSpec.Port = 80
+	_, err = r.RenderDesiredObjects(context.Background(), privileged)
+	require.NoError(t, err)
+
+	assert.Empty(t, invalid.Status.Conditions, "rendering should not update the status")
+	assert.Empty(t, privileged.Status.Conditions, "rendering should not update the status")
+	assert.Empty(t, recorder.Events, "rendering should not record Events")
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// dnsPort is the port of the DNS servers, always reachable by server pods whose outgoing traffic is restricted.
+const dnsPort = 53
+
+// getAllowedIngressRule returns the ingress rule letting the peers of the spec reach the server ports, or nil
+// when the spec doesn't allow additional peers.
+func getAllowedIngressRule(instance *llamav1alpha1.LlamaStackDistribution,
+	ports []networkingv1.NetworkPolicyPort) *networkingv1.NetworkPolicyIngressRule {
+	networkPolicy := instance.Spec.Server.NetworkPolicy
+	if networkPolicy == nil || len(networkPolicy.AllowedIngress) == 0 {
+		return nil
+	}
+	return &networkingv1.NetworkPolicyIngressRule{
+		From:  networkPolicy.AllowedIngress,
+		Ports: ports,
+	}
+}
+
+// restrictsIngress returns true when the spec drops the rule allowing the llama-stack pods of every namespace,
+// leaving the operator namespace and the allowed peers of the spec.
+func restrictsIngress(instance *llamav1alpha1.LlamaStackDistribution) bool {
+	networkPolicy := instance.Spec.Server.NetworkPolicy
+	return networkPolicy != nil && networkPolicy.RestrictIngress
+}
+
+// getEgressRules returns the egress rules of the NetworkPolicy, starting with the one allowing DNS, or nil when
+// the spec doesn't restrict the outgoing traffic of the server pods.
+func getEgressRules(instance *llamav1alpha1.LlamaStackDistribution) []networkingv1.NetworkPolicyEgressRule {
+	networkPolicy := instance.Spec.Server.NetworkPolicy
+	if networkPolicy == nil || networkPolicy.Egress == nil {
+		return nil
+	}
+	port := intstr.FromInt32(dnsPort)
+	dns := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: ptr.To(corev1.ProtocolUDP), Port: &port},
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: &port},
+		},
+	}
+	return append([]networkingv1.NetworkPolicyEgressRule{dns}, networkPolicy.Egress.Rules...)
+}
+
+// validateNetworkPolicy checks the peers allowed to reach the server and the ones it may reach, so that an
+// invalid peer is reported on the instance rather than when the NetworkPolicy is applied.
+func validateNetworkPolicy(instance *llamav1alpha1.LlamaStackDistribution) error {
+	networkPolicy := instance.Spec.Server.NetworkPolicy
+	if networkPolicy == nil {
+		return nil
+	}
+	for i, peer := range networkPolicy.AllowedIngress {
+		if err := validateNetworkPolicyPeer(peer); err != nil {
+			return fmt.Errorf("failed to validate network policy: allowedIngress[%d]: %w", i, err)
+		}
+	}
+	if networkPolicy.Egress == nil {
+		return nil
+	}
+	for i, rule := range networkPolicy.Egress.Rules {
+		for j, peer := range rule.To {
+			if err := validateNetworkPolicyPeer(peer); err != nil {
+				return fmt.Errorf("failed to validate network policy: egress.rules[%d].to[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNetworkPolicyPeer checks that the peer selects pods and namespaces with valid selectors, or an IP
+// block with valid CIDRs.
+func validateNetworkPolicyPeer(peer networkingv1.NetworkPolicyPeer) error {
+	if peer.IPBlock != nil {
+		if peer.PodSelector != nil || peer.NamespaceSelector != nil {
+			return errors.New("ipBlock can't be combined with podSelector or namespaceSelector")
+		}
+		return validateIPBlock(peer.IPBlock)
+	}
+	if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+		return errors.New("one of podSelector, namespaceSelector or ipBlock must be set")
+	}
+	for _, selector := range []*metav1.LabelSelector{peer.PodSelector, peer.NamespaceSelector} {
+		if selector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateIPBlock checks that the CIDRs of the IP block parse, and that the excluded ones are within its range.
+func validateIPBlock(ipBlock *networkingv1.IPBlock) error {
+	_, cidr, err := net.ParseCIDR(ipBlock.CIDR)
+	if err != nil {
+		return fmt.Errorf("invalid ipBlock cidr: %w", err)
+	}
+	cidrOnes, _ := cidr.Mask.Size()
+	for _, except := range ipBlock.Except {
+		exceptIP, exceptNet, err := net.ParseCIDR(except)
+		if err != nil {
+			return fmt.Errorf("invalid ipBlock except: %w", err)
+		}
+		if exceptOnes, _ := exceptNet.Mask.Size(); !cidr.Contains(exceptIP) || exceptOnes <= cidrOnes {
+			return fmt.Errorf("ipBlock except %s is not within the cidr %s", except, ipBlock.CIDR)
+		}
+	}
+	return nil
+}
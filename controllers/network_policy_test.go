@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestBuildNetworkPolicyAllowedIngress(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "llama-stack-operator")
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	r := &LlamaStackDistributionReconciler{}
+
+	networkPolicy, err := r.buildNetworkPolicy(instance)
+	require.NoError(t, err)
+	assert.Len(t, networkPolicy.Spec.Ingress, 2, "only the default peers are allowed without allowedIngress")
+
+	tenant := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+	}
+	office := networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "192.0.2.0/24"}}
+	instance.Spec.Server.NetworkPolicy = &llamav1alpha1.NetworkPolicySpec{
+		AllowedIngress: []networkingv1.NetworkPolicyPeer{tenant, office},
+	}
+	networkPolicy, err = r.buildNetworkPolicy(instance)
+	require.NoError(t, err)
+	require.Len(t, networkPolicy.Spec.Ingress, 3, "the allowed peers are added to the default ones")
+	rule := networkPolicy.Spec.Ingress[2]
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{tenant, office}, rule.From)
+	assert.Equal(t, networkPolicy.Spec.Ingress[0].Ports, rule.Ports, "the allowed peers only reach the server ports")
+
+	// Restricting the ingress drops the llama-stack pods of every namespace, the operator namespace stays allowed
+	instance.Spec.Server.NetworkPolicy.RestrictIngress = true
+	networkPolicy, err = r.buildNetworkPolicy(instance)
+	require.NoError(t, err)
+	require.Len(t, networkPolicy.Spec.Ingress, 2)
+	assert.Equal(t, map[string]string{"kubernetes.io/metadata.name": "llama-stack-operator"},
+		networkPolicy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{tenant, office}, networkPolicy.Spec.Ingress[1].From)
+}
+
+func TestBuildNetworkPolicyEgress(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "llama-stack-operator")
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	r := &LlamaStackDistributionReconciler{}
+
+	networkPolicy, err := r.buildNetworkPolicy(instance)
+	require.NoError(t, err)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes,
+		"the outgoing traffic is only restricted on demand")
+	assert.Empty(t, networkPolicy.Spec.Egress)
+
+	// Restricting the outgoing traffic always allows DNS
+	instance.Spec.Server.NetworkPolicy = &llamav1alpha1.NetworkPolicySpec{Egress: &llamav1alpha1.NetworkPolicyEgressSpec{}}
+	networkPolicy, err = r.buildNetworkPolicy(instance)
+	require.NoError(t, err)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, networkPolicy.Spec.PolicyTypes)
+	require.Len(t, networkPolicy.Spec.Egress, 1)
+	dns := networkPolicy.Spec.Egress[0]
+	assert.Empty(t, dns.To)
+	require.Len(t, dns.Ports, 2)
+	for _, port := range dns.Ports {
+		assert.Equal(t, intstr.FromInt32(53), *port.Port)
+	}
+	assert.Equal(t, ptr.To(corev1.ProtocolUDP), dns.Ports[0].Protocol)
+	assert.Equal(t, ptr.To(corev1.ProtocolTCP), dns.Ports[1].Protocol)
+
+	vectorDB := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "milvus"}},
+		}},
+		Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(19530))}},
+	}
+	instance.Spec.Server.NetworkPolicy.Egress.Rules = []networkingv1.NetworkPolicyEgressRule{vectorDB}
+	networkPolicy, err = r.buildNetworkPolicy(instance)
+	require.NoError(t, err)
+	assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{dns, vectorDB}, networkPolicy.Spec.Egress)
+}
+
+func TestValidateNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		peer        networkingv1.NetworkPolicyPeer
+		expectedErr string
+	}{
+		{
+			name: "pods of selected namespaces",
+			peer: networkingv1.NetworkPolicyPeer{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "chat"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+			},
+		},
+		{
+			name: "ip block with exceptions",
+			peer: networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+		},
+		{
+			name:        "empty peer",
+			peer:        networkingv1.NetworkPolicyPeer{},
+			expectedErr: "one of podSelector, namespaceSelector or ipBlock must be set",
+		},
+		{
+			name: "invalid selector",
+			peer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Matches"}},
+			}},
+			expectedErr: "invalid selector",
+		},
+		{
+			name: "ip block with a selector",
+			peer: networkingv1.NetworkPolicyPeer{
+				IPBlock:     &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+				PodSelector: &metav1.LabelSelector{},
+			},
+			expectedErr: "ipBlock can't be combined",
+		},
+		{
+			name:        "invalid cidr",
+			peer:        networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0"}},
+			expectedErr: "invalid ipBlock cidr",
+		},
+		{
+			name:        "exception outside of the cidr",
+			peer:        networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.1.0.0/24"}}},
+			expectedErr: "is not within the cidr 10.0.0.0/16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := createLSD("", "test-image:latest")
+			instance.Spec.Server.NetworkPolicy = &llamav1alpha1.NetworkPolicySpec{
+				AllowedIngress: []networkingv1.NetworkPolicyPeer{tt.peer},
+			}
+			err := validateNetworkPolicy(instance)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, "allowedIngress[0]")
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+
+	// The destinations of the egress rules are checked the same way
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.NetworkPolicy = &llamav1alpha1.NetworkPolicySpec{Egress: &llamav1alpha1.NetworkPolicyEgressSpec{
+		Rules: []networkingv1.NetworkPolicyEgressRule{{To: []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: "203.0.113.0/24"}},
+			{IPBlock: &networkingv1.IPBlock{CIDR: "not-a-cidr"}},
+		}}},
+	}}
+	require.ErrorContains(t, validateNetworkPolicy(instance), "egress.rules[0].to[1]: invalid ipBlock cidr")
+}
+
+func TestValidateOperatorNamespaceLabelsForbidden(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "llama-operator")
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "llama-operator"}}
+	// The patch permission on namespaces is only granted by the namespace-labeler overlay
+	forbidPatch := interceptor.Funcs{Patch: func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+		return k8serrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "llama-operator", errors.New("patch is not allowed"))
+	}}
+	r := &LlamaStackDistributionReconciler{
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace).WithInterceptorFuncs(forbidPatch).Build(),
+		NetworkPolicyConfig: &deploy.NetworkPolicyConfig{OperatorNamespaceLabelKey: "team", OperatorNamespaceLabelValue: "llama", LabelOperatorNamespace: true},
+	}
+	instance := createLSD("", "test-image:latest")
+
+	require.NoError(t, r.validateOperatorNamespaceLabels(context.Background(), instance))
+	condition := GetCondition(&instance.Status, ConditionTypeNetworkPolicyValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, "lacks the label team=llama")
+	assert.Contains(t, condition.Message, "requires the namespace-labeler RBAC overlay")
+}
+
+func TestValidateOperatorNamespaceLabelsReadsUncached(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "llama-operator")
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	// The cached client would start a cluster-wide Namespace informer
+	failCachedGet := interceptor.Funcs{Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+		return errors.New("the operator namespace should be read through the API reader")
+	}}
+	apiReader := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &LlamaStackDistributionReconciler{
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(failCachedGet).Build(),
+		APIReader:           apiReader,
+		NetworkPolicyConfig: &deploy.NetworkPolicyConfig{OperatorNamespaceLabelKey: "team", OperatorNamespaceLabelValue: "llama"},
+	}
+	instance := createLSD("", "test-image:latest")
+
+	require.NoError(t, r.validateOperatorNamespaceLabels(context.Background(), instance))
+	condition := GetCondition(&instance.Status, ConditionTypeNetworkPolicyValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonOperatorNamespaceNotFound, condition.Reason)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "llama-operator", Labels: map[string]string{"team": "llama"}}}
+	require.NoError(t, apiReader.Create(context.Background(), namespace))
+	require.NoError(t, r.validateOperatorNamespaceLabels(context.Background(), instance))
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeNetworkPolicyValid))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// orphanSweepInterval is how often the operator looks for resources whose owning CR no longer exists.
+const orphanSweepInterval = time.Hour
+
+// newSweepableLists returns the kinds rendered from the operator manifests that are garbage-collected
+// by the orphan sweep. PersistentVolumeClaims are deliberately left out to never delete user data.
+func newSweepableLists() []client.ObjectList {
+	return []client.ObjectList{
+		&corev1.ServiceList{},
+		&corev1.ServiceAccountList{},
+		&rbacv1.ClusterRoleBindingList{},
+	}
+}
+
+// runOrphanSweeper sweeps orphaned resources on startup and then periodically until the context is done.
+func (r *LlamaStackDistributionReconciler) runOrphanSweeper(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphan-sweeper")
+	ctx = log.IntoContext(ctx, logger)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.SweepOrphanedResources(ctx); err != nil {
+			logger.Error(err, "failed to sweep orphaned resources")
+		}
+	}, orphanSweepInterval)
+	return nil
+}
+
+// SweepOrphanedResources deletes operator-managed resources whose owning LlamaStackDistribution
+// no longer exists, e.g. after the CR was renamed or the operator crashed during deletion.
+// Resources are matched through the instance labels set when rendering the manifests.
+func (r *LlamaStackDistributionReconciler) SweepOrphanedResources(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	for _, list := range newSweepableLists() {
+		if err := r.List(ctx, list,
+			client.MatchingLabels{deploy.ManagedByLabelKey: deploy.ManagedByLabelValue},
+			client.HasLabels{deploy.InstanceLabelKey}); err != nil {
+			return fmt.Errorf("failed to list managed resources: %w", err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("failed to extract managed resources: %w", err)
+		}
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			orphaned, err := r.isOrphaned(ctx, obj)
+			if err != nil {
+				return err
+			}
+			if !orphaned {
+				continue
+			}
+
+			logger.Info("Deleting orphaned resource", "kind", fmt.Sprintf("%T", obj),
+				"name", obj.GetName(), "namespace", obj.GetNamespace())
+			if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete orphaned resource %s: %w", obj.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isOrphaned reports whether the LlamaStackDistribution recorded in the resource labels is gone.
+// A resource that is still owned by a previous incarnation of a recreated CR is orphaned as well.
+func (r *LlamaStackDistributionReconciler) isOrphaned(ctx context.Context, obj client.Object) (bool, error) {
+	labels := obj.GetLabels()
+	key := types.NamespacedName{
+		Name:      labels[deploy.InstanceLabelKey],
+		Namespace: obj.GetNamespace(),
+	}
+	if key.Namespace == "" {
+		key.Namespace = labels[deploy.InstanceNamespaceLabelKey]
+	}
+	// Without a namespace the owner can't be looked up, leave the resource alone
+	if key.Namespace == "" {
+		return false, nil
+	}
+
+	owner := &llamav1alpha1.LlamaStackDistribution{}
+	if err := r.Get(ctx, key, owner); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get owning LlamaStackDistribution %s: %w", key, err)
+	}
+
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == llamav1alpha1.LlamaStackDistributionKind && ref.UID != owner.UID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
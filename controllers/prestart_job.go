@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// preStartJobComponent is the component label value of the pre-start Jobs.
+	preStartJobComponent = "pre-start-job"
+	// preStartJobContainerName is the name of the container of the pre-start Job pods.
+	preStartJobContainerName = "pre-start"
+	// defaultPreStartJobBackoffLimit is the number of retries of a failing pre-start Job.
+	defaultPreStartJobBackoffLimit = 3
+	// defaultPreStartJobActiveDeadlineSeconds bounds the run of a pre-start Job, e.g. one waiting for a database.
+	defaultPreStartJobActiveDeadlineSeconds = 1800
+	// preStartJobHashLength is the length of the pod template hash suffix of the pre-start Job names.
+	preStartJobHashLength = 10
+)
+
+// reconcilePreStartJob runs the pre-start Job of the desired pod template, and reports whether the Deployment
+// can be rolled out. A Job runs once per pod template, so that every rollout is preceded by a successful run.
+func (r *LlamaStackDistributionReconciler) reconcilePreStartJob(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	deployment *appsv1.Deployment) (bool, error) {
+	if instance.Spec.Server.PreStartJob == nil {
+		return true, nil
+	}
+
+	job, err := buildPreStartJob(instance, &deployment.Spec.Template)
+	if err != nil {
+		return false, err
+	}
+	if err := ctrl.SetControllerReference(instance, job, r.Scheme); err != nil {
+		return false, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	existing := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(job), existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to fetch pre-start Job: %w", err)
+		}
+		log.FromContext(ctx).Info("creating pre-start Job before rolling out the pod template", "job", job.Name)
+		err := r.createWithImageVolumes(ctx, job, job.Spec.Template.Annotations, "spec", "template")
+		if err != nil && !k8serrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create pre-start Job: %w", err)
+		}
+		SetPreStartJobCompleteCondition(instance, false, fmt.Sprintf("Waiting for pre-start Job %s to complete", job.Name))
+		return false, nil
+	}
+
+	switch {
+	case isJobConditionTrue(existing, batchv1.JobFailed):
+		message := fmt.Sprintf("Pre-start Job %s failed, the rollout is held until the spec changes or the Job is deleted", job.Name)
+		condition := GetCondition(&instance.Status, ConditionTypePreStartJobComplete)
+		if (condition == nil || condition.Reason != ReasonPreStartJobFailed) && r.Recorder != nil {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ReasonPreStartJobFailed, message)
+		}
+		SetPreStartJobFailedCondition(instance, message)
+		return false, nil
+	case !isJobConditionTrue(existing, batchv1.JobComplete):
+		SetPreStartJobCompleteCondition(instance, false, fmt.Sprintf("Waiting for pre-start Job %s to complete", job.Name))
+		return false, nil
+	}
+
+	SetPreStartJobCompleteCondition(instance, true, "")
+	return true, r.deleteStalePreStartJobs(ctx, instance, job.Name)
+}
+
+// buildPreStartJob returns the pre-start Job of the pod template. The Job pod runs the server container of the
+// template, with the command of the Job, and is named after the hash of its own template. The resources of the
+// server container and its persistent storage are only given to the Job when requested, so that the Job
+// doesn't wait for the GPUs or the ReadWriteOnce volumes held by the running server pods.
+func buildPreStartJob(instance *llamav1alpha1.LlamaStackDistribution, template *corev1.PodTemplateSpec) (*batchv1.Job, error) {
+	spec := instance.Spec.Server.PreStartJob
+	podSpec := template.Spec.DeepCopy()
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	var container corev1.Container
+	for _, c := range podSpec.Containers {
+		if c.Name == getContainerName(instance) {
+			container = c
+		}
+	}
+	container.Name = preStartJobContainerName
+	if spec.Image != "" {
+		container.Image = spec.Image
+	}
+	container.Command = spec.Command
+	container.Args = spec.Args
+	container.Env = mergeEnv(container.Env, spec.Env)
+	container.Ports = nil
+	container.StartupProbe = nil
+	container.ReadinessProbe = nil
+	container.LivenessProbe = nil
+	container.Lifecycle = nil
+	container.Resources = corev1.ResourceRequirements{}
+	if spec.Resources != nil {
+		container.Resources = *spec.Resources
+	}
+	podSpec.Containers = []corev1.Container{container}
+	if !spec.MountStorage {
+		removePersistentVolumes(podSpec)
+	}
+
+	// The pods must not carry the selector labels of the server pods, which would add them to the Service
+	podTemplate := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: deploy.MergeLabels(instance.Spec.Labels, deploy.GetPodLabels(instance), map[string]string{
+				deploy.InstanceLabelKey:  instance.Name,
+				deploy.ComponentLabelKey: preStartJobComponent,
+			}),
+			Annotations: template.Annotations,
+		},
+		Spec: *podSpec,
+	}
+	hash, err := getPodTemplateHash(&podTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-prestart-%s", instance.Name, hash[:preStartJobHashLength]),
+			Namespace: instance.Namespace,
+			Labels: deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+				deploy.InstanceLabelKey:  instance.Name,
+				deploy.ComponentLabelKey: preStartJobComponent,
+			}),
+			Annotations: deploy.GetResourceAnnotations(instance),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(ptr.Deref(spec.BackoffLimit, defaultPreStartJobBackoffLimit)),
+			ActiveDeadlineSeconds: ptr.To(ptr.Deref(spec.ActiveDeadlineSeconds, defaultPreStartJobActiveDeadlineSeconds)),
+			Template:              podTemplate,
+		},
+	}, nil
+}
+
+// removePersistentVolumes removes the PersistentVolumeClaim volumes of the pod, and their mounts.
+func removePersistentVolumes(podSpec *corev1.PodSpec) {
+	removed := map[string]bool{}
+	volumes := podSpec.Volumes[:0]
+	for _, volume := range podSpec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			removed[volume.Name] = true
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	podSpec.Volumes = volumes
+
+	removeMounts := func(containers []corev1.Container) {
+		for i := range containers {
+			mounts := containers[i].VolumeMounts[:0]
+			for _, mount := range containers[i].VolumeMounts {
+				if !removed[mount.Name] {
+					mounts = append(mounts, mount)
+				}
+			}
+			containers[i].VolumeMounts = mounts
+		}
+	}
+	removeMounts(podSpec.InitContainers)
+	removeMounts(podSpec.Containers)
+}
+
+// deleteStalePreStartJobs deletes the pre-start Jobs of the instance other than the current one.
+func (r *LlamaStackDistributionReconciler) deleteStalePreStartJobs(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	current string) error {
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(instance.Namespace), client.MatchingLabels{
+		deploy.InstanceLabelKey:  instance.Name,
+		deploy.ComponentLabelKey: preStartJobComponent,
+	}); err != nil {
+		return fmt.Errorf("failed to list pre-start Jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Name == current || !metav1.IsControlledBy(job, instance) {
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete stale pre-start Job %s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+// isJobConditionTrue returns true when the Job has the condition set to true.
+func isJobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildPreStartJob(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.PreStartJob = &llamav1alpha1.PreStartJobSpec{
+		Command: []string{"llama", "stack", "migrate"},
+		Env:     []corev1.EnvVar{{Name: "MIGRATION_TIMEOUT", Value: "300"}},
+	}
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: getPodSelectorLabels(instance)},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:           getContainerName(instance),
+				Image:          "llama-stack:new",
+				Env:            []corev1.EnvVar{{Name: "POSTGRES_HOST", Value: "db"}},
+				Ports:          []corev1.ContainerPort{{ContainerPort: 8321}},
+				ReadinessProbe: &corev1.Probe{},
+				VolumeMounts:   []corev1.VolumeMount{{Name: "lls-storage", MountPath: "/.llama"}},
+			}},
+			Volumes: []corev1.Volume{{Name: "lls-storage"}},
+		},
+	}
+
+	job, err := buildPreStartJob(instance, template)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(job.Name, "llsd-prestart-"))
+	assert.Equal(t, int32(defaultPreStartJobBackoffLimit), ptr.Deref(job.Spec.BackoffLimit, 0))
+	assert.Equal(t, preStartJobComponent, job.Labels[deploy.ComponentLabelKey])
+	assert.NotContains(t, job.Spec.Template.Labels, llamav1alpha1.DefaultLabelKey, "job pods must not be selected by the Service")
+
+	podSpec := job.Spec.Template.Spec
+	assert.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+	assert.Equal(t, template.Spec.Volumes, podSpec.Volumes)
+	require.Len(t, podSpec.Containers, 1)
+	container := podSpec.Containers[0]
+	assert.Equal(t, "llama-stack:new", container.Image)
+	assert.Equal(t, []string{"llama", "stack", "migrate"}, container.Command)
+	assert.Equal(t, []corev1.EnvVar{{Name: "POSTGRES_HOST", Value: "db"}, {Name: "MIGRATION_TIMEOUT", Value: "300"}}, container.Env)
+	assert.Empty(t, container.Ports)
+	assert.Nil(t, container.ReadinessProbe)
+	assert.Len(t, template.Spec.Containers[0].Env, 1, "the server pod template must not be modified")
+
+	// The same template runs the same Job, a new one runs a new Job
+	same, err := buildPreStartJob(instance, template)
+	require.NoError(t, err)
+	assert.Equal(t, job.Name, same.Name)
+	template.Spec.Containers[0].Image = "llama-stack:newer"
+	updated, err := buildPreStartJob(instance, template)
+	require.NoError(t, err)
+	assert.NotEqual(t, job.Name, updated.Name)
+}
+
+func TestBuildPreStartJobResourcesAndStorage(t *testing.T) {
+	instance := createLSD("", "llama-stack:new")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.PreStartJob = &llamav1alpha1.PreStartJobSpec{Command: []string{"llama", "stack", "migrate"}}
+	gpu := corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}
+	template := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name:         "init",
+				VolumeMounts: []corev1.VolumeMount{{Name: "lls-storage", MountPath: "/.llama"}},
+			}},
+			Containers: []corev1.Container{{
+				Name:      getContainerName(instance),
+				Image:     "llama-stack:new",
+				Resources: corev1.ResourceRequirements{Limits: gpu, Requests: gpu},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "lls-storage", MountPath: "/.llama"},
+					{Name: "user-config", MountPath: "/etc/llama-stack"},
+				},
+			}},
+			Volumes: []corev1.Volume{
+				{Name: "lls-storage", VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "llsd-pvc"},
+				}},
+				{Name: "user-config", VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "llsd-config"}},
+				}},
+			},
+		},
+	}
+
+	// Neither the GPUs nor the persistent storage of the server are held by the Job by default
+	job, err := buildPreStartJob(instance, template)
+	require.NoError(t, err)
+	assert.Equal(t, int64(defaultPreStartJobActiveDeadlineSeconds), ptr.Deref(job.Spec.ActiveDeadlineSeconds, 0))
+	podSpec := job.Spec.Template.Spec
+	assert.Equal(t, []corev1.Volume{template.Spec.Volumes[1]}, podSpec.Volumes)
+	assert.Empty(t, podSpec.InitContainers[0].VolumeMounts)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "user-config", MountPath: "/etc/llama-stack"}}, podSpec.Containers[0].VolumeMounts)
+	assert.Empty(t, podSpec.Containers[0].Resources)
+	assert.Len(t, template.Spec.Volumes, 2, "the server pod template must not be modified")
+
+	instance.Spec.Server.PreStartJob.MountStorage = true
+	instance.Spec.Server.PreStartJob.ActiveDeadlineSeconds = ptr.To(int64(600))
+	instance.Spec.Server.PreStartJob.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}
+	job, err = buildPreStartJob(instance, template)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), ptr.Deref(job.Spec.ActiveDeadlineSeconds, 0))
+	podSpec = job.Spec.Template.Spec
+	assert.Equal(t, template.Spec.Volumes, podSpec.Volumes)
+	assert.Equal(t, template.Spec.Containers[0].VolumeMounts, podSpec.Containers[0].VolumeMounts)
+	assert.Equal(t, *instance.Spec.Server.PreStartJob.Resources, podSpec.Containers[0].Resources)
+}
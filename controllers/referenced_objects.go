@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// referencedObjectsHashAnnotation is the pod template annotation holding the checksum of the ConfigMaps
+	// and Secrets referenced by the env and volumes of the server pods, rolling the pods when they change.
+	referencedObjectsHashAnnotation = "llamastack.io/referenced-objects-hash"
+	// referencedConfigMapsIndexField indexes the instances by the ConfigMaps referenced by their pods.
+	referencedConfigMapsIndexField = "spec.server.referencedConfigMaps"
+	// referencedSecretsIndexField indexes the instances by the Secrets referenced by their pods.
+	referencedSecretsIndexField = "spec.server.referencedSecrets"
+)
+
+// getReferencedObjectNames returns the sorted names of the ConfigMaps and Secrets referenced by the env, envFrom
+// and volumes of the server and pre-start Job containers. They are in the namespace of the instance. The user
+// config and CA bundle ConfigMaps are tracked by their own annotations.
+func getReferencedObjectNames(instance *llamav1alpha1.LlamaStackDistribution) ([]string, []string) {
+	var configMaps, secrets []string
+
+	env := instance.Spec.Server.ContainerSpec.Env
+	if instance.Spec.Server.PreStartJob != nil {
+		env = append(slices.Clone(env), instance.Spec.Server.PreStartJob.Env...)
+	}
+	for _, envVar := range env {
+		if envVar.ValueFrom == nil {
+			continue
+		}
+		if ref := envVar.ValueFrom.ConfigMapKeyRef; ref != nil {
+			configMaps = append(configMaps, ref.Name)
+		}
+		if ref := envVar.ValueFrom.SecretKeyRef; ref != nil {
+			secrets = append(secrets, ref.Name)
+		}
+	}
+	for _, envFrom := range instance.Spec.Server.ContainerSpec.EnvFrom {
+		if envFrom.ConfigMapRef != nil {
+			configMaps = append(configMaps, envFrom.ConfigMapRef.Name)
+		}
+		if envFrom.SecretRef != nil {
+			secrets = append(secrets, envFrom.SecretRef.Name)
+		}
+	}
+	if instance.Spec.Server.PodOverrides != nil {
+		for _, volume := range instance.Spec.Server.PodOverrides.Volumes {
+			volumeConfigMaps, volumeSecrets := getVolumeObjectNames(volume.VolumeSource)
+			configMaps = append(configMaps, volumeConfigMaps...)
+			secrets = append(secrets, volumeSecrets...)
+		}
+	}
+
+	slices.Sort(configMaps)
+	slices.Sort(secrets)
+	return slices.Compact(configMaps), slices.Compact(secrets)
+}
+
+// getVolumeObjectNames returns the names of the ConfigMaps and Secrets of a volume, including projected ones.
+func getVolumeObjectNames(source corev1.VolumeSource) ([]string, []string) {
+	var configMaps, secrets []string
+	if source.ConfigMap != nil {
+		configMaps = append(configMaps, source.ConfigMap.Name)
+	}
+	if source.Secret != nil {
+		secrets = append(secrets, source.Secret.SecretName)
+	}
+	if source.Projected != nil {
+		for _, projection := range source.Projected.Sources {
+			if projection.ConfigMap != nil {
+				configMaps = append(configMaps, projection.ConfigMap.Name)
+			}
+			if projection.Secret != nil {
+				secrets = append(secrets, projection.Secret.Name)
+			}
+		}
+	}
+	return configMaps, secrets
+}
+
+// getReferencedObjectsHash returns the checksum of the data of the ConfigMaps and Secrets referenced by the
+// server pods, or an empty string when the pods reference none. Missing objects are part of the checksum,
+// so that the pods roll once they are created.
+func (r *LlamaStackDistributionReconciler) getReferencedObjectsHash(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) (string, error) {
+	configMaps, secrets := getReferencedObjectNames(instance)
+	if len(configMaps) == 0 && len(secrets) == 0 {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	for _, name := range configMaps {
+		configMap := &corev1.ConfigMap{}
+		data, err := r.getReferencedObjectData(ctx, instance, name, configMap, func() any {
+			return []any{configMap.Data, configMap.BinaryData}
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get referenced ConfigMap %s: %w", name, err)
+		}
+		fmt.Fprintf(hash, "ConfigMap/%s=%s\n", name, data)
+	}
+	for _, name := range secrets {
+		secret := &corev1.Secret{}
+		data, err := r.getReferencedObjectData(ctx, instance, name, secret, func() any {
+			return secret.Data
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get referenced Secret %s: %w", name, err)
+		}
+		fmt.Fprintf(hash, "Secret/%s=%s\n", name, data)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// getReferencedObjectData fetches a referenced object of the instance namespace and returns its data as JSON,
+// or nil when the object does not exist.
+func (r *LlamaStackDistributionReconciler) getReferencedObjectData(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	name string, obj client.Object, data func() any) ([]byte, error) {
+	key := types.NamespacedName{Name: name, Namespace: instance.Namespace}
+	var err error
+	if secret, ok := obj.(*corev1.Secret); ok {
+		err = r.getSecret(ctx, key, secret)
+	} else {
+		err = r.Get(ctx, key, obj)
+	}
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return json.Marshal(data())
+}
+
+// getSecret reads a Secret through the API reader. Secrets are only watched by their metadata, and reading one
+// through the cached client would start an informer caching the data of every Secret of the cluster.
+func (r *LlamaStackDistributionReconciler) getSecret(ctx context.Context, key types.NamespacedName, secret *corev1.Secret) error {
+	return r.getAPIReader().Get(ctx, key, secret)
+}
+
+// getAPIReader returns the reader of the objects the manager doesn't cache, falling back to the client.
+func (r *LlamaStackDistributionReconciler) getAPIReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// createReferencedObjectsFieldIndexers indexes the instances by the ConfigMaps and Secrets referenced by their
+// pods. Like the ConfigMap field indexer, a failure falls back to listing the instances of the namespace.
+func (r *LlamaStackDistributionReconciler) createReferencedObjectsFieldIndexers(ctx context.Context, mgr ctrl.Manager) {
+	indexers := map[string]func(*llamav1alpha1.LlamaStackDistribution) []string{
+		referencedConfigMapsIndexField: func(llsd *llamav1alpha1.LlamaStackDistribution) []string {
+			configMaps, _ := getReferencedObjectNames(llsd)
+			return configMaps
+		},
+		referencedSecretsIndexField: func(llsd *llamav1alpha1.LlamaStackDistribution) []string {
+			_, secrets := getReferencedObjectNames(llsd)
+			return secrets
+		},
+	}
+	for field, names := range indexers {
+		if err := mgr.GetFieldIndexer().IndexField(ctx, &llamav1alpha1.LlamaStackDistribution{}, field, func(rawObj client.Object) []string {
+			llsd, ok := rawObj.(*llamav1alpha1.LlamaStackDistribution)
+			if !ok {
+				return nil
+			}
+			keys := make([]string, 0)
+			for _, name := range names(llsd) {
+				keys = append(keys, fmt.Sprintf("%s/%s", llsd.Namespace, name))
+			}
+			return keys
+		}); err != nil {
+			mgr.GetLogger().V(1).Info("Field indexer for referenced objects not supported, will use manual search fallback",
+				"field", field, "error", err.Error())
+		}
+	}
+}
+
+// findLlamaStackDistributionsReferencing returns the instances whose pods reference the ConfigMap or Secret.
+func (r *LlamaStackDistributionReconciler) findLlamaStackDistributionsReferencing(ctx context.Context, obj client.Object,
+	field string) []llamav1alpha1.LlamaStackDistribution {
+	logger := log.FromContext(ctx).WithValues("name", obj.GetName(), "namespace", obj.GetNamespace())
+
+	llamaStacks := llamav1alpha1.LlamaStackDistributionList{}
+	indexKey := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+	if err := r.List(ctx, &llamaStacks, client.MatchingFields{field: indexKey}); err == nil {
+		return llamaStacks.Items
+	}
+
+	// Fall back to filtering the instances of the namespace
+	if err := r.List(ctx, &llamaStacks, client.InNamespace(obj.GetNamespace())); err != nil {
+		logger.Error(err, "failed to list LlamaStackDistributions for referenced object search")
+		return nil
+	}
+	return slices.DeleteFunc(llamaStacks.Items, func(ls llamav1alpha1.LlamaStackDistribution) bool {
+		configMaps, secrets := getReferencedObjectNames(&ls)
+		if field == referencedSecretsIndexField {
+			return !slices.Contains(secrets, obj.GetName())
+		}
+		return !slices.Contains(configMaps, obj.GetName())
+	})
+}
+
+// findLlamaStackDistributionsForSecret maps Secret changes to LlamaStackDistribution reconcile requests.
+func (r *LlamaStackDistributionReconciler) findLlamaStackDistributionsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	return r.convertToReconcileRequests(llamav1alpha1.LlamaStackDistributionList{
+		Items: r.findLlamaStackDistributionsReferencing(ctx, secret, referencedSecretsIndexField),
+	})
+}
+
+// secretPredicates only lets through the events of the Secrets referenced by an instance. The Secrets are
+// watched by their metadata, so every update changing the resource version is let through, and the reconcile
+// only rolls the pods when the checksum of their data changed.
+func (r *LlamaStackDistributionReconciler) secretPredicates() predicate.Funcs {
+	isReferenced := func(obj client.Object) bool {
+		return len(r.findLlamaStackDistributionsReferencing(context.Background(), obj, referencedSecretsIndexField)) > 0
+	}
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil || e.ObjectOld.GetResourceVersion() == e.ObjectNew.GetResourceVersion() {
+				return false
+			}
+			return isReferenced(e.ObjectNew)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isReferenced(e.Object)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isReferenced(e.Object)
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestGetReferencedObjectNames(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	configMaps, secrets := getReferencedObjectNames(instance)
+	assert.Empty(t, configMaps)
+	assert.Empty(t, secrets)
+
+	instance.Spec.Server.ContainerSpec.Env = []corev1.EnvVar{
+		{Name: "INFERENCE_MODEL", Value: "llama3.2:1b"},
+		{Name: "VLLM_API_TOKEN", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "vllm"}, Key: "token"},
+		}},
+		{Name: "VLLM_URL", ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "endpoints"}, Key: "vllm"},
+		}},
+	}
+	instance.Spec.Server.ContainerSpec.EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "vllm"}}},
+		{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}},
+	}
+	instance.Spec.Server.PreStartJob = &llamav1alpha1.PreStartJobSpec{
+		Env: []corev1.EnvVar{{Name: "POSTGRES_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "postgres"}, Key: "password"},
+		}}},
+	}
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		Volumes: []corev1.Volume{
+			{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "certs"}}},
+			{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "endpoints"}}},
+					{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "api-keys"}}},
+				},
+			}}},
+		},
+	}
+
+	configMaps, secrets = getReferencedObjectNames(instance)
+	assert.Equal(t, []string{"endpoints", "settings"}, configMaps)
+	assert.Equal(t, []string{"api-keys", "certs", "postgres", "vllm"}, secrets)
+	assert.Len(t, instance.Spec.Server.ContainerSpec.Env, 3, "the env of the instance must not be modified")
+}
+
+func TestReferencedSecretsReadThroughAPIReader(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Namespace = "default"
+	instance.Spec.Server.ContainerSpec.EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "vllm"}}},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("initial")},
+	}
+	apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	// The cached client doesn't hold the Secret, only the API reader does
+	r := &LlamaStackDistributionReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build(),
+		APIReader: apiReader,
+	}
+	ctx := context.Background()
+
+	initialHash, err := r.getReferencedObjectsHash(ctx, instance)
+	require.NoError(t, err)
+	secret.Data["token"] = []byte("rotated")
+	require.NoError(t, apiReader.Update(ctx, secret))
+	rotatedHash, err := r.getReferencedObjectsHash(ctx, instance)
+	require.NoError(t, err)
+	assert.NotEqual(t, initialHash, rotatedHash, "the data of the Secret is read through the API reader")
+
+	// The Secrets are watched by their metadata only
+	metadata := func(name, resourceVersion string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: resourceVersion}}
+	}
+	predicates := r.secretPredicates()
+	assert.True(t, predicates.Update(event.UpdateEvent{ObjectOld: metadata("vllm", "1"), ObjectNew: metadata("vllm", "2")}))
+	assert.False(t, predicates.Update(event.UpdateEvent{ObjectOld: metadata("vllm", "2"), ObjectNew: metadata("vllm", "2")}))
+	assert.False(t, predicates.Update(event.UpdateEvent{ObjectOld: metadata("other", "1"), ObjectNew: metadata("other", "2")}))
+	assert.True(t, predicates.Create(event.CreateEvent{Object: metadata("vllm", "1")}))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// initializingRequeueConfigKey is the key used in the operator ConfigMap to store the requeue backoff of the
+	// distributions in the Initializing phase.
+	initializingRequeueConfigKey = "initializingRequeue"
+	// defaultInitializingRequeueInterval is the first requeue interval of a distribution entering the Initializing phase.
+	defaultInitializingRequeueInterval = 10 * time.Second
+	// defaultInitializingRequeueMaxInterval caps the requeue interval of the distributions in the Initializing phase.
+	defaultInitializingRequeueMaxInterval = 5 * time.Minute
+)
+
+// InitializingRequeueConfig is the backoff of the reconciles of the distributions in the Initializing phase.
+// The interval starts at InitialInterval and doubles on every requeue, up to MaxInterval.
+type InitializingRequeueConfig struct {
+	// InitialInterval is the first requeue interval, 10s when unset.
+	InitialInterval time.Duration `yaml:"initialInterval,omitempty"`
+	// MaxInterval caps the requeue interval, 5m when unset.
+	MaxInterval time.Duration `yaml:"maxInterval,omitempty"`
+}
+
+// Validate checks that the intervals are not negative and that the cap is not below the initial interval.
+func (c *InitializingRequeueConfig) Validate() error {
+	if c.InitialInterval < 0 || c.MaxInterval < 0 {
+		return errors.New("failed to validate initializing requeue: intervals must not be negative")
+	}
+	if c.getMaxInterval() < c.getInitialInterval() {
+		return fmt.Errorf("failed to validate initializing requeue: maxInterval %s is below initialInterval %s",
+			c.getMaxInterval(), c.getInitialInterval())
+	}
+	return nil
+}
+
+// getInitialInterval returns the first requeue interval, or the default one. The config may be nil.
+func (c *InitializingRequeueConfig) getInitialInterval() time.Duration {
+	if c == nil || c.InitialInterval == 0 {
+		return defaultInitializingRequeueInterval
+	}
+	return c.InitialInterval
+}
+
+// getMaxInterval returns the cap of the requeue interval, or the default one. The config may be nil.
+func (c *InitializingRequeueConfig) getMaxInterval() time.Duration {
+	if c == nil || c.MaxInterval == 0 {
+		return defaultInitializingRequeueMaxInterval
+	}
+	return c.MaxInterval
+}
+
+// parseInitializingRequeue extracts and validates the Initializing requeue backoff from ConfigMap data.
+// A nil config uses the defaults of the operator.
+func parseInitializingRequeue(configMapData map[string]string) (*InitializingRequeueConfig, error) {
+	requeueYAML, exists := configMapData[initializingRequeueConfigKey]
+	if !exists || strings.TrimSpace(requeueYAML) == "" {
+		return nil, nil
+	}
+
+	requeueConfig := &InitializingRequeueConfig{}
+	if err := yaml.Unmarshal([]byte(requeueYAML), requeueConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse initializing requeue: %w", err)
+	}
+	if err := requeueConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	return requeueConfig, nil
+}
+
+// requeueBackoff holds the next requeue interval of each instance in the Initializing phase.
+type requeueBackoff struct {
+	mu        sync.Mutex
+	intervals map[types.NamespacedName]time.Duration
+}
+
+// next returns the requeue interval of the instance, starting at initial, and doubles the following one up to
+// maxInterval.
+func (b *requeueBackoff) next(name types.NamespacedName, initial, maxInterval time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	interval, ok := b.intervals[name]
+	if !ok {
+		interval = initial
+	}
+	interval = min(interval, maxInterval)
+	if b.intervals == nil {
+		b.intervals = map[types.NamespacedName]time.Duration{}
+	}
+	b.intervals[name] = min(2*interval, maxInterval)
+	return interval
+}
+
+// reset drops the backoff of the instance, if any, so that its next Initializing phase starts over.
+func (b *requeueBackoff) reset(name types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.intervals, name)
+}
+
+// nextInitializingRequeue returns how soon the instance in the Initializing phase is reconciled again.
+func (r *LlamaStackDistributionReconciler) nextInitializingRequeue(name types.NamespacedName) time.Duration {
+	return r.initializingBackoff.next(name, r.InitializingRequeue.getInitialInterval(), r.InitializingRequeue.getMaxInterval())
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseInitializingRequeue(t *testing.T) {
+	requeueConfig, err := parseInitializingRequeue(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, requeueConfig)
+
+	requeueConfig, err = parseInitializingRequeue(map[string]string{initializingRequeueConfigKey: "initialInterval: 15s\nmaxInterval: 2m\n"})
+	require.NoError(t, err)
+	assert.Equal(t, &InitializingRequeueConfig{InitialInterval: 15 * time.Second, MaxInterval: 2 * time.Minute}, requeueConfig)
+
+	_, err = parseInitializingRequeue(map[string]string{initializingRequeueConfigKey: "maxInterval: 5s\n"})
+	require.ErrorContains(t, err, "below initialInterval")
+
+	_, err = parseInitializingRequeue(map[string]string{initializingRequeueConfigKey: "initialInterval: soon\n"})
+	require.Error(t, err)
+}
+
+func TestNextInitializingRequeue(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{}
+	name := types.NamespacedName{Name: "llsd", Namespace: "default"}
+	other := types.NamespacedName{Name: "other", Namespace: "default"}
+
+	// The interval doubles from the default up to the default cap
+	var intervals []time.Duration
+	for range 7 {
+		intervals = append(intervals, r.nextInitializingRequeue(name))
+	}
+	assert.Equal(t, []time.Duration{
+		10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second, 160 * time.Second, 5 * time.Minute, 5 * time.Minute,
+	}, intervals)
+	assert.Equal(t, 10*time.Second, r.nextInitializingRequeue(other), "each instance has its own backoff")
+
+	// Leaving the Initializing phase starts the backoff over
+	r.initializingBackoff.reset(name)
+	assert.Equal(t, 10*time.Second, r.nextInitializingRequeue(name))
+
+	r.InitializingRequeue = &InitializingRequeueConfig{InitialInterval: 30 * time.Second, MaxInterval: time.Minute}
+	r.initializingBackoff.reset(name)
+	assert.Equal(t, 30*time.Second, r.nextInitializingRequeue(name))
+	assert.Equal(t, time.Minute, r.nextInitializingRequeue(name))
+	assert.Equal(t, time.Minute, r.nextInitializingRequeue(name))
+}
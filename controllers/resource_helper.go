@@ -18,13 +18,27 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"path"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -37,6 +51,33 @@ const (
 	maxConfigMapKeyLength = 253
 )
 
+// featureGatesEnvVar passes the feature gates of the distribution to the server.
+const featureGatesEnvVar = "LLAMA_STACK_FEATURE_GATES"
+
+// milliCPUPerCPU is the number of millicores in a CPU.
+const milliCPUPerCPU = 1000
+
+// imageVolumesAnnotation records on the pod template its image volumes, as JSON, see addImageVolumes.
+const imageVolumesAnnotation = "llamastack.io/image-volumes"
+
+// Port ranges.
+const (
+	maxPort = 65535
+	// minUnprivilegedPort is the first port that can be bound without the NET_BIND_SERVICE capability.
+	minUnprivilegedPort = 1024
+)
+
+// Projected ServiceAccount token configuration.
+const (
+	serviceAccountTokenVolumeName = "sa-token"
+	serviceAccountTokenFileName   = "token"
+	// defaultServiceAccountTokenExpirationSeconds is the token validity used when none is requested.
+	defaultServiceAccountTokenExpirationSeconds int64 = 3600
+)
+
+// safeToEvictAnnotation tells the cluster autoscaler whether a pod may be evicted to scale a node down.
+const safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
 // Readiness probe configuration.
 const (
 	readinessProbeInitialDelaySeconds = 15 // Time to wait before the first probe
@@ -46,6 +87,16 @@ const (
 	readinessProbeSuccessThreshold    = 1  // Pod is marked Ready after 1 successful probe
 )
 
+// Liveness and startup probe configuration.
+const (
+	livenessProbePeriodSeconds    = 10 // How often to probe
+	livenessProbeTimeoutSeconds   = 5  // When the probe times out
+	livenessProbeFailureThreshold = 3  // Container is restarted after 3 consecutive failures
+	startupProbePeriodSeconds     = 10 // How often to probe
+	startupProbeTimeoutSeconds    = 5  // When the probe times out
+	startupProbeFailureThreshold  = 60 // Container is restarted when it hasn't started after 10 minutes
+)
+
 // validConfigMapKeyRegex defines allowed characters for ConfigMap keys.
 // Kubernetes ConfigMap keys must be valid DNS subdomain names or data keys.
 var validConfigMapKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9\-_.]*[a-zA-Z0-9])?$`)
@@ -78,21 +129,13 @@ func buildContainerSpec(ctx context.Context, r *LlamaStackDistributionReconciler
 		Name:            getContainerName(instance),
 		Image:           image,
 		Resources:       instance.Spec.Server.ContainerSpec.Resources,
-		ImagePullPolicy: corev1.PullAlways,
-		Ports:           []corev1.ContainerPort{{ContainerPort: getContainerPort(instance)}},
-		ReadinessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path: "/v1/health",
-					Port: intstr.FromInt(int(getContainerPort(instance))),
-				},
-			},
-			InitialDelaySeconds: readinessProbeInitialDelaySeconds,
-			PeriodSeconds:       readinessProbePeriodSeconds,
-			TimeoutSeconds:      readinessProbeTimeoutSeconds,
-			FailureThreshold:    readinessProbeFailureThreshold,
-			SuccessThreshold:    readinessProbeSuccessThreshold,
-		},
+		ImagePullPolicy: getImagePullPolicy(instance, image),
+		Ports:           getContainerPorts(instance),
+		LivenessProbe:   getLivenessProbe(instance),
+		ReadinessProbe:  getReadinessProbe(instance),
+		StartupProbe:    r.getStartupProbe(instance),
+		SecurityContext: getContainerSecurityContext(instance),
+		Lifecycle:       getLifecycle(instance),
 	}
 
 	// Configure environment variables and mounts
@@ -103,6 +146,93 @@ func buildContainerSpec(ctx context.Context, r *LlamaStackDistributionReconciler
 	return container
 }
 
+// getLifecycle returns the lifecycle hooks of the server container, only running the preStop hook when set.
+func getLifecycle(instance *llamav1alpha1.LlamaStackDistribution) *corev1.Lifecycle {
+	if instance.Spec.Server.PreStop == nil {
+		return nil
+	}
+	return &corev1.Lifecycle{PreStop: instance.Spec.Server.PreStop.DeepCopy()}
+}
+
+// getImagePullPolicy returns the pull policy of the server image, following the Kubernetes defaults when
+// none is set: images tagged latest or without a tag are always pulled.
+func getImagePullPolicy(instance *llamav1alpha1.LlamaStackDistribution, image string) corev1.PullPolicy {
+	if policy := instance.Spec.Server.ContainerSpec.ImagePullPolicy; policy != "" {
+		return policy
+	}
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if _, tag, found := strings.Cut(name, ":"); !found || tag == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
+// getHealthProbeHandler returns the handler of the default probes, checking the first health endpoint of the
+// health check on its port, with the scheme the server is served with. The kubelet doesn't verify the server
+// certificate.
+func getHealthProbeHandler(instance *llamav1alpha1.LlamaStackDistribution) corev1.ProbeHandler {
+	port := getContainerPort(instance)
+	if healthCheck := instance.Spec.Server.HealthCheck; healthCheck != nil && healthCheck.Port != nil {
+		port = *healthCheck.Port
+	}
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: getHealthCheckEndpoints(instance)[0],
+			Port: intstr.FromInt(int(port)),
+		},
+	}
+	// Left unset for HTTP, the default of the API server, so that the pods of existing instances don't roll
+	if getServerScheme(instance) == "https" {
+		handler.HTTPGet.Scheme = corev1.URISchemeHTTPS
+	}
+	return handler
+}
+
+// getLivenessProbe returns the liveness probe of the server container, using the custom probe if specified.
+func getLivenessProbe(instance *llamav1alpha1.LlamaStackDistribution) *corev1.Probe {
+	if probes := instance.Spec.Server.Probes; probes != nil && probes.LivenessProbe != nil {
+		return probes.LivenessProbe.DeepCopy()
+	}
+	return &corev1.Probe{
+		ProbeHandler:     getHealthProbeHandler(instance),
+		PeriodSeconds:    livenessProbePeriodSeconds,
+		TimeoutSeconds:   livenessProbeTimeoutSeconds,
+		FailureThreshold: livenessProbeFailureThreshold,
+	}
+}
+
+// getReadinessProbe returns the readiness probe of the server container, using the custom probe if specified.
+func getReadinessProbe(instance *llamav1alpha1.LlamaStackDistribution) *corev1.Probe {
+	if probes := instance.Spec.Server.Probes; probes != nil && probes.ReadinessProbe != nil {
+		return probes.ReadinessProbe.DeepCopy()
+	}
+	return &corev1.Probe{
+		ProbeHandler:        getHealthProbeHandler(instance),
+		InitialDelaySeconds: readinessProbeInitialDelaySeconds,
+		PeriodSeconds:       readinessProbePeriodSeconds,
+		TimeoutSeconds:      readinessProbeTimeoutSeconds,
+		FailureThreshold:    readinessProbeFailureThreshold,
+		SuccessThreshold:    readinessProbeSuccessThreshold,
+	}
+}
+
+// getStartupProbe returns the startup probe of the server container, using the custom probe if specified.
+// The default probe gives large distributions minutes to load before the liveness probe takes over.
+func (r *LlamaStackDistributionReconciler) getStartupProbe(instance *llamav1alpha1.LlamaStackDistribution) *corev1.Probe {
+	if probes := instance.Spec.Server.Probes; probes != nil && probes.StartupProbe != nil {
+		return probes.StartupProbe.DeepCopy()
+	}
+	return &corev1.Probe{
+		ProbeHandler:     getHealthProbeHandler(instance),
+		PeriodSeconds:    startupProbePeriodSeconds,
+		TimeoutSeconds:   startupProbeTimeoutSeconds,
+		FailureThreshold: r.getStartupProbeFailureThreshold(instance),
+	}
+}
+
 // getContainerName returns the container name, using custom name if specified.
 func getContainerName(instance *llamav1alpha1.LlamaStackDistribution) string {
 	if instance.Spec.Server.ContainerSpec.Name != "" {
@@ -119,6 +249,20 @@ func getContainerPort(instance *llamav1alpha1.LlamaStackDistribution) int32 {
 	return llamav1alpha1.DefaultServerPort
 }
 
+// getContainerPorts returns the server port, left to the TCP default as it serves the HTTP API, followed by the
+// additional named ports.
+func getContainerPorts(instance *llamav1alpha1.LlamaStackDistribution) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{{ContainerPort: getContainerPort(instance)}}
+	for _, port := range instance.Spec.Server.ContainerSpec.Ports {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.Port,
+			Protocol:      port.GetProtocol(),
+		})
+	}
+	return ports
+}
+
 // configureContainerEnvironment sets up environment variables for the container.
 func configureContainerEnvironment(ctx context.Context, r *LlamaStackDistributionReconciler, instance *llamav1alpha1.LlamaStackDistribution, container *corev1.Container) {
 	mountPath := getMountPath(instance)
@@ -150,8 +294,65 @@ func configureContainerEnvironment(ctx context.Context, r *LlamaStackDistributio
 		}
 	}
 
-	// Finally, add the user provided env vars
-	container.Env = append(container.Env, instance.Spec.Server.ContainerSpec.Env...)
+	if featureGates := getFeatureGates(instance); featureGates != "" {
+		log.FromContext(ctx).Info("setting server feature gates", "featureGates", featureGates)
+		container.Env = append(container.Env, corev1.EnvVar{Name: featureGatesEnvVar, Value: featureGates})
+	}
+
+	// Match thread pools to the CPU limit, unless the user sets the variable
+	container.Env = append(container.Env, getThreadCountEnv(instance)...)
+
+	// Finally, add the user provided env vars, which override the ones set by the operator
+	container.Env = mergeEnv(container.Env, instance.Spec.Server.ContainerSpec.Env)
+	container.EnvFrom = instance.Spec.Server.ContainerSpec.EnvFrom
+}
+
+// mergeEnv appends the overrides to the environment variables. An override of an existing variable replaces
+// it in place, so that the order stays stable and every variable is set once.
+func mergeEnv(env, overrides []corev1.EnvVar) []corev1.EnvVar {
+	for _, override := range overrides {
+		idx := slices.IndexFunc(env, func(e corev1.EnvVar) bool { return e.Name == override.Name })
+		if idx < 0 {
+			env = append(env, override)
+			continue
+		}
+		env[idx] = override
+	}
+	return env
+}
+
+// getFeatureGates returns the feature gates of the instance as sorted name=bool pairs, so that the
+// pod template only changes when the gates change.
+func getFeatureGates(instance *llamav1alpha1.LlamaStackDistribution) string {
+	gates := make([]string, 0, len(instance.Spec.Server.FeatureGates))
+	for name, enabled := range instance.Spec.Server.FeatureGates {
+		gates = append(gates, name+"="+strconv.FormatBool(enabled))
+	}
+	slices.Sort(gates)
+	return strings.Join(gates, ",")
+}
+
+// getThreadCountEnv returns the thread count variables of the instance, set to its CPU limit rounded up.
+func getThreadCountEnv(instance *llamav1alpha1.LlamaStackDistribution) []corev1.EnvVar {
+	containerSpec := instance.Spec.Server.ContainerSpec
+	limit, ok := containerSpec.Resources.Limits[corev1.ResourceCPU]
+	if !ok || limit.IsZero() {
+		return nil
+	}
+	// Round up, so that a limit of 500m still gets one thread
+	threads := strconv.FormatInt((limit.MilliValue()+milliCPUPerCPU-1)/milliCPUPerCPU, 10)
+
+	userEnv := make(map[string]bool, len(containerSpec.Env))
+	for _, env := range containerSpec.Env {
+		userEnv[env.Name] = true
+	}
+	var envVars []corev1.EnvVar
+	for _, name := range containerSpec.ThreadCountEnv {
+		if !userEnv[name] {
+			envVars = append(envVars, corev1.EnvVar{Name: name, Value: threads})
+		}
+	}
+	return envVars
 }
 
 // configureContainerMounts sets up volume mounts for the container.
@@ -169,7 +370,7 @@ func configureContainerMounts(ctx context.Context, r *LlamaStackDistributionReco
 // configureContainerCommands sets up container commands and args.
 func configureContainerCommands(instance *llamav1alpha1.LlamaStackDistribution, container *corev1.Container) {
 	// Override the container entrypoint to use the custom config file if user config is specified
-	if instance.Spec.Server.UserConfig != nil && instance.Spec.Server.UserConfig.ConfigMapName != "" {
+	if usesUserConfig(instance) {
 		container.Command = []string{"python", "-m", "llama_stack.distribution.server.server"}
 		container.Args = []string{"--config", "/etc/llama-stack/run.yaml"}
 	}
@@ -195,15 +396,19 @@ func getMountPath(instance *llamav1alpha1.LlamaStackDistribution) string {
 // addStorageVolumeMount adds the storage volume mount to the container.
 func addStorageVolumeMount(instance *llamav1alpha1.LlamaStackDistribution, container *corev1.Container) {
 	mountPath := getMountPath(instance)
-	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
-		Name:      "lls-storage",
+	mount := corev1.VolumeMount{
+		Name:      storageVolumeName,
 		MountPath: mountPath,
-	})
+	}
+	if instance.Spec.Server.Storage != nil {
+		mount.SubPath = instance.Spec.Server.Storage.SubPath
+	}
+	container.VolumeMounts = append(container.VolumeMounts, mount)
 }
 
 // addUserConfigVolumeMount adds the user config volume mount to the container if specified.
 func addUserConfigVolumeMount(instance *llamav1alpha1.LlamaStackDistribution, container *corev1.Container) {
-	if instance.Spec.Server.UserConfig != nil && instance.Spec.Server.UserConfig.ConfigMapName != "" {
+	if usesUserConfig(instance) {
 		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 			Name:      "user-config",
 			MountPath: "/etc/llama-stack/",
@@ -267,8 +472,9 @@ func createCABundleVolume(caBundleConfig *llamav1alpha1.CABundleConfig) corev1.V
 }
 
 // createCABundleInitContainer creates an InitContainer that concatenates multiple CA bundle keys
-// from a ConfigMap into a single file in the shared ca-bundle volume.
-func createCABundleInitContainer(caBundleConfig *llamav1alpha1.CABundleConfig) (corev1.Container, error) {
+// from a ConfigMap into a single file in the shared ca-bundle volume. It runs the server image, which already
+// runs as a non-root user, instead of pulling another image that would run as root.
+func createCABundleInitContainer(caBundleConfig *llamav1alpha1.CABundleConfig, image string) (corev1.Container, error) {
 	// Validate ConfigMap keys for security
 	if err := validateConfigMapKeys(caBundleConfig.ConfigMapKeys); err != nil {
 		return corev1.Container{}, fmt.Errorf("failed to validate ConfigMap keys: %w", err)
@@ -309,7 +515,7 @@ done`, CABundleTempPath, CABundleSourceDir, fileList)
 
 	return corev1.Container{
 		Name:    CABundleInitName,
-		Image:   "registry.access.redhat.com/ubi9/ubi-minimal:latest",
+		Image:   image,
 		Command: []string{"/bin/sh", "-c", script},
 		// No Args needed since we embed the file list in the script
 		VolumeMounts: []corev1.VolumeMount{
@@ -323,34 +529,44 @@ done`, CABundleTempPath, CABundleSourceDir, fileList)
 				MountPath: CABundleTempDir,
 			},
 		},
-		SecurityContext: &corev1.SecurityContext{
-			AllowPrivilegeEscalation: &[]bool{false}[0],
-			RunAsNonRoot:             &[]bool{false}[0],
-			Capabilities: &corev1.Capabilities{
-				Drop: []corev1.Capability{"ALL"},
-			},
-		},
+		SecurityContext: getCABundleInitSecurityContext(),
 	}, nil
 }
 
 // configurePodStorage configures the pod storage and returns the complete pod spec.
 func configurePodStorage(ctx context.Context, r *LlamaStackDistributionReconciler, instance *llamav1alpha1.LlamaStackDistribution, container corev1.Container) corev1.PodSpec {
 	podSpec := corev1.PodSpec{
-		Containers: []corev1.Container{container},
+		Containers:                    []corev1.Container{container},
+		TerminationGracePeriodSeconds: instance.Spec.Server.TerminationGracePeriodSeconds,
 	}
 
 	// Configure storage volumes and init containers
 	configureStorage(instance, &podSpec)
 
+	// Configure the shared memory volume
+	configureSharedMemory(instance, &podSpec)
+
 	// Configure TLS CA bundle (with auto-detection support)
 	configureTLSCABundle(ctx, r, instance, &podSpec)
 
 	// Configure user config
 	configureUserConfig(instance, &podSpec)
 
+	// Add the user init containers after the ones of the operator
+	configureInitContainers(instance, &podSpec)
+
 	// Apply pod overrides including ServiceAccount, volumes, and volume mounts
 	configurePodOverrides(instance, &podSpec)
 
+	// Configure the projected ServiceAccount token
+	configureServiceAccountToken(instance, &podSpec)
+
+	// Configure pod scheduling
+	configurePodScheduling(instance, &podSpec)
+
+	// Add the sidecars after the server container
+	configureSidecarContainers(instance, &podSpec)
+
 	return podSpec
 }
 
@@ -367,14 +583,19 @@ func configureStorage(instance *llamav1alpha1.LlamaStackDistribution, podSpec *c
 func configurePersistentStorage(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) {
 	// Use PVC for persistent storage
 	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-		Name: "lls-storage",
+		Name: storageVolumeName,
 		VolumeSource: corev1.VolumeSource{
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: instance.Name + "-pvc",
+				ClaimName: getPVCName(instance),
 			},
 		},
 	})
 
+	// An fsGroup grants access to the volume without running the permission fix as root
+	if hasPodFSGroup(instance) {
+		return
+	}
+
 	// Add init container to fix permissions on the PVC mount.
 	mountPath := llamav1alpha1.DefaultMountPath
 	if instance.Spec.Server.Storage.MountPath != "" {
@@ -389,7 +610,7 @@ func configurePersistentStorage(instance *llamav1alpha1.LlamaStackDistribution,
 	command := strings.Join(commands, " && ")
 
 	initContainer := corev1.Container{
-		Name:  "update-pvc-permissions",
+		Name:  storagePermissionsInitName,
 		Image: "registry.access.redhat.com/ubi9/ubi-minimal:latest",
 		Command: []string{
 			"/bin/sh",
@@ -399,14 +620,11 @@ func configurePersistentStorage(instance *llamav1alpha1.LlamaStackDistribution,
 		},
 		VolumeMounts: []corev1.VolumeMount{
 			{
-				Name:      "lls-storage",
+				Name:      storageVolumeName,
 				MountPath: mountPath,
 			},
 		},
-		SecurityContext: &corev1.SecurityContext{
-			RunAsUser:  ptr.To(int64(0)), // Run as root to be able to change ownership
-			RunAsGroup: ptr.To(int64(0)),
-		},
+		SecurityContext: getStoragePermissionsSecurityContext(),
 	}
 
 	podSpec.InitContainers = append(podSpec.InitContainers, initContainer)
@@ -416,13 +634,116 @@ func configurePersistentStorage(instance *llamav1alpha1.LlamaStackDistribution,
 func configureEmptyDirStorage(podSpec *corev1.PodSpec) {
 	// Use emptyDir for non-persistent storage
 	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-		Name: "lls-storage",
+		Name: storageVolumeName,
 		VolumeSource: corev1.VolumeSource{
 			EmptyDir: &corev1.EmptyDirVolumeSource{},
 		},
 	})
 }
 
+// configureInitContainers appends the init containers of the spec to the pod, sharing the storage of the
+// server container.
+func configureInitContainers(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) {
+	mountPath := getMountPath(instance)
+	for _, initContainer := range instance.Spec.Server.InitContainers {
+		container := *initContainer.DeepCopy()
+		mountsStorage := slices.ContainsFunc(container.VolumeMounts, func(mount corev1.VolumeMount) bool {
+			return mount.Name == storageVolumeName || path.Clean(mount.MountPath) == path.Clean(mountPath)
+		})
+		if !mountsStorage {
+			mount := corev1.VolumeMount{Name: storageVolumeName, MountPath: mountPath}
+			if instance.Spec.Server.Storage != nil {
+				mount.SubPath = instance.Spec.Server.Storage.SubPath
+			}
+			container.VolumeMounts = append(container.VolumeMounts, mount)
+		}
+		container.Env = mergeEnv([]corev1.EnvVar{{Name: "HF_HOME", Value: mountPath}}, container.Env)
+		podSpec.InitContainers = append(podSpec.InitContainers, container)
+	}
+}
+
+// validateInitContainers checks that the init containers of the spec don't reuse the name of a container
+// added by the operator.
+func validateInitContainers(instance *llamav1alpha1.LlamaStackDistribution) error {
+	reserved := []string{getContainerName(instance), storagePermissionsInitName, CABundleInitName}
+	for _, initContainer := range instance.Spec.Server.InitContainers {
+		if slices.Contains(reserved, initContainer.Name) {
+			return fmt.Errorf("failed to validate init container %q: the name is used by a container of the operator", initContainer.Name)
+		}
+	}
+	return nil
+}
+
+// configureSidecarContainers appends the sidecars of the spec to the pod after the server container. They
+// are added last so that they can mount any volume of the pod, including the podOverrides volumes.
+func configureSidecarContainers(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) {
+	for _, sidecar := range instance.Spec.Server.SidecarContainers {
+		podSpec.Containers = append(podSpec.Containers, *sidecar.DeepCopy())
+	}
+}
+
+// validateSidecarContainers checks that the sidecars of the spec don't reuse the name of the server container
+// or of an init container.
+func validateSidecarContainers(instance *llamav1alpha1.LlamaStackDistribution) error {
+	reserved := []string{getContainerName(instance), storagePermissionsInitName, CABundleInitName}
+	for _, initContainer := range instance.Spec.Server.InitContainers {
+		reserved = append(reserved, initContainer.Name)
+	}
+	for _, sidecar := range instance.Spec.Server.SidecarContainers {
+		if slices.Contains(reserved, sidecar.Name) {
+			return fmt.Errorf("failed to validate sidecar container %q: the name is used by another container of the server pods", sidecar.Name)
+		}
+	}
+	return nil
+}
+
+// configureSharedMemory mounts a memory-backed emptyDir at /dev/shm in the server container when the shared
+// memory is configured.
+func configureSharedMemory(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) {
+	sharedMemory := instance.Spec.Server.SharedMemory
+	if sharedMemory == nil {
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: SharedMemoryVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium:    corev1.StorageMediumMemory,
+				SizeLimit: ptr.To(sharedMemory.Size.DeepCopy()),
+			},
+		},
+	})
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != getContainerName(instance) {
+			continue
+		}
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      SharedMemoryVolumeName,
+			MountPath: SharedMemoryMountPath,
+		})
+	}
+}
+
+// validateSharedMemory checks that the shared memory size is positive, fits in the memory limit of the server
+// container, and that the storage isn't mounted at /dev/shm as well.
+func validateSharedMemory(instance *llamav1alpha1.LlamaStackDistribution) error {
+	sharedMemory := instance.Spec.Server.SharedMemory
+	if sharedMemory == nil {
+		return nil
+	}
+	if sharedMemory.Size.Sign() <= 0 {
+		return fmt.Errorf("failed to validate shared memory: size %s must be positive", sharedMemory.Size.String())
+	}
+	if limit, ok := instance.Spec.Server.ContainerSpec.Resources.Limits[corev1.ResourceMemory]; ok && sharedMemory.Size.Cmp(limit) > 0 {
+		return fmt.Errorf("failed to validate shared memory: size %s exceeds the memory limit %s of the server container",
+			sharedMemory.Size.String(), limit.String())
+	}
+	if path.Clean(getMountPath(instance)) == SharedMemoryMountPath {
+		return fmt.Errorf("failed to validate shared memory: the storage is mounted at %s", SharedMemoryMountPath)
+	}
+	return nil
+}
+
 // configureTLSCABundle handles TLS CA bundle configuration.
 // For multiple keys: adds a ca-bundle-init init container that concatenates all keys into a single file
 // in a shared emptyDir volume, which the main container then mounts via SubPath.
@@ -447,7 +768,7 @@ func configureTLSCABundle(ctx context.Context, r *LlamaStackDistributionReconcil
 func addExplicitCABundle(ctx context.Context, caBundleConfig *llamav1alpha1.CABundleConfig, podSpec *corev1.PodSpec) {
 	// Add CA bundle InitContainer if multiple keys are specified
 	if len(caBundleConfig.ConfigMapKeys) > 0 {
-		caBundleInitContainer, err := createCABundleInitContainer(caBundleConfig)
+		caBundleInitContainer, err := createCABundleInitContainer(caBundleConfig, podSpec.Containers[0].Image)
 		if err != nil {
 			log.FromContext(ctx).Error(err, "Failed to create CA bundle init container")
 			return
@@ -500,7 +821,7 @@ func addAutoDetectedCABundle(ctx context.Context, r *LlamaStackDistributionRecon
 	}
 
 	// Use the same logic as explicit configuration
-	caBundleInitContainer, err := createCABundleInitContainer(autoCaBundleConfig)
+	caBundleInitContainer, err := createCABundleInitContainer(autoCaBundleConfig, podSpec.Containers[0].Image)
 	if err != nil {
 		// Log error and skip auto-detected CA bundle configuration
 		log.FromContext(ctx).Error(err, "Failed to create CA bundle init container for auto-detected ConfigMap")
@@ -532,21 +853,23 @@ func addAutoDetectedCABundle(ctx context.Context, r *LlamaStackDistributionRecon
 
 // configureUserConfig handles user configuration setup.
 func configureUserConfig(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) {
-	userConfig := instance.Spec.Server.UserConfig
-	if userConfig == nil || userConfig.ConfigMapName == "" {
+	if !usesUserConfig(instance) {
 		return
 	}
 
 	// Add ConfigMap volume if user config is specified
-	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-		Name: "user-config",
-		VolumeSource: corev1.VolumeSource{
-			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: userConfig.ConfigMapName,
-				},
-			},
+	source := &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: getUserConfigVolumeConfigMapName(instance),
 		},
+	}
+	// Mount only the selected key, as the run.yaml read by the server
+	if key := instance.Spec.Server.UserConfig.ConfigMapKey; key != "" {
+		source.Items = []corev1.KeyToPath{{Key: key, Path: userConfigFileName}}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         "user-config",
+		VolumeSource: corev1.VolumeSource{ConfigMap: source},
 	})
 }
 
@@ -558,6 +881,7 @@ func configurePodOverrides(instance *llamav1alpha1.LlamaStackDistribution, podSp
 	} else {
 		podSpec.ServiceAccountName = instance.Name + "-sa"
 	}
+	podSpec.SecurityContext = getPodSecurityContext(instance)
 
 	// Apply other pod overrides if specified
 	if instance.Spec.Server.PodOverrides != nil {
@@ -572,7 +896,301 @@ func configurePodOverrides(instance *llamav1alpha1.LlamaStackDistribution, podSp
 				podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, instance.Spec.Server.PodOverrides.VolumeMounts...)
 			}
 		}
+
+		// Keep resolving cluster Services when running in the host network namespace
+		if instance.Spec.Server.PodOverrides.HostNetwork {
+			podSpec.HostNetwork = true
+			podSpec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+		}
+
+		// Pull the images from private registries with the credentials of the Secrets
+		if len(instance.Spec.Server.PodOverrides.ImagePullSecrets) > 0 {
+			podSpec.ImagePullSecrets = slices.Clone(instance.Spec.Server.PodOverrides.ImagePullSecrets)
+		}
+	}
+}
+
+// validatePodOverrideVolumes rejects the volumes and mounts of the pod overrides colliding with the ones
+// managed by the operator, which would otherwise be rejected by the API server or shadow the managed ones.
+func validatePodOverrideVolumes(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) error {
+	overrides := instance.Spec.Server.PodOverrides
+	if overrides == nil {
+		return nil
+	}
+
+	volumeNames := map[string]int{}
+	for _, volume := range podSpec.Volumes {
+		volumeNames[volume.Name]++
+	}
+	for _, volume := range overrides.Volumes {
+		if volumeNames[volume.Name] > 1 {
+			return fmt.Errorf("failed to validate podOverrides volume %q: the name is already used by another volume of the server pods", volume.Name)
+		}
+	}
+
+	mountPaths := map[string]int{}
+	for _, container := range podSpec.Containers {
+		if container.Name != getContainerName(instance) {
+			continue
+		}
+		for _, mount := range container.VolumeMounts {
+			mountPaths[path.Clean(mount.MountPath)]++
+		}
+	}
+	for _, mount := range overrides.VolumeMounts {
+		if mountPaths[path.Clean(mount.MountPath)] > 1 {
+			return fmt.Errorf("failed to validate podOverrides volume mount of %q: the path %s is already used by another mount of the server container",
+				mount.Name, mount.MountPath)
+		}
+	}
+	return nil
+}
+
+// usesHostNetwork checks if the server pods of the instance run in the host network namespace.
+func usesHostNetwork(instance *llamav1alpha1.LlamaStackDistribution) bool {
+	return instance.Spec.Server.PodOverrides != nil && instance.Spec.Server.PodOverrides.HostNetwork
+}
+
+// findHostPortConflict returns the first LlamaStackDistribution running in the host network that binds
+// a port also bound by the instance, along with the colliding port. Instances created earlier keep
+// their ports, so only the newer instance of a colliding pair reports the conflict.
+func findHostPortConflict(instance *llamav1alpha1.LlamaStackDistribution,
+	others []llamav1alpha1.LlamaStackDistribution) (*llamav1alpha1.LlamaStackDistribution, int32) {
+	if !usesHostNetwork(instance) {
+		return nil, 0
+	}
+
+	hostPorts := map[corev1.ContainerPort]bool{}
+	for _, port := range getContainerPorts(instance) {
+		hostPorts[hostPortKey(port)] = true
+	}
+
+	for i := range others {
+		other := &others[i]
+		if (other.Namespace == instance.Namespace && other.Name == instance.Name) || !usesHostNetwork(other) || !createdBefore(other, instance) {
+			continue
+		}
+		for _, port := range getContainerPorts(other) {
+			if hostPorts[hostPortKey(port)] {
+				return other, port.ContainerPort
+			}
+		}
+	}
+	return nil, 0
+}
+
+// hostPortKey identifies a port bound on the node by its number and protocol.
+func hostPortKey(port corev1.ContainerPort) corev1.ContainerPort {
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
 	}
+	return corev1.ContainerPort{ContainerPort: port.ContainerPort, Protocol: protocol}
+}
+
+// createdBefore orders instances by creation time, using the namespace and name to break ties.
+func createdBefore(a, b *llamav1alpha1.LlamaStackDistribution) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// configureServiceAccountToken mounts a projected ServiceAccount token with the requested audience
+// and expiration into the server container.
+func configureServiceAccountToken(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) {
+	tokenSpec := instance.Spec.Server.ServiceAccountToken
+	if tokenSpec == nil {
+		return
+	}
+
+	expirationSeconds := ptr.To(defaultServiceAccountTokenExpirationSeconds)
+	if tokenSpec.ExpirationSeconds != nil {
+		expirationSeconds = tokenSpec.ExpirationSeconds
+	}
+	mountPath := llamav1alpha1.DefaultServiceAccountTokenMountPath
+	if tokenSpec.MountPath != "" {
+		mountPath = tokenSpec.MountPath
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: serviceAccountTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          tokenSpec.Audience,
+							ExpirationSeconds: expirationSeconds,
+							Path:              serviceAccountTokenFileName,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	if len(podSpec.Containers) > 0 {
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      serviceAccountTokenVolumeName,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+	}
+}
+
+// configurePodScheduling applies the scheduling settings to the pod spec.
+func configurePodScheduling(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec) {
+	// Leaving the scheduler name empty lets Kubernetes use the default scheduler
+	if instance.Spec.Server.SchedulerName != "" {
+		podSpec.SchedulerName = instance.Spec.Server.SchedulerName
+	}
+
+	// Unset overrides are left nil, so that the pod template hash doesn't change and the Deployment isn't updated
+	overrides := instance.Spec.Server.PodOverrides
+	if overrides == nil {
+		return
+	}
+	if len(overrides.NodeSelector) > 0 {
+		podSpec.NodeSelector = maps.Clone(overrides.NodeSelector)
+	}
+	if overrides.Affinity != nil {
+		podSpec.Affinity = overrides.Affinity.DeepCopy()
+	}
+	for _, toleration := range overrides.Tolerations {
+		podSpec.Tolerations = append(podSpec.Tolerations, *toleration.DeepCopy())
+	}
+	podSpec.PriorityClassName = overrides.PriorityClassName
+}
+
+// checkPriorityClass reports through a condition whether the PriorityClass of the server pods exists, and warns
+// with an event when it goes missing. The Deployment is applied anyway, so that the PriorityClass can be created
+// after the instance.
+func (r *LlamaStackDistributionReconciler) checkPriorityClass(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
+	if instance.Spec.Server.PodOverrides == nil || instance.Spec.Server.PodOverrides.PriorityClassName == "" {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, ConditionTypePriorityClassAvailable)
+		return
+	}
+	name := instance.Spec.Server.PodOverrides.PriorityClassName
+	err := r.Get(ctx, types.NamespacedName{Name: name}, &schedulingv1.PriorityClass{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		message := fmt.Sprintf("PriorityClass %s not found, the server pods can't be created until it exists", name)
+		// The event is only recorded on the transition, the condition reports it on the following reconciles
+		if condition := GetCondition(&instance.Status, ConditionTypePriorityClassAvailable); condition == nil || condition.Message != message {
+			log.FromContext(ctx).Info(message)
+			r.recordEvent(instance, corev1.EventTypeWarning, reasonPriorityClassNotFound, message)
+		}
+		SetPriorityClassAvailableCondition(instance, false, message)
+	case err != nil:
+		log.FromContext(ctx).V(1).Info("failed to check the PriorityClass of the server pods", "priorityClass", name, "error", err.Error())
+	default:
+		SetPriorityClassAvailableCondition(instance, true, MessagePriorityClassAvailable)
+	}
+}
+
+// getDeploymentStrategy returns the update strategy of the Deployment. It is left empty when unset, so that
+// the Deployment defaults to RollingUpdate.
+func getDeploymentStrategy(instance *llamav1alpha1.LlamaStackDistribution) appsv1.DeploymentStrategy {
+	strategy := instance.Spec.Server.DeploymentStrategy
+	if strategy == nil {
+		return appsv1.DeploymentStrategy{}
+	}
+	return appsv1.DeploymentStrategy{
+		Type:          strategy.Type,
+		RollingUpdate: strategy.RollingUpdate.DeepCopy(),
+	}
+}
+
+// configureTopologySpread applies the topology spread constraints of the pod overrides. Without them, and when
+// spreadAcrossNodes is set, the pods of an instance running more than one replica are spread across the nodes,
+// without blocking their scheduling when the nodes can't be balanced.
+func configureTopologySpread(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec, replicas int32) {
+	overrides := instance.Spec.Server.PodOverrides
+	if overrides == nil {
+		return
+	}
+	if len(overrides.TopologySpreadConstraints) > 0 {
+		for _, constraint := range overrides.TopologySpreadConstraints {
+			podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, *constraint.DeepCopy())
+		}
+		return
+	}
+	if !overrides.SpreadAcrossNodes {
+		return
+	}
+
+	if autoscaling := instance.Spec.Server.Autoscaling; autoscaling != nil {
+		replicas = autoscaling.MaxReplicas
+	}
+	if replicas <= 1 {
+		return
+	}
+	podSpec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelHostname,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: getPodSelectorLabels(instance)},
+	}}
+}
+
+// invalidSpecError is a validation error of the spec, reported with the InvalidSpec reason rather than as a
+// failure of the resources.
+type invalidSpecError struct {
+	err error
+}
+
+func (e *invalidSpecError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidSpecError) Unwrap() error {
+	return e.err
+}
+
+// isInvalidSpec returns true when the error is a validation error of the spec.
+func isInvalidSpec(err error) bool {
+	var invalidSpec *invalidSpecError
+	return errors.As(err, &invalidSpec)
+}
+
+// validateImageAllowlist checks the other images pulled for the instance against the image allowlist: the
+// images of the init and sidecar containers, of the pre-start Job and of the image volumes. A pre-start Job
+// without an image runs the server image, which is checked with the distribution.
+func (r *LlamaStackDistributionReconciler) validateImageAllowlist(instance *llamav1alpha1.LlamaStackDistribution) error {
+	if r.ImageAllowlist == nil {
+		return nil
+	}
+	type pulledImage struct {
+		source string
+		image  string
+	}
+	var images []pulledImage
+	for _, container := range instance.Spec.Server.InitContainers {
+		images = append(images, pulledImage{source: "init container " + container.Name, image: container.Image})
+	}
+	for _, container := range instance.Spec.Server.SidecarContainers {
+		images = append(images, pulledImage{source: "sidecar container " + container.Name, image: container.Image})
+	}
+	if preStartJob := instance.Spec.Server.PreStartJob; preStartJob != nil && preStartJob.Image != "" {
+		images = append(images, pulledImage{source: "pre-start Job", image: preStartJob.Image})
+	}
+	if storage := instance.Spec.Server.Storage; storage != nil {
+		for _, imageVolume := range storage.ImageVolumes {
+			images = append(images, pulledImage{source: "image volume " + imageVolume.Name, image: imageVolume.Reference})
+		}
+	}
+
+	for _, pulled := range images {
+		if !r.ImageAllowlist.Allows(pulled.image) {
+			return fmt.Errorf("failed to validate image %s of the %s: the image is not in the image allowlist of the operator",
+				pulled.image, pulled.source)
+		}
+	}
+	return nil
 }
 
 // validateDistribution validates the distribution configuration.
@@ -585,13 +1203,169 @@ func (r *LlamaStackDistributionReconciler) validateDistribution(instance *llamav
 		if _, exists := r.ClusterInfo.DistributionImages[instance.Spec.Server.Distribution.Name]; !exists {
 			return fmt.Errorf("failed to validate distribution: %s. Distribution name not supported", instance.Spec.Server.Distribution.Name)
 		}
+	} else if image := instance.Spec.Server.Distribution.Image; image != "" && !r.ImageAllowlist.Allows(image) {
+		// The catalog images are trusted, only the custom images are checked
+		return fmt.Errorf("failed to validate distribution image %s: the image is not in the image allowlist of the operator", image)
+	}
+
+	if err := r.validateImageAllowlist(instance); err != nil {
+		return err
+	}
+
+	if err := validateSubPaths(instance); err != nil {
+		return err
+	}
+
+	if err := validateSharedMemory(instance); err != nil {
+		return err
+	}
+
+	if err := validateInitContainers(instance); err != nil {
+		return err
+	}
+
+	if err := validateSidecarContainers(instance); err != nil {
+		return err
 	}
 
+	if err := validateMaintenanceWindow(instance); err != nil {
+		return err
+	}
+
+	if err := validateHealthCheck(instance); err != nil {
+		return err
+	}
+
+	if err := validateNetworkPolicy(instance); err != nil {
+		return err
+	}
+
+	if err := validateCreatedServiceAccount(instance); err != nil {
+		return err
+	}
+
+	if err := r.validateImageVolumeSupport(instance); err != nil {
+		return err
+	}
+
+	return validatePorts(instance)
+}
+
+// validateImageVolumeSupport checks that the cluster supports the image volumes of the instance, if any.
+func (r *LlamaStackDistributionReconciler) validateImageVolumeSupport(instance *llamav1alpha1.LlamaStackDistribution) error {
+	if len(getImageVolumes(instance)) == 0 || (r.ClusterInfo != nil && r.ClusterInfo.ImageVolumesSupported) {
+		return nil
+	}
+	return errors.New("failed to validate image volumes: the cluster does not support image volumes, " +
+		"Kubernetes 1.31 or later with the ImageVolume feature gate enabled is required")
+}
+
+// validateSubPaths checks that the subpaths of the storage and image volume mounts stay within their volume.
+func validateSubPaths(instance *llamav1alpha1.LlamaStackDistribution) error {
+	storage := instance.Spec.Server.Storage
+	if storage == nil {
+		return nil
+	}
+	if err := validateSubPath(storage.SubPath); err != nil {
+		return fmt.Errorf("failed to validate storage: %w", err)
+	}
+	for _, imageVolume := range storage.ImageVolumes {
+		if err := validateSubPath(imageVolume.SubPath); err != nil {
+			return fmt.Errorf("failed to validate image volume %s: %w", imageVolume.Name, err)
+		}
+	}
 	return nil
 }
 
+// validateSubPath checks that a subpath is relative and has no ".." element, which the API server rejects
+// even when the path stays within the volume.
+func validateSubPath(subPath string) error {
+	if subPath == "" {
+		return nil
+	}
+	if path.IsAbs(subPath) {
+		return fmt.Errorf("failed to validate subPath %q: must be a relative path", subPath)
+	}
+	if slices.Contains(strings.Split(subPath, "/"), "..") {
+		return fmt.Errorf("failed to validate subPath %q: must not contain '..'", subPath)
+	}
+	return nil
+}
+
+// validatePorts checks that the ports of the server container, which are also its Service ports, are in range.
+func validatePorts(instance *llamav1alpha1.LlamaStackDistribution) error {
+	for _, port := range getContainerPorts(instance) {
+		if port.ContainerPort < 1 || port.ContainerPort > maxPort {
+			return fmt.Errorf("failed to validate ports: port %d is out of range, ports must be between 1 and %d", port.ContainerPort, maxPort)
+		}
+	}
+	return nil
+}
+
+// reportPortsValid sets the PortsValid condition from the range of the ports of the server container.
+func reportPortsValid(instance *llamav1alpha1.LlamaStackDistribution) {
+	if err := validatePorts(instance); err != nil {
+		SetPortsValidCondition(instance, false, err.Error())
+		return
+	}
+	SetPortsValidCondition(instance, true, MessagePortsValid)
+}
+
+// checkPrivilegedPorts warns, through the PortsValid condition, when the container listens on a privileged
+// port without the NET_BIND_SERVICE capability. The server may still bind it, e.g. when it runs as root with the
+// default capabilities of the container runtime, so the Deployment is created anyway.
+func checkPrivilegedPorts(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution, container *corev1.Container) {
+	if canBindPrivilegedPorts(container.SecurityContext) {
+		return
+	}
+	for _, port := range container.Ports {
+		if port.ContainerPort < minUnprivilegedPort {
+			message := fmt.Sprintf("Port %d is privileged and the container lacks the NET_BIND_SERVICE capability, "+
+				"the server may fail to bind it", port.ContainerPort)
+			log.FromContext(ctx).Info(message)
+			SetPortsValidCondition(instance, false, message)
+			return
+		}
+	}
+}
+
+// canBindPrivilegedPorts returns true when the security context explicitly allows binding privileged ports.
+func canBindPrivilegedPorts(securityContext *corev1.SecurityContext) bool {
+	if securityContext == nil {
+		return false
+	}
+	if ptr.Deref(securityContext.Privileged, false) {
+		return true
+	}
+	return securityContext.Capabilities != nil && slices.Contains(securityContext.Capabilities.Add, "NET_BIND_SERVICE")
+}
+
+// getReplicas returns the replica count for the distribution. An explicit spec.replicas always wins,
+// otherwise the catalog default for the named distribution is used, falling back to 1, like the webhook.
+func (r *LlamaStackDistributionReconciler) getReplicas(instance *llamav1alpha1.LlamaStackDistribution) int32 {
+	if instance.Spec.Replicas != nil {
+		return *instance.Spec.Replicas
+	}
+	var distributionReplicas map[string]int32
+	if r.ClusterInfo != nil {
+		distributionReplicas = r.ClusterInfo.DistributionReplicas
+	}
+	return llamav1alpha1.GetDefaultReplicas(instance, distributionReplicas)
+}
+
+// getStartupProbeFailureThreshold returns the failures allowed to the startup probe, extended by the catalog
+// warm-up hint of distributions known to start slowly, so that they aren't restarted while loading.
+func (r *LlamaStackDistributionReconciler) getStartupProbeFailureThreshold(instance *llamav1alpha1.LlamaStackDistribution) int32 {
+	if r != nil && r.ClusterInfo != nil && instance.Spec.Server.Distribution.Name != "" {
+		if startup, ok := r.ClusterInfo.DistributionStartupSeconds[instance.Spec.Server.Distribution.Name]; ok {
+			return startupProbeFailureThreshold + (startup+startupProbePeriodSeconds-1)/startupProbePeriodSeconds
+		}
+	}
+	return startupProbeFailureThreshold
+}
+
 // resolveImage determines the container image to use based on the distribution configuration.
-// It returns the resolved image and any error encountered.
+// The resolved image is rewritten to the configured registry mirror, if any.
 func (r *LlamaStackDistributionReconciler) resolveImage(distribution llamav1alpha1.DistributionType) (string, error) {
 	distributionMap := r.ClusterInfo.DistributionImages
 	switch {
@@ -599,10 +1373,210 @@ func (r *LlamaStackDistributionReconciler) resolveImage(distribution llamav1alph
 		if _, exists := distributionMap[distribution.Name]; !exists {
 			return "", fmt.Errorf("failed to validate distribution name: %s", distribution.Name)
 		}
-		return distributionMap[distribution.Name], nil
+		return r.ImageRegistryMirror.Rewrite(distributionMap[distribution.Name]), nil
 	case distribution.Image != "":
-		return distribution.Image, nil
+		return r.ImageRegistryMirror.Rewrite(distribution.Image), nil
 	default:
 		return "", errors.New("failed to validate distribution: either distribution.name or distribution.image must be set")
 	}
 }
+
+// getImageVolumes returns the OCI image volumes mounted into the server container.
+func getImageVolumes(instance *llamav1alpha1.LlamaStackDistribution) []llamav1alpha1.ImageVolumeSpec {
+	if instance.Spec.Server.Storage == nil {
+		return nil
+	}
+	return instance.Spec.Server.Storage.ImageVolumes
+}
+
+// addImageVolumes adds the image volumes of the instance and their read-only mounts in the server container to
+// the pod spec, and records them in the imageVolumesAnnotation of the pod template. The typed API predates image
+// volumes, so the volumes are added without a source, which setImageVolumeSources sets from the annotation on
+// the unstructured object that is applied. The annotation is kept by the pod templates read back from the live
+// Deployment, so that a deferred or rolled back template still mounts its own image volumes.
+func addImageVolumes(instance *llamav1alpha1.LlamaStackDistribution, podSpec *corev1.PodSpec, annotations map[string]string) error {
+	imageVolumes := getImageVolumes(instance)
+	if len(imageVolumes) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(imageVolumes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal image volumes: %w", err)
+	}
+	annotations[imageVolumesAnnotation] = string(data)
+
+	for _, imageVolume := range imageVolumes {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{Name: imageVolume.Name})
+		for i := range podSpec.Containers {
+			if podSpec.Containers[i].Name != getContainerName(instance) {
+				continue
+			}
+			podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      imageVolume.Name,
+				MountPath: imageVolume.MountPath,
+				SubPath:   imageVolume.SubPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+	return nil
+}
+
+// hasImageVolumes reports whether the pod template with the given annotations mounts image volumes, which
+// must be applied through an unstructured object.
+func hasImageVolumes(annotations map[string]string) bool {
+	_, ok := annotations[imageVolumesAnnotation]
+	return ok
+}
+
+// setImageVolumeSources sets the image source of the volumes recorded in the imageVolumesAnnotation of the pod
+// template found at templatePath in the object, e.g. spec.template for a Deployment and the object itself for
+// a Pod.
+func setImageVolumeSources(obj *unstructured.Unstructured, templatePath ...string) error {
+	annotation, found, err := unstructured.NestedString(obj.Object, append(slices.Clone(templatePath), "metadata", "annotations", imageVolumesAnnotation)...)
+	if err != nil || !found {
+		return err
+	}
+	var imageVolumes []llamav1alpha1.ImageVolumeSpec
+	if err := json.Unmarshal([]byte(annotation), &imageVolumes); err != nil {
+		return fmt.Errorf("failed to parse image volumes: %w", err)
+	}
+
+	volumesPath := append(slices.Clone(templatePath), "spec", "volumes")
+	volumes, _, err := unstructured.NestedSlice(obj.Object, volumesPath...)
+	if err != nil {
+		return fmt.Errorf("failed to read volumes: %w", err)
+	}
+	for _, item := range volumes {
+		volume, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, imageVolume := range imageVolumes {
+			if volume["name"] != imageVolume.Name {
+				continue
+			}
+			source := map[string]any{"reference": imageVolume.Reference}
+			if imageVolume.PullPolicy != "" {
+				source["pullPolicy"] = string(imageVolume.PullPolicy)
+			}
+			volume["image"] = source
+		}
+	}
+	if err := unstructured.SetNestedSlice(obj.Object, volumes, volumesPath...); err != nil {
+		return fmt.Errorf("failed to set volumes: %w", err)
+	}
+	return nil
+}
+
+// reportPodTemplate records the summary of the pod template in the status of instances asking for it. The
+// image volumes are part of the template, as placeholder volumes and the annotation holding their sources.
+func reportPodTemplate(instance *llamav1alpha1.LlamaStackDistribution, template *corev1.PodTemplateSpec) error {
+	if !instance.Spec.Server.ReportPodTemplate {
+		instance.Status.PodTemplate = nil
+		return nil
+	}
+
+	hash, err := getPodTemplateHash(template)
+	if err != nil {
+		return err
+	}
+	summary := &llamav1alpha1.PodTemplateSummary{
+		Hash:               hash,
+		ServiceAccountName: template.Spec.ServiceAccountName,
+		NodeSelector:       template.Spec.NodeSelector,
+	}
+	for _, container := range template.Spec.Containers {
+		if container.Name != getContainerName(instance) {
+			continue
+		}
+		summary.Image = container.Image
+		summary.Resources = container.Resources
+		for _, env := range container.Env {
+			summary.EnvNames = append(summary.EnvNames, env.Name)
+		}
+	}
+	for _, volume := range template.Spec.Volumes {
+		summary.Volumes = append(summary.Volumes, volume.Name)
+	}
+	instance.Status.PodTemplate = summary
+	return nil
+}
+
+// setSafeToEvictAnnotation tells the cluster autoscaler whether it may evict the server pods.
+func setSafeToEvictAnnotation(instance *llamav1alpha1.LlamaStackDistribution, podAnnotations map[string]string) {
+	if instance.Spec.Server.PodOverrides == nil || instance.Spec.Server.PodOverrides.SafeToEvict == nil {
+		return
+	}
+	podAnnotations[safeToEvictAnnotation] = strconv.FormatBool(*instance.Spec.Server.PodOverrides.SafeToEvict)
+}
+
+// setLogCollectionAnnotations adds the log collection annotations of the operator config to the server pods
+// of instances enabling log collection.
+func (r *LlamaStackDistributionReconciler) setLogCollectionAnnotations(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	podAnnotations map[string]string) {
+	if instance.Spec.Server.PodOverrides == nil || !instance.Spec.Server.PodOverrides.LogCollection {
+		return
+	}
+	if r.LogCollectionConfig == nil {
+		log.FromContext(ctx).Info("log collection is enabled but the operator config sets no log collection annotations")
+		return
+	}
+	for key, value := range r.LogCollectionConfig.PodAnnotations(getContainerName(instance)) {
+		podAnnotations[key] = value
+	}
+}
+
+// trackRollout records the start of a rollout when the pod template of the Deployment changes.
+func trackRollout(instance *llamav1alpha1.LlamaStackDistribution, deployment *appsv1.Deployment, now time.Time) error {
+	hash, err := getPodTemplateHash(&deployment.Spec.Template)
+	if err != nil {
+		return err
+	}
+	if instance.Status.Rollout == nil || instance.Status.Rollout.TemplateHash != hash {
+		instance.Status.Rollout = &llamav1alpha1.RolloutStatus{TemplateHash: hash, StartTime: metav1.NewTime(now)}
+	}
+	return nil
+}
+
+// isDeploymentScalingDown returns true when the deployment runs more replicas than desired because it is
+// being scaled down. Surge replicas of a rollout also exceed the desired count, ready or not, but are not all
+// updated yet.
+func isDeploymentScalingDown(deployment *appsv1.Deployment, replicas int32) bool {
+	status := deployment.Status
+	if status.UpdatedReplicas < status.Replicas {
+		return false
+	}
+	return status.ReadyReplicas > replicas || status.Replicas > replicas
+}
+
+// findSelectorConflict returns the name of the first Deployment whose selector matches the pod
+// labels of the instance, or whose pods are matched by the instance selector.
+func findSelectorConflict(instance *llamav1alpha1.LlamaStackDistribution, deployments []appsv1.Deployment) (string, error) {
+	podLabels := labels.Set(getPodSelectorLabels(instance))
+	ownSelector := labels.SelectorFromSet(podLabels)
+
+	for i := range deployments {
+		deployment := &deployments[i]
+		if deployment.Name == instance.Name {
+			continue
+		}
+
+		if ownSelector.Matches(labels.Set(deployment.Spec.Template.Labels)) {
+			return deployment.Name, nil
+		}
+
+		if deployment.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse selector of Deployment %s: %w", deployment.Name, err)
+		}
+		if !selector.Empty() && selector.Matches(podLabels) {
+			return deployment.Name, nil
+		}
+	}
+
+	return "", nil
+}
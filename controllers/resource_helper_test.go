@@ -18,18 +18,31 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/cluster"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/registry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestBuildContainerSpec(t *testing.T) {
@@ -50,10 +63,11 @@ func TestBuildContainerSpec(t *testing.T) {
 			},
 			image: "test-image:latest",
 			expectedResult: corev1.Container{
-				Name:           llamav1alpha1.DefaultContainerName,
-				Image:          "test-image:latest",
-				Ports:          []corev1.ContainerPort{{ContainerPort: llamav1alpha1.DefaultServerPort}},
-				ReadinessProbe: newDefaultReadinessProbe(llamav1alpha1.DefaultServerPort),
+				Name:            llamav1alpha1.DefaultContainerName,
+				Image:           "test-image:latest",
+				Ports:           []corev1.ContainerPort{{ContainerPort: llamav1alpha1.DefaultServerPort}},
+				ReadinessProbe:  newDefaultReadinessProbe(llamav1alpha1.DefaultServerPort),
+				SecurityContext: newDefaultContainerSecurityContext(),
 				VolumeMounts: []corev1.VolumeMount{{
 					Name:      "lls-storage",
 					MountPath: llamav1alpha1.DefaultMountPath,
@@ -75,6 +89,7 @@ func TestBuildContainerSpec(t *testing.T) {
 								Limits: corev1.ResourceList{
 									corev1.ResourceCPU:    resource.MustParse("1"),
 									corev1.ResourceMemory: resource.MustParse("2Gi"),
+									"nvidia.com/gpu":      resource.MustParse("1"),
 								},
 							},
 							Env: []corev1.EnvVar{
@@ -84,19 +99,24 @@ func TestBuildContainerSpec(t *testing.T) {
 						Storage: &llamav1alpha1.StorageSpec{
 							MountPath: "/custom/path",
 						},
+						PodOverrides: &llamav1alpha1.PodOverrides{
+							ContainerSecurityContext: &corev1.SecurityContext{RunAsUser: ptr.To(int64(1001))},
+						},
 					},
 				},
 			},
 			image: "test-image:latest",
 			expectedResult: corev1.Container{
-				Name:           "custom-container",
-				Image:          "test-image:latest",
-				Ports:          []corev1.ContainerPort{{ContainerPort: 9000}},
-				ReadinessProbe: newDefaultReadinessProbe(9000),
+				Name:            "custom-container",
+				Image:           "test-image:latest",
+				Ports:           []corev1.ContainerPort{{ContainerPort: 9000}},
+				ReadinessProbe:  newDefaultReadinessProbe(9000),
+				SecurityContext: withRunAsUser(newDefaultContainerSecurityContext(), 1001),
 				Resources: corev1.ResourceRequirements{
 					Limits: corev1.ResourceList{
 						corev1.ResourceCPU:    resource.MustParse("1"),
 						corev1.ResourceMemory: resource.MustParse("2Gi"),
+						"nvidia.com/gpu":      resource.MustParse("1"),
 					},
 				},
 				Env: []corev1.EnvVar{
@@ -124,12 +144,13 @@ func TestBuildContainerSpec(t *testing.T) {
 			},
 			image: "test-image:latest",
 			expectedResult: corev1.Container{
-				Name:           llamav1alpha1.DefaultContainerName,
-				Image:          "test-image:latest",
-				Command:        []string{"/custom/entrypoint.sh"},
-				Args:           []string{"--config", "/etc/config.yaml", "--debug"},
-				Ports:          []corev1.ContainerPort{{ContainerPort: llamav1alpha1.DefaultServerPort}},
-				ReadinessProbe: newDefaultReadinessProbe(llamav1alpha1.DefaultServerPort),
+				Name:            llamav1alpha1.DefaultContainerName,
+				Image:           "test-image:latest",
+				Command:         []string{"/custom/entrypoint.sh"},
+				Args:            []string{"--config", "/etc/config.yaml", "--debug"},
+				Ports:           []corev1.ContainerPort{{ContainerPort: llamav1alpha1.DefaultServerPort}},
+				ReadinessProbe:  newDefaultReadinessProbe(llamav1alpha1.DefaultServerPort),
+				SecurityContext: newDefaultContainerSecurityContext(),
 				VolumeMounts: []corev1.VolumeMount{{
 					Name:      "lls-storage",
 					MountPath: llamav1alpha1.DefaultMountPath,
@@ -139,6 +160,66 @@ func TestBuildContainerSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "empty command and args keep the image defaults",
+			instance: &llamav1alpha1.LlamaStackDistribution{
+				Spec: llamav1alpha1.LlamaStackDistributionSpec{
+					Server: llamav1alpha1.ServerSpec{
+						ContainerSpec: llamav1alpha1.ContainerSpec{
+							Command: []string{},
+							Args:    []string{},
+						},
+					},
+				},
+			},
+			image: "test-image:latest",
+			expectedResult: corev1.Container{
+				Name:            llamav1alpha1.DefaultContainerName,
+				Image:           "test-image:latest",
+				Ports:           []corev1.ContainerPort{{ContainerPort: llamav1alpha1.DefaultServerPort}},
+				ReadinessProbe:  newDefaultReadinessProbe(llamav1alpha1.DefaultServerPort),
+				SecurityContext: newDefaultContainerSecurityContext(),
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "lls-storage",
+					MountPath: llamav1alpha1.DefaultMountPath,
+				}},
+				Env: []corev1.EnvVar{
+					{Name: "HF_HOME", Value: llamav1alpha1.DefaultMountPath},
+				},
+			},
+		},
+		{
+			name: "with preStop hook",
+			instance: &llamav1alpha1.LlamaStackDistribution{
+				Spec: llamav1alpha1.LlamaStackDistributionSpec{
+					Server: llamav1alpha1.ServerSpec{
+						PreStop: &corev1.LifecycleHandler{
+							Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "sleep 20"}},
+						},
+					},
+				},
+			},
+			image: "test-image:latest",
+			expectedResult: corev1.Container{
+				Name:            llamav1alpha1.DefaultContainerName,
+				Image:           "test-image:latest",
+				Ports:           []corev1.ContainerPort{{ContainerPort: llamav1alpha1.DefaultServerPort}},
+				ReadinessProbe:  newDefaultReadinessProbe(llamav1alpha1.DefaultServerPort),
+				SecurityContext: newDefaultContainerSecurityContext(),
+				Lifecycle: &corev1.Lifecycle{
+					PreStop: &corev1.LifecycleHandler{
+						Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "sleep 20"}},
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "lls-storage",
+					MountPath: llamav1alpha1.DefaultMountPath,
+				}},
+				Env: []corev1.EnvVar{
+					{Name: "HF_HOME", Value: llamav1alpha1.DefaultMountPath},
+				},
+			},
+		},
 		{
 			name: "with user config",
 			instance: &llamav1alpha1.LlamaStackDistribution{
@@ -161,6 +242,7 @@ func TestBuildContainerSpec(t *testing.T) {
 				ImagePullPolicy: corev1.PullAlways,
 				Ports:           []corev1.ContainerPort{{ContainerPort: llamav1alpha1.DefaultServerPort}},
 				ReadinessProbe:  newDefaultReadinessProbe(llamav1alpha1.DefaultServerPort),
+				SecurityContext: newDefaultContainerSecurityContext(),
 				Command:         []string{"python", "-m", "llama_stack.distribution.server.server"},
 				Args:            []string{"--config", "/etc/llama-stack/run.yaml"},
 				Env: []corev1.EnvVar{
@@ -193,6 +275,8 @@ func TestBuildContainerSpec(t *testing.T) {
 			assert.Equal(t, tc.expectedResult.Command, result.Command)
 			assert.Equal(t, tc.expectedResult.Args, result.Args)
 			assert.Equal(t, tc.expectedResult.ReadinessProbe, result.ReadinessProbe)
+			assert.Equal(t, tc.expectedResult.Lifecycle, result.Lifecycle)
+			assert.Equal(t, tc.expectedResult.SecurityContext, result.SecurityContext)
 		})
 	}
 }
@@ -400,6 +484,145 @@ func setupTestClusterInfo(images map[string]string) *cluster.ClusterInfo {
 	}
 }
 
+func TestConfigureSharedMemory(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	container := corev1.Container{Name: getContainerName(instance)}
+
+	podSpec := configurePodStorage(context.Background(), nil, instance, container)
+	assert.Len(t, podSpec.Volumes, 1, "only the storage volume is mounted by default")
+
+	instance.Spec.Server.SharedMemory = &llamav1alpha1.SharedMemorySpec{Size: resource.MustParse("2Gi")}
+	podSpec = configurePodStorage(context.Background(), nil, instance, container)
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: SharedMemoryVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory, SizeLimit: ptr.To(resource.MustParse("2Gi"))},
+		},
+	})
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: SharedMemoryVolumeName, MountPath: SharedMemoryMountPath})
+	require.NoError(t, validatePodOverrideVolumes(instance, &podSpec))
+
+	// A pod override volume can't reuse the name of the shared memory volume
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		Volumes: []corev1.Volume{{Name: SharedMemoryVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+	}
+	podSpec = configurePodStorage(context.Background(), nil, instance, container)
+	require.Error(t, validatePodOverrideVolumes(instance, &podSpec))
+}
+
+func TestConfigureInitContainers(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{MountPath: "/data", SubPath: "models"}
+	instance.Spec.Server.InitContainers = []corev1.Container{
+		{Name: "fetch-weights", Image: "hf-downloader:latest"},
+		{
+			Name:         "fetch-custom",
+			Image:        "downloader:latest",
+			Env:          []corev1.EnvVar{{Name: "HF_HOME", Value: "/data/hf"}},
+			VolumeMounts: []corev1.VolumeMount{{Name: storageVolumeName, MountPath: "/cache"}},
+		},
+	}
+	container := corev1.Container{Name: getContainerName(instance)}
+
+	podSpec := configurePodStorage(context.Background(), nil, instance, container)
+	require.Len(t, podSpec.InitContainers, 3)
+	assert.Equal(t, storagePermissionsInitName, podSpec.InitContainers[0].Name, "the PVC ownership is fixed first")
+
+	fetch := podSpec.InitContainers[1]
+	assert.Equal(t, "fetch-weights", fetch.Name)
+	assert.Equal(t, []corev1.VolumeMount{{Name: storageVolumeName, MountPath: "/data", SubPath: "models"}}, fetch.VolumeMounts)
+	assert.Equal(t, []corev1.EnvVar{{Name: "HF_HOME", Value: "/data"}}, fetch.Env)
+
+	// The mounts and environment of the init containers are kept
+	custom := podSpec.InitContainers[2]
+	assert.Equal(t, []corev1.VolumeMount{{Name: storageVolumeName, MountPath: "/cache"}}, custom.VolumeMounts)
+	assert.Equal(t, []corev1.EnvVar{{Name: "HF_HOME", Value: "/data/hf"}}, custom.Env)
+	assert.Empty(t, instance.Spec.Server.InitContainers[0].VolumeMounts, "the spec is left unchanged")
+
+	require.NoError(t, validateInitContainers(instance))
+	instance.Spec.Server.InitContainers[0].Name = storagePermissionsInitName
+	require.ErrorContains(t, validateInitContainers(instance), "the name is used by a container of the operator")
+}
+
+func TestConfigureSidecarContainers(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		Volumes: []corev1.Volume{{Name: "logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+	}
+	instance.Spec.Server.SidecarContainers = []corev1.Container{
+		{
+			Name:         "log-shipper",
+			Image:        "fluent-bit:latest",
+			VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/var/log/llama"}},
+		},
+	}
+	container := corev1.Container{Name: getContainerName(instance)}
+
+	podSpec := configurePodStorage(context.Background(), nil, instance, container)
+	require.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, getContainerName(instance), podSpec.Containers[0].Name, "the server container comes first")
+	assert.Equal(t, instance.Spec.Server.SidecarContainers[0], podSpec.Containers[1])
+	assert.True(t, slices.ContainsFunc(podSpec.Volumes, func(volume corev1.Volume) bool {
+		return volume.Name == "logs"
+	}), "the sidecar mounts a volume of the pod overrides")
+
+	require.NoError(t, validateSidecarContainers(instance))
+	instance.Spec.Server.InitContainers = []corev1.Container{{Name: "log-shipper"}}
+	require.ErrorContains(t, validateSidecarContainers(instance), "the name is used by another container")
+	instance.Spec.Server.InitContainers = nil
+	instance.Spec.Server.SidecarContainers[0].Name = getContainerName(instance)
+	require.ErrorContains(t, validateSidecarContainers(instance), "the name is used by another container")
+}
+
+func TestConfigureTerminationGracePeriod(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	container := corev1.Container{Name: getContainerName(instance)}
+
+	podSpec := configurePodStorage(context.Background(), nil, instance, container)
+	assert.Nil(t, podSpec.TerminationGracePeriodSeconds, "the pods keep the default grace period")
+
+	instance.Spec.Server.TerminationGracePeriodSeconds = ptr.To(int64(120))
+	podSpec = configurePodStorage(context.Background(), nil, instance, container)
+	assert.Equal(t, ptr.To(int64(120)), podSpec.TerminationGracePeriodSeconds)
+}
+
+func TestValidateSharedMemory(t *testing.T) {
+	testCases := []struct {
+		name        string
+		size        string
+		memory      string
+		mountPath   string
+		expectedErr string
+	}{
+		{name: "unset memory limit", size: "1Gi"},
+		{name: "within the memory limit", size: "1Gi", memory: "4Gi"},
+		{name: "zero size", size: "0", expectedErr: "size 0 must be positive"},
+		{name: "negative size", size: "-1Gi", expectedErr: "size -1Gi must be positive"},
+		{name: "above the memory limit", size: "8Gi", memory: "4Gi", expectedErr: "size 8Gi exceeds the memory limit 4Gi of the server container"},
+		{name: "storage mounted at /dev/shm", size: "1Gi", mountPath: "/dev/shm/", expectedErr: "the storage is mounted at /dev/shm"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &llamav1alpha1.LlamaStackDistribution{}
+			instance.Spec.Server.SharedMemory = &llamav1alpha1.SharedMemorySpec{Size: resource.MustParse(tc.size)}
+			if tc.memory != "" {
+				instance.Spec.Server.ContainerSpec.Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(tc.memory)}
+			}
+			if tc.mountPath != "" {
+				instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{MountPath: tc.mountPath}
+			}
+
+			err := validateSharedMemory(instance)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
 func TestResolveImage(t *testing.T) {
 	// Setup test cluster info
 	clusterInfo := setupTestClusterInfo(map[string]string{
@@ -447,162 +670,1556 @@ func TestResolveImage(t *testing.T) {
 	}
 }
 
-func TestDistributionValidation(t *testing.T) {
-	// Setup test cluster info
+func TestGetReplicas(t *testing.T) {
 	clusterInfo := setupTestClusterInfo(map[string]string{
-		"ollama": "lls/lls-ollama:1.0",
+		"ollama":  "ollama-image:latest",
+		"starter": "starter-image:latest",
 	})
+	clusterInfo.DistributionReplicas = map[string]int32{"ollama": 3}
 
 	testCases := []struct {
-		name        string
-		instance    *llamav1alpha1.LlamaStackDistribution
-		expectError bool
+		name             string
+		instance         *llamav1alpha1.LlamaStackDistribution
+		expectedReplicas int32
 	}{
 		{
-			name:        "valid distribution name",
-			instance:    createLSD("ollama", ""),
-			expectError: false,
+			name:             "catalog default when unset",
+			instance:         createLSD("ollama", ""),
+			expectedReplicas: 3,
 		},
 		{
-			name:        "valid direct image",
-			instance:    createLSD("", "test-image:latest"),
-			expectError: false,
+			name: "explicit value wins over catalog default",
+			instance: func() *llamav1alpha1.LlamaStackDistribution {
+				instance := createLSD("ollama", "")
+				instance.Spec.Replicas = ptr.To(int32(0))
+				return instance
+			}(),
+			expectedReplicas: 0,
 		},
 		{
-			name:        "invalid distribution name",
-			instance:    createLSD("invalid-name", ""),
-			expectError: true,
+			name:             "fallback without catalog default",
+			instance:         createLSD("starter", ""),
+			expectedReplicas: 1,
+		},
+		{
+			name:             "fallback for custom image",
+			instance:         createLSD("", "test-image:latest"),
+			expectedReplicas: 1,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			r := &LlamaStackDistributionReconciler{ClusterInfo: clusterInfo}
-			err := r.validateDistribution(tc.instance)
-			if tc.expectError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
+			assert.Equal(t, tc.expectedReplicas, r.getReplicas(tc.instance))
 		})
 	}
 }
 
-func TestDistributionWithoutClusterInfo(t *testing.T) {
-	// Clear cluster info
+func TestBuildDeploymentUsesCatalogReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	clusterInfo := setupTestClusterInfo(nil)
+	clusterInfo.DistributionReplicas = map[string]int32{"ollama": 3}
+	r := &LlamaStackDistributionReconciler{
+		Client:      fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:      scheme,
+		ClusterInfo: clusterInfo,
+	}
 	instance := createLSD("ollama", "")
-	r := &LlamaStackDistributionReconciler{ClusterInfo: nil}
-	err := r.validateDistribution(instance)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to initialize cluster info")
+	instance.Name = "test"
+
+	deployment, err := r.buildDeployment(context.Background(), instance, "ollama-image:latest")
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(3)), deployment.Spec.Replicas, "the catalog default applies without spec.replicas")
+
+	instance.Spec.Replicas = ptr.To(int32(2))
+	deployment, err = r.buildDeployment(context.Background(), instance, "ollama-image:latest")
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(2)), deployment.Spec.Replicas, "spec.replicas wins over the catalog default")
+
+	instance.Spec.Replicas = nil
+	instance.Spec.Server.Autoscaling = &llamav1alpha1.AutoscalingSpec{MaxReplicas: 5}
+	deployment, err = r.buildDeployment(context.Background(), instance, "ollama-image:latest")
+	require.NoError(t, err)
+	assert.Nil(t, deployment.Spec.Replicas, "the HPA owns the replicas")
 }
 
-func TestPodOverridesWithServiceAccount(t *testing.T) {
-	// Create a test instance with ServiceAccount override
-	instance := &llamav1alpha1.LlamaStackDistribution{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-instance",
-			Namespace: "test-namespace",
-		},
-		Spec: llamav1alpha1.LlamaStackDistributionSpec{
-			Server: llamav1alpha1.ServerSpec{
-				PodOverrides: &llamav1alpha1.PodOverrides{
-					ServiceAccountName: "custom-sa",
-				},
-			},
-		},
+func TestGetStartupProbeFailureThreshold(t *testing.T) {
+	clusterInfo := setupTestClusterInfo(map[string]string{
+		"ollama":   "ollama-image:latest",
+		"vllm-gpu": "vllm-image:latest",
+		"starter":  "starter-image:latest",
+	})
+	clusterInfo.DistributionStartupSeconds = map[string]int32{"vllm-gpu": 300, "ollama": 5}
+	r := &LlamaStackDistributionReconciler{ClusterInfo: clusterInfo}
+
+	// The warm-up hint extends the startup budget, the readiness probe keeps its defaults
+	instance := createLSD("vllm-gpu", "")
+	assert.Equal(t, int32(startupProbeFailureThreshold+30), r.getStartupProbe(instance).FailureThreshold)
+	assert.Equal(t, int32(readinessProbeInitialDelaySeconds), getReadinessProbe(instance).InitialDelaySeconds)
+	// A partial period is rounded up
+	assert.Equal(t, int32(startupProbeFailureThreshold+1), r.getStartupProbeFailureThreshold(createLSD("ollama", "")))
+	assert.Equal(t, int32(startupProbeFailureThreshold), r.getStartupProbeFailureThreshold(createLSD("starter", "")))
+	assert.Equal(t, int32(startupProbeFailureThreshold), r.getStartupProbeFailureThreshold(createLSD("", "test-image:latest")))
+}
+
+func TestContainerProbes(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.ContainerSpec.Port = 9000
+
+	container := buildContainerSpec(context.Background(), nil, instance, "test-image:latest")
+	require.NotNil(t, container.LivenessProbe)
+	require.NotNil(t, container.StartupProbe)
+	assert.Equal(t, newDefaultReadinessProbe(9000), container.ReadinessProbe)
+	assert.Equal(t, "/v1/health", container.StartupProbe.HTTPGet.Path)
+	assert.Equal(t, intstr.FromInt(9000), container.StartupProbe.HTTPGet.Port)
+	assert.Equal(t, int32(startupProbeFailureThreshold), container.StartupProbe.FailureThreshold)
+	assert.Equal(t, "/v1/health", container.LivenessProbe.HTTPGet.Path)
+
+	// Custom probes replace the defaults, the others keep their default
+	custom := &corev1.Probe{
+		ProbeHandler:     corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(9000)}},
+		FailureThreshold: 120,
 	}
+	instance.Spec.Server.Probes = &llamav1alpha1.ProbesSpec{StartupProbe: custom}
+	container = buildContainerSpec(context.Background(), nil, instance, "test-image:latest")
+	assert.Equal(t, custom, container.StartupProbe)
+	assert.Equal(t, newDefaultReadinessProbe(9000), container.ReadinessProbe)
+	require.NotNil(t, container.LivenessProbe)
+	assert.NotNil(t, container.LivenessProbe.HTTPGet)
 
-	// Create deployment
-	deployment := &appsv1.Deployment{
-		Spec: appsv1.DeploymentSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name: "test-container",
-						},
-					},
-				},
-			},
-		},
+	// The default probes check the health endpoint with the scheme the server is served with
+	instance.Spec.Server.Probes = nil
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Scheme: corev1.URISchemeHTTPS}
+	container = buildContainerSpec(context.Background(), nil, instance, "test-image:latest")
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+		require.NotNil(t, probe.HTTPGet)
+		assert.Equal(t, corev1.URISchemeHTTPS, probe.HTTPGet.Scheme)
 	}
 
-	// Apply pod overrides
-	configurePodOverrides(instance, &deployment.Spec.Template.Spec)
+	// The default probes check the first configured health endpoint
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Endpoints: []string{"/healthz", "/readyz"}}
+	container = buildContainerSpec(context.Background(), nil, instance, "test-image:latest")
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+		require.NotNil(t, probe.HTTPGet)
+		assert.Equal(t, "/healthz", probe.HTTPGet.Path)
+		assert.Equal(t, intstr.FromInt(9000), probe.HTTPGet.Port)
+	}
 
-	// Verify ServiceAccount name
-	if deployment.Spec.Template.Spec.ServiceAccountName != "custom-sa" {
-		t.Errorf("expected ServiceAccountName to be 'custom-sa', got %s", deployment.Spec.Template.Spec.ServiceAccountName)
+	// A separate health port is checked by the default probes too
+	instance.Spec.Server.ContainerSpec.Ports = []llamav1alpha1.PortSpec{
+		{Name: "admin", Port: 9001, Exposure: llamav1alpha1.PortExposureInternal},
+	}
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Endpoints: []string{"/admin/health"}, Port: ptr.To[int32](9001)}
+	container = buildContainerSpec(context.Background(), nil, instance, "test-image:latest")
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+		require.NotNil(t, probe.HTTPGet)
+		assert.Equal(t, "/admin/health", probe.HTTPGet.Path)
+		assert.Equal(t, intstr.FromInt(9001), probe.HTTPGet.Port)
 	}
 }
 
-func TestPodOverridesWithoutServiceAccount(t *testing.T) {
-	// Create a test instance without ServiceAccount override
-	instance := &llamav1alpha1.LlamaStackDistribution{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-instance",
-			Namespace: "test-namespace",
+func TestResolveImageWithRegistryMirror(t *testing.T) {
+	clusterInfo := setupTestClusterInfo(map[string]string{
+		"starter": "docker.io/llamastack/distribution-starter:latest",
+	})
+	mirror := &registry.MirrorConfig{
+		Mirror: "registry.internal.example.com/mirror",
+		Rules:  []registry.MirrorRule{{Source: "quay.io", Mirror: "registry.internal.example.com/quay"}},
+	}
+
+	testCases := []struct {
+		name          string
+		instance      *llamav1alpha1.LlamaStackDistribution
+		expectedImage string
+	}{
+		{
+			name:          "catalog image uses default mirror",
+			instance:      createLSD("starter", ""),
+			expectedImage: "registry.internal.example.com/mirror/llamastack/distribution-starter:latest",
 		},
-		Spec: llamav1alpha1.LlamaStackDistributionSpec{
-			Server: llamav1alpha1.ServerSpec{
-				PodOverrides: &llamav1alpha1.PodOverrides{},
-			},
+		{
+			name:          "custom image uses matching rule",
+			instance:      createLSD("", "quay.io/org/custom:1.0"),
+			expectedImage: "registry.internal.example.com/quay/org/custom:1.0",
 		},
 	}
 
-	// Create deployment
-	deployment := &appsv1.Deployment{
-		Spec: appsv1.DeploymentSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name: "test-container",
-						},
-					},
-				},
-			},
-		},
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &LlamaStackDistributionReconciler{ClusterInfo: clusterInfo, ImageRegistryMirror: mirror}
+			image, err := r.resolveImage(tc.instance.Spec.Server.Distribution)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedImage, image)
+		})
 	}
+}
 
-	// Apply pod overrides
-	configurePodOverrides(instance, &deployment.Spec.Template.Spec)
+func TestParseImageRegistryMirror(t *testing.T) {
+	mirror, err := parseImageRegistryMirror(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, mirror, "mirror should be nil when not configured")
 
-	// Verify ServiceAccount name is empty (default ServiceAccountName should be set when not explicitly provided)
-	if deployment.Spec.Template.Spec.ServiceAccountName != instance.Name+"-sa" {
-		t.Errorf("expected default ServiceAccountName when not explicitly provided, got %s", deployment.Spec.Template.Spec.ServiceAccountName)
-	}
+	mirror, err = parseImageRegistryMirror(map[string]string{
+		registry.MirrorConfigKey: "mirror: registry.internal.example.com\nrules:\n- source: quay.io\n  mirror: registry.internal.example.com/quay\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &registry.MirrorConfig{
+		Mirror: "registry.internal.example.com",
+		Rules:  []registry.MirrorRule{{Source: "quay.io", Mirror: "registry.internal.example.com/quay"}},
+	}, mirror)
+
+	_, err = parseImageRegistryMirror(map[string]string{registry.MirrorConfigKey: "mirror: [invalid"})
+	require.Error(t, err)
 }
 
-func TestValidateConfigMapKeys(t *testing.T) {
+func TestImageAllowlist(t *testing.T) {
+	allowlist, err := parseImageAllowlist(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, allowlist, "allowlist should be nil when not configured")
+
+	_, err = parseImageAllowlist(map[string]string{registry.AllowlistConfigKey: "registries: []\n"})
+	require.Error(t, err, "an empty allowlist should be rejected")
+	_, err = parseImageAllowlist(map[string]string{registry.AllowlistConfigKey: "registries: [invalid"})
+	require.Error(t, err)
+
+	allowlist, err = parseImageAllowlist(map[string]string{
+		registry.AllowlistConfigKey: "registries:\n- registry.internal.example.com\n",
+	})
+	require.NoError(t, err)
+	r := &LlamaStackDistributionReconciler{
+		ClusterInfo:    setupTestClusterInfo(map[string]string{"ollama": "docker.io/lls/lls-ollama:1.0"}),
+		ImageAllowlist: allowlist,
+	}
+	r.ClusterInfo.ImageVolumesSupported = true
+
+	require.NoError(t, r.validateDistribution(createLSD("ollama", "")), "catalog images are always allowed")
+	require.NoError(t, r.validateDistribution(createLSD("", "registry.internal.example.com/lls:1.0")))
+	err = r.validateDistribution(createLSD("", "docker.io/lls/lls-ollama:1.0"))
+	require.ErrorContains(t, err, "the image is not in the image allowlist")
+
+	// The other images pulled for the instance are checked too, including with a catalog distribution
 	tests := []struct {
-		name        string
-		keys        []string
-		expectError bool
-		errorMsg    string
+		name     string
+		setImage func(instance *llamav1alpha1.LlamaStackDistribution, image string)
+		source   string
 	}{
 		{
-			name:        "valid keys",
-			keys:        []string{DefaultCABundleKey, "intermediate.pem", "root-ca.cert"},
-			expectError: false,
+			name: "init container",
+			setImage: func(instance *llamav1alpha1.LlamaStackDistribution, image string) {
+				instance.Spec.Server.InitContainers = []corev1.Container{{Name: "download", Image: image}}
+			},
+			source: "init container download",
 		},
 		{
-			name:        "empty key",
-			keys:        []string{""},
-			expectError: true,
-			errorMsg:    "ConfigMap key cannot be empty",
+			name: "sidecar container",
+			setImage: func(instance *llamav1alpha1.LlamaStackDistribution, image string) {
+				instance.Spec.Server.SidecarContainers = []corev1.Container{{Name: "log-shipper", Image: image}}
+			},
+			source: "sidecar container log-shipper",
 		},
 		{
-			name:        "command injection attempt",
-			keys:        []string{"valid-key; rm -rf /; echo malicious"},
-			expectError: true,
-			errorMsg:    "contains invalid characters",
+			name: "pre-start Job",
+			setImage: func(instance *llamav1alpha1.LlamaStackDistribution, image string) {
+				instance.Spec.Server.PreStartJob = &llamav1alpha1.PreStartJobSpec{Image: image}
+			},
+			source: "pre-start Job",
 		},
 		{
-			name:        "path traversal attempt",
-			keys:        []string{"../../../etc/passwd"},
-			expectError: true,
+			name: "image volume",
+			setImage: func(instance *llamav1alpha1.LlamaStackDistribution, image string) {
+				instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{ImageVolumes: []llamav1alpha1.ImageVolumeSpec{
+					{Name: "weights", Reference: image, MountPath: "/models"},
+				}}
+			},
+			source: "image volume weights",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := createLSD("ollama", "")
+			tc.setImage(instance, "registry.internal.example.com/tools:1.0")
+			require.NoError(t, r.validateDistribution(instance))
+
+			tc.setImage(instance, "docker.io/library/busybox:1.36")
+			err := r.validateDistribution(instance)
+			require.ErrorContains(t, err, "failed to validate image docker.io/library/busybox:1.36 of the "+tc.source)
+			require.ErrorContains(t, err, "the image is not in the image allowlist")
+		})
+	}
+
+	// A pre-start Job without an image runs the allowed server image
+	instance := createLSD("", "registry.internal.example.com/lls:1.0")
+	instance.Spec.Server.PreStartJob = &llamav1alpha1.PreStartJobSpec{}
+	require.NoError(t, r.validateDistribution(instance))
+}
+
+func TestAddImageVolumes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{Scheme: scheme}
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{ImageVolumes: []llamav1alpha1.ImageVolumeSpec{
+		{Name: "models", Reference: "quay.io/example/granite-weights:1.0", MountPath: "/models", PullPolicy: corev1.PullIfNotPresent},
+		{Name: "variants", Reference: "quay.io/example/granite-variants:1.0", MountPath: "/variant", SubPath: "granite-8b"},
+	}}
+	deployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: llamav1alpha1.DefaultContainerName, VolumeMounts: []corev1.VolumeMount{{Name: "lls-storage", MountPath: "/.llama"}}},
+				{Name: "sidecar"},
+			},
+			Volumes: []corev1.Volume{{Name: "lls-storage", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+	}}}
+	template := &deployment.Spec.Template
+	require.NoError(t, addImageVolumes(instance, &template.Spec, template.Annotations))
+	assert.True(t, hasImageVolumes(template.Annotations))
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "lls-storage", MountPath: "/.llama"},
+		{Name: "models", MountPath: "/models", ReadOnly: true},
+		{Name: "variants", MountPath: "/variant", ReadOnly: true, SubPath: "granite-8b"},
+	}, template.Spec.Containers[0].VolumeMounts)
+	assert.Empty(t, template.Spec.Containers[1].VolumeMounts, "only the server container should mount the image volumes")
+
+	// The image source is only set on the applied object
+	u, err := r.toDesiredWithImageVolumes(deployment, "spec", "template")
+	require.NoError(t, err)
+	volumes, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "volumes")
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"name": "lls-storage", "emptyDir": map[string]any{}},
+		map[string]any{"name": "models", "image": map[string]any{"reference": "quay.io/example/granite-weights:1.0", "pullPolicy": "IfNotPresent"}},
+		map[string]any{"name": "variants", "image": map[string]any{"reference": "quay.io/example/granite-variants:1.0"}},
+	}, volumes)
+
+	// Pod templates without image volumes are left as they are
+	instance.Spec.Server.Storage = nil
+	podSpec := &corev1.PodSpec{}
+	annotations := map[string]string{}
+	require.NoError(t, addImageVolumes(instance, podSpec, annotations))
+	assert.Empty(t, podSpec.Volumes)
+	assert.False(t, hasImageVolumes(annotations))
+}
+
+func TestValidateSubPaths(t *testing.T) {
+	tests := []struct {
+		subPath     string
+		expectError bool
+	}{
+		{subPath: ""},
+		{subPath: "models/granite-8b"},
+		{subPath: "models/granite..8b"},
+		{subPath: "models/../granite-8b", expectError: true},
+		{subPath: "/models", expectError: true},
+		{subPath: "..", expectError: true},
+		{subPath: "models/../../etc", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.subPath, func(t *testing.T) {
+			for _, storage := range []*llamav1alpha1.StorageSpec{
+				{SubPath: tc.subPath},
+				{ImageVolumes: []llamav1alpha1.ImageVolumeSpec{{Name: "models", SubPath: tc.subPath}}},
+			} {
+				instance := &llamav1alpha1.LlamaStackDistribution{}
+				instance.Spec.Server.Storage = storage
+				err := validateSubPaths(instance)
+				if tc.expectError {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveServerImageRequiresImageVolumeSupport(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{ClusterInfo: setupTestClusterInfo(nil)}
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	instance.Spec.Server.Distribution.Name = "ollama"
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{
+		ImageVolumes: []llamav1alpha1.ImageVolumeSpec{{Name: "models", Reference: "quay.io/example/weights:1.0", MountPath: "/models"}},
+	}
+
+	_, err := r.resolveServerImage(instance)
+	require.ErrorContains(t, err, "does not support image volumes")
+	assert.True(t, isInvalidSpec(err), "missing image volume support is reported as an invalid spec")
+
+	r.ClusterInfo.ImageVolumesSupported = true
+	_, err = r.resolveServerImage(instance)
+	require.NoError(t, err)
+}
+
+func TestResolveServerImageInvalidSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{
+		Client:      fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:      scheme,
+		ClusterInfo: setupTestClusterInfo(nil),
+		Recorder:    recorder,
+	}
+	instance := createLSD("unknown", "")
+	instance.Name = "test"
+
+	_, err := r.resolveServerImage(instance)
+	require.ErrorContains(t, err, "Distribution name not supported")
+	assert.True(t, isInvalidSpec(err), "validation errors are reported as an invalid spec")
+	assert.Empty(t, instance.Status.Conditions, "the reconcile loop reports validation errors")
+	assert.Empty(t, recorder.Events)
+
+	r.reportInvalidSpec(instance, err)
+	condition := GetCondition(&instance.Status, ConditionTypeSpecValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonInvalidSpec, condition.Reason)
+	assert.Equal(t, err.Error(), condition.Message)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning ValidationFailed")
+
+	otherErr := fmt.Errorf("failed to apply manifests: %w", errors.New("conflict"))
+	assert.False(t, isInvalidSpec(otherErr))
+	r.reportInvalidSpec(instance, otherErr)
+	assert.Empty(t, recorder.Events, "other errors are not reported as validation failures")
+
+	instance.Spec.Server.Distribution.Name = "ollama"
+	image, err := r.resolveServerImage(instance)
+	require.NoError(t, err)
+	assert.Equal(t, "ollama-image:latest", image)
+}
+
+func TestReportPodTemplate(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	template := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "llama-sa",
+			NodeSelector:       map[string]string{"gpu": "true"},
+			Containers: []corev1.Container{
+				{
+					Name:  llamav1alpha1.DefaultContainerName,
+					Image: "test-image:latest",
+					Env:   []corev1.EnvVar{{Name: "HF_HOME", Value: "/.llama"}, {Name: "API_KEY", Value: "secret"}},
+				},
+				{Name: "sidecar", Image: "sidecar:latest", Env: []corev1.EnvVar{{Name: "SIDECAR"}}},
+			},
+			Volumes: []corev1.Volume{{Name: "lls-storage"}},
+		},
+	}
+
+	require.NoError(t, reportPodTemplate(instance, template))
+	assert.Nil(t, instance.Status.PodTemplate, "the summary should only be reported on request")
+
+	instance.Spec.Server.ReportPodTemplate = true
+	require.NoError(t, reportPodTemplate(instance, template))
+	summary := instance.Status.PodTemplate
+	require.NotNil(t, summary)
+	assert.NotEmpty(t, summary.Hash)
+	assert.Equal(t, "test-image:latest", summary.Image)
+	assert.Equal(t, []string{"HF_HOME", "API_KEY"}, summary.EnvNames)
+	assert.Equal(t, []string{"lls-storage"}, summary.Volumes)
+	assert.Equal(t, "llama-sa", summary.ServiceAccountName)
+	assert.Equal(t, map[string]string{"gpu": "true"}, summary.NodeSelector)
+
+	previousHash := summary.Hash
+	template.Spec.NodeSelector = nil
+	require.NoError(t, reportPodTemplate(instance, template))
+	assert.NotEqual(t, previousHash, instance.Status.PodTemplate.Hash, "the hash should follow the template")
+
+	// The image volumes added to the template are reported with its volumes, and their reference with the hash
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{
+		ImageVolumes: []llamav1alpha1.ImageVolumeSpec{{Name: "granite-weights", Reference: "quay.io/models/granite:8b", MountPath: "/models"}},
+	}
+	withImageVolumes := template.DeepCopy()
+	withImageVolumes.Annotations = map[string]string{}
+	require.NoError(t, addImageVolumes(instance, &withImageVolumes.Spec, withImageVolumes.Annotations))
+	require.NoError(t, reportPodTemplate(instance, withImageVolumes))
+	assert.Equal(t, []string{"lls-storage", "granite-weights"}, instance.Status.PodTemplate.Volumes)
+	previousHash = instance.Status.PodTemplate.Hash
+
+	instance.Spec.Server.Storage.ImageVolumes[0].Reference = "quay.io/models/granite:8b-v2"
+	withImageVolumes = template.DeepCopy()
+	withImageVolumes.Annotations = map[string]string{}
+	require.NoError(t, addImageVolumes(instance, &withImageVolumes.Spec, withImageVolumes.Annotations))
+	require.NoError(t, reportPodTemplate(instance, withImageVolumes))
+	assert.NotEqual(t, previousHash, instance.Status.PodTemplate.Hash, "the hash should follow the image volume references")
+}
+
+func TestSetSafeToEvictAnnotation(t *testing.T) {
+	tests := []struct {
+		name         string
+		podOverrides *llamav1alpha1.PodOverrides
+		expected     map[string]string
+	}{
+		{
+			name:     "no pod overrides",
+			expected: map[string]string{},
+		},
+		{
+			name:         "unset",
+			podOverrides: &llamav1alpha1.PodOverrides{},
+			expected:     map[string]string{},
+		},
+		{
+			name:         "protected from eviction",
+			podOverrides: &llamav1alpha1.PodOverrides{SafeToEvict: ptr.To(false)},
+			expected:     map[string]string{safeToEvictAnnotation: "false"},
+		},
+		{
+			name:         "safe to evict",
+			podOverrides: &llamav1alpha1.PodOverrides{SafeToEvict: ptr.To(true)},
+			expected:     map[string]string{safeToEvictAnnotation: "true"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &llamav1alpha1.LlamaStackDistribution{}
+			instance.Spec.Server.PodOverrides = tc.podOverrides
+			annotations := map[string]string{}
+			setSafeToEvictAnnotation(instance, annotations)
+			assert.Equal(t, tc.expected, annotations)
+		})
+	}
+}
+
+func TestIsDeploymentScalingDown(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   appsv1.DeploymentStatus
+		expected bool
+	}{
+		{
+			name:     "stable",
+			status:   appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+			expected: false,
+		},
+		{
+			name:     "more ready replicas than desired",
+			status:   appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3},
+			expected: true,
+		},
+		{
+			name:     "extra replicas not ready anymore",
+			status:   appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 1},
+			expected: true,
+		},
+		{
+			name:     "surge replica of a rollout",
+			status:   appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 1},
+			expected: false,
+		},
+		{
+			name:     "ready surge replica of a rollout",
+			status:   appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 2},
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isDeploymentScalingDown(&appsv1.Deployment{Status: tc.status}, 1))
+		})
+	}
+}
+
+func TestParseNetworkPolicyConfig(t *testing.T) {
+	config, err := parseNetworkPolicyConfig(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, config, "config should be nil when not configured")
+	assert.Equal(t, map[string]string{deploy.DefaultNamespaceLabelKey: "operator-ns"},
+		config.OperatorNamespaceSelector("operator-ns"), "nil config should select the operator namespace by name")
+
+	config, err = parseNetworkPolicyConfig(map[string]string{
+		deploy.NetworkPolicyConfigKey: "operatorNamespaceLabelKey: team\noperatorNamespaceLabelValue: llama-operator\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "llama-operator"}, config.OperatorNamespaceSelector("operator-ns"))
+
+	config, err = parseNetworkPolicyConfig(map[string]string{
+		deploy.NetworkPolicyConfigKey: "operatorNamespaceLabelKey: name\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "operator-ns"}, config.OperatorNamespaceSelector("operator-ns"),
+		"value should default to the operator namespace name")
+
+	_, err = parseNetworkPolicyConfig(map[string]string{deploy.NetworkPolicyConfigKey: "operatorNamespaceLabelKey: [invalid"})
+	require.Error(t, err)
+}
+
+func TestSetLogCollectionAnnotations(t *testing.T) {
+	config, err := parseLogCollectionConfig(map[string]string{
+		deploy.LogCollectionConfigKey: "annotations:\n  fluentbit.io/parser-{container}: llama-stack\n  collector.example.com/multiline: python\n",
+	})
+	require.NoError(t, err)
+	r := &LlamaStackDistributionReconciler{LogCollectionConfig: config}
+
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	annotations := map[string]string{}
+	r.setLogCollectionAnnotations(context.Background(), instance, annotations)
+	assert.Empty(t, annotations, "log collection should be opt-in")
+
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{LogCollection: true}
+	r.setLogCollectionAnnotations(context.Background(), instance, annotations)
+	assert.Equal(t, map[string]string{
+		"fluentbit.io/parser-llama-stack": "llama-stack",
+		"collector.example.com/multiline": "python",
+	}, annotations)
+
+	// Enabled without operator config sets nothing
+	annotations = map[string]string{}
+	(&LlamaStackDistributionReconciler{}).setLogCollectionAnnotations(context.Background(), instance, annotations)
+	assert.Empty(t, annotations)
+
+	config, err = parseLogCollectionConfig(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, err = parseLogCollectionConfig(map[string]string{deploy.LogCollectionConfigKey: "annotations: [invalid"})
+	require.Error(t, err)
+}
+
+func TestDistributionValidation(t *testing.T) {
+	// Setup test cluster info
+	clusterInfo := setupTestClusterInfo(map[string]string{
+		"ollama": "lls/lls-ollama:1.0",
+	})
+
+	testCases := []struct {
+		name        string
+		instance    *llamav1alpha1.LlamaStackDistribution
+		expectError bool
+	}{
+		{
+			name:        "valid distribution name",
+			instance:    createLSD("ollama", ""),
+			expectError: false,
+		},
+		{
+			name:        "valid direct image",
+			instance:    createLSD("", "test-image:latest"),
+			expectError: false,
+		},
+		{
+			name:        "invalid distribution name",
+			instance:    createLSD("invalid-name", ""),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &LlamaStackDistributionReconciler{ClusterInfo: clusterInfo}
+			err := r.validateDistribution(tc.instance)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	require.NoError(t, validatePorts(instance))
+	reportPortsValid(instance)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypePortsValid))
+
+	instance.Spec.Server.ContainerSpec.Port = 70000
+	require.Error(t, validatePorts(instance))
+	reportPortsValid(instance)
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypePortsValid))
+}
+
+func TestCheckPrivilegedPorts(t *testing.T) {
+	tests := []struct {
+		name            string
+		port            int32
+		securityContext *corev1.SecurityContext
+		expectWarning   bool
+	}{
+		{
+			name: "unprivileged port",
+			port: 8321,
+		},
+		{
+			name:          "privileged port without capability",
+			port:          80,
+			expectWarning: true,
+		},
+		{
+			name: "privileged port with NET_BIND_SERVICE",
+			port: 80,
+			securityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+			},
+		},
+		{
+			name:            "privileged port in a privileged container",
+			port:            80,
+			securityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := createLSD("", "test-image:latest")
+			SetPortsValidCondition(instance, true, MessagePortsValid)
+			container := &corev1.Container{
+				Ports:           []corev1.ContainerPort{{ContainerPort: tc.port}},
+				SecurityContext: tc.securityContext,
+			}
+
+			checkPrivilegedPorts(context.Background(), instance, container)
+
+			assert.Equal(t, tc.expectWarning, IsConditionFalse(&instance.Status, ConditionTypePortsValid))
+		})
+	}
+}
+
+func TestDistributionWithoutClusterInfo(t *testing.T) {
+	// Clear cluster info
+	instance := createLSD("ollama", "")
+	r := &LlamaStackDistributionReconciler{ClusterInfo: nil}
+	err := r.validateDistribution(instance)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to initialize cluster info")
+}
+
+func TestPodOverridesWithServiceAccount(t *testing.T) {
+	// Create a test instance with ServiceAccount override
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: "test-namespace",
+		},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				PodOverrides: &llamav1alpha1.PodOverrides{
+					ServiceAccountName: "custom-sa",
+				},
+			},
+		},
+	}
+
+	// Create deployment
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "test-container",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Apply pod overrides
+	configurePodOverrides(instance, &deployment.Spec.Template.Spec)
+
+	// Verify ServiceAccount name
+	if deployment.Spec.Template.Spec.ServiceAccountName != "custom-sa" {
+		t.Errorf("expected ServiceAccountName to be 'custom-sa', got %s", deployment.Spec.Template.Spec.ServiceAccountName)
+	}
+}
+
+func TestPodOverridesWithoutServiceAccount(t *testing.T) {
+	// Create a test instance without ServiceAccount override
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: "test-namespace",
+		},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				PodOverrides: &llamav1alpha1.PodOverrides{},
+			},
+		},
+	}
+
+	// Create deployment
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "test-container",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Apply pod overrides
+	configurePodOverrides(instance, &deployment.Spec.Template.Spec)
+
+	// Verify ServiceAccount name is empty (default ServiceAccountName should be set when not explicitly provided)
+	if deployment.Spec.Template.Spec.ServiceAccountName != instance.Name+"-sa" {
+		t.Errorf("expected default ServiceAccountName when not explicitly provided, got %s", deployment.Spec.Template.Spec.ServiceAccountName)
+	}
+	assert.False(t, deployment.Spec.Template.Spec.HostNetwork, "host network should be disabled by default")
+}
+
+func TestPodOverridesWithHostNetwork(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-namespace"},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				PodOverrides: &llamav1alpha1.PodOverrides{HostNetwork: true},
+			},
+		},
+	}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}}
+
+	configurePodOverrides(instance, podSpec)
+
+	assert.True(t, podSpec.HostNetwork)
+	assert.Equal(t, corev1.DNSClusterFirstWithHostNet, podSpec.DNSPolicy, "pods on the host network should keep resolving cluster Services")
+}
+
+func TestConfigurePodSchedulingOverrides(t *testing.T) {
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "nvidia.com/gpu.product",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"NVIDIA-A100-SXM4-80GB"},
+					}},
+				}},
+			},
+		},
+	}
+	tolerations := []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-namespace"},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				PodOverrides: &llamav1alpha1.PodOverrides{
+					NodeSelector: map[string]string{"node-role.kubernetes.io/gpu": ""},
+					Affinity:     affinity,
+					Tolerations:  tolerations,
+				},
+			},
+		},
+	}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}}
+
+	configurePodScheduling(instance, podSpec)
+
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/gpu": ""}, podSpec.NodeSelector)
+	assert.Equal(t, affinity, podSpec.Affinity)
+	assert.NotSame(t, affinity, podSpec.Affinity, "the pod spec must not share the spec of the instance")
+	assert.Equal(t, tolerations, podSpec.Tolerations)
+
+	// Unset overrides must leave the fields nil, so that the pod template hash stays the same
+	unset := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}}
+	configurePodScheduling(&llamav1alpha1.LlamaStackDistribution{
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{Server: llamav1alpha1.ServerSpec{PodOverrides: &llamav1alpha1.PodOverrides{}}},
+	}, unset)
+	assert.Nil(t, unset.NodeSelector)
+	assert.Nil(t, unset.Affinity)
+	assert.Nil(t, unset.Tolerations)
+}
+
+func TestCheckPriorityClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	priorityClass := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "inference-critical"}, Value: 1000000}
+	recorder := record.NewFakeRecorder(1)
+	r := &LlamaStackDistributionReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(priorityClass).Build(),
+		Recorder: recorder,
+	}
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-namespace"},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				PodOverrides: &llamav1alpha1.PodOverrides{PriorityClassName: "inference-critical"},
+			},
+		},
+	}
+
+	podSpec := &corev1.PodSpec{}
+	configurePodScheduling(instance, podSpec)
+	assert.Equal(t, "inference-critical", podSpec.PriorityClassName)
+	r.checkPriorityClass(context.Background(), instance)
+	assert.Empty(t, recorder.Events)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypePriorityClassAvailable))
+
+	// A missing PriorityClass is reported without failing the reconcile
+	instance.Spec.Server.PodOverrides.PriorityClassName = "missing"
+	r.checkPriorityClass(context.Background(), instance)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning PriorityClassNotFound PriorityClass missing not found, the server pods can't be created until it exists",
+		<-recorder.Events)
+	condition := GetCondition(&instance.Status, ConditionTypePriorityClassAvailable)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonPriorityClassNotFound, condition.Reason)
+
+	// The following reconciles report it through the condition only
+	r.checkPriorityClass(context.Background(), instance)
+	assert.Empty(t, recorder.Events)
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypePriorityClassAvailable))
+
+	// Building the Deployment, e.g. to render the desired manifests, doesn't check it
+	r.Scheme = scheme
+	r.ClusterInfo = setupTestClusterInfo(nil)
+	_, err := r.buildDeployment(context.Background(), instance, "ollama-image:latest")
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestConfigureTopologySpread(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-namespace"},
+	}
+
+	// The pods aren't spread unless asked to
+	podSpec := &corev1.PodSpec{}
+	configureTopologySpread(instance, podSpec, 3)
+	assert.Nil(t, podSpec.TopologySpreadConstraints)
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{}
+	configureTopologySpread(instance, podSpec, 3)
+	assert.Nil(t, podSpec.TopologySpreadConstraints)
+
+	// A single replica has nothing to spread
+	instance.Spec.Server.PodOverrides.SpreadAcrossNodes = true
+	configureTopologySpread(instance, podSpec, 1)
+	assert.Nil(t, podSpec.TopologySpreadConstraints)
+
+	// Multiple replicas are spread across the nodes on a best-effort basis
+	configureTopologySpread(instance, podSpec, 3)
+	assert.Equal(t, []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelHostname,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: getPodSelectorLabels(instance)},
+	}}, podSpec.TopologySpreadConstraints)
+
+	// Autoscaled instances are spread when they can scale up
+	instance.Spec.Server.Autoscaling = &llamav1alpha1.AutoscalingSpec{MaxReplicas: 4}
+	podSpec = &corev1.PodSpec{}
+	configureTopologySpread(instance, podSpec, 1)
+	assert.Len(t, podSpec.TopologySpreadConstraints, 1)
+
+	// The constraints of the pod overrides replace the default one
+	zones := []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelTopologyZone,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: getPodSelectorLabels(instance)},
+	}}
+	instance.Spec.Server.PodOverrides.TopologySpreadConstraints = zones
+	podSpec = &corev1.PodSpec{}
+	configureTopologySpread(instance, podSpec, 3)
+	assert.Equal(t, zones, podSpec.TopologySpreadConstraints)
+}
+
+func TestGetDeploymentStrategy(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+
+	// Unset leaves the Deployment default, RollingUpdate
+	assert.Equal(t, appsv1.DeploymentStrategy{}, getDeploymentStrategy(instance))
+
+	instance.Spec.Server.DeploymentStrategy = &llamav1alpha1.DeploymentStrategySpec{Type: appsv1.RecreateDeploymentStrategyType}
+	assert.Equal(t, appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, getDeploymentStrategy(instance))
+
+	rollingUpdate := &appsv1.RollingUpdateDeployment{
+		MaxSurge:       ptr.To(intstr.FromInt32(0)),
+		MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+	}
+	instance.Spec.Server.DeploymentStrategy = &llamav1alpha1.DeploymentStrategySpec{
+		Type:          appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}
+	assert.Equal(t, appsv1.DeploymentStrategy{
+		Type:          appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}, getDeploymentStrategy(instance))
+}
+
+func TestPodOverridesWithImagePullSecrets(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-namespace"},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				PodOverrides: &llamav1alpha1.PodOverrides{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+				},
+			},
+		},
+	}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}}
+
+	configurePodOverrides(instance, podSpec)
+
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry-credentials"}}, podSpec.ImagePullSecrets)
+}
+
+func TestGetImagePullPolicy(t *testing.T) {
+	tests := []struct {
+		image    string
+		policy   corev1.PullPolicy
+		expected corev1.PullPolicy
+	}{
+		{image: "quay.io/llamastack/distribution-starter:latest", expected: corev1.PullAlways},
+		{image: "quay.io/llamastack/distribution-starter", expected: corev1.PullAlways},
+		{image: "localhost:5000/distribution-starter", expected: corev1.PullAlways},
+		{image: "quay.io/llamastack/distribution-starter:0.2.23", expected: corev1.PullIfNotPresent},
+		{image: "localhost:5000/distribution-starter:0.2.23", expected: corev1.PullIfNotPresent},
+		{image: "quay.io/llamastack/distribution-starter@sha256:0123456789abcdef", expected: corev1.PullIfNotPresent},
+		{image: "quay.io/llamastack/distribution-starter:latest", policy: corev1.PullNever, expected: corev1.PullNever},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			instance := &llamav1alpha1.LlamaStackDistribution{
+				Spec: llamav1alpha1.LlamaStackDistributionSpec{
+					Server: llamav1alpha1.ServerSpec{
+						ContainerSpec: llamav1alpha1.ContainerSpec{ImagePullPolicy: tc.policy},
+					},
+				},
+			}
+			assert.Equal(t, tc.expected, getImagePullPolicy(instance, tc.image))
+		})
+	}
+}
+
+func TestValidatePodOverrideVolumes(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-namespace"},
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				Storage: &llamav1alpha1.StorageSpec{},
+				PodOverrides: &llamav1alpha1.PodOverrides{
+					Volumes:      []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "scratch", MountPath: "/scratch"}},
+				},
+			},
+		},
+	}
+	buildPodSpec := func() corev1.PodSpec {
+		container := corev1.Container{Name: getContainerName(instance)}
+		addStorageVolumeMount(instance, &container)
+		podSpec := corev1.PodSpec{Containers: []corev1.Container{container}}
+		configureStorage(instance, &podSpec)
+		configurePodOverrides(instance, &podSpec)
+		return podSpec
+	}
+	podSpec := buildPodSpec()
+	require.NoError(t, validatePodOverrideVolumes(instance, &podSpec))
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "lls-storage", MountPath: llamav1alpha1.DefaultMountPath},
+		"the managed storage mount must be kept")
+
+	instance.Spec.Server.PodOverrides.Volumes = append(instance.Spec.Server.PodOverrides.Volumes, corev1.Volume{Name: "lls-storage"})
+	podSpec = buildPodSpec()
+	require.ErrorContains(t, validatePodOverrideVolumes(instance, &podSpec), `volume "lls-storage"`)
+
+	instance.Spec.Server.PodOverrides.Volumes = instance.Spec.Server.PodOverrides.Volumes[:1]
+	instance.Spec.Server.PodOverrides.VolumeMounts[0].MountPath = llamav1alpha1.DefaultMountPath + "/"
+	podSpec = buildPodSpec()
+	require.ErrorContains(t, validatePodOverrideVolumes(instance, &podSpec), "is already used by another mount")
+}
+
+func TestGetContainerPorts(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				ContainerSpec: llamav1alpha1.ContainerSpec{
+					Ports: []llamav1alpha1.PortSpec{
+						{Name: "metrics", Port: 9090, Exposure: llamav1alpha1.PortExposureInternal},
+						{Name: "grpc", Port: 9000},
+						{Name: "stream", Port: 9500, Exposure: llamav1alpha1.PortExposureInternal, Protocol: corev1.ProtocolUDP},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []corev1.ContainerPort{
+		{ContainerPort: llamav1alpha1.DefaultServerPort},
+		{Name: "metrics", ContainerPort: 9090, Protocol: corev1.ProtocolTCP},
+		{Name: "grpc", ContainerPort: 9000, Protocol: corev1.ProtocolTCP},
+		{Name: "stream", ContainerPort: 9500, Protocol: corev1.ProtocolUDP},
+	}
+	assert.Equal(t, expected, getContainerPorts(instance))
+
+	// ports without an exposure default to Public
+	assert.Equal(t, []llamav1alpha1.PortSpec{{Name: "grpc", Port: 9000}},
+		instance.GetPortsByExposure(llamav1alpha1.PortExposurePublic))
+	assert.Equal(t, []llamav1alpha1.PortSpec{
+		{Name: "metrics", Port: 9090, Exposure: llamav1alpha1.PortExposureInternal},
+		{Name: "stream", Port: 9500, Exposure: llamav1alpha1.PortExposureInternal, Protocol: corev1.ProtocolUDP},
+	}, instance.GetPortsByExposure(llamav1alpha1.PortExposureInternal))
+}
+
+func TestGetThreadCountEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		cpuLimit string
+		env      []corev1.EnvVar
+		expected []corev1.EnvVar
+	}{
+		{
+			name: "no CPU limit",
+		},
+		{
+			name:     "whole CPUs",
+			cpuLimit: "4",
+			expected: []corev1.EnvVar{{Name: "GOMAXPROCS", Value: "4"}, {Name: "OMP_NUM_THREADS", Value: "4"}},
+		},
+		{
+			name:     "fractional CPUs round up",
+			cpuLimit: "1500m",
+			expected: []corev1.EnvVar{{Name: "GOMAXPROCS", Value: "2"}, {Name: "OMP_NUM_THREADS", Value: "2"}},
+		},
+		{
+			name:     "user env takes precedence",
+			cpuLimit: "500m",
+			env:      []corev1.EnvVar{{Name: "OMP_NUM_THREADS", Value: "8"}},
+			expected: []corev1.EnvVar{{Name: "GOMAXPROCS", Value: "1"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &llamav1alpha1.LlamaStackDistribution{}
+			instance.Spec.Server.ContainerSpec.ThreadCountEnv = []string{"GOMAXPROCS", "OMP_NUM_THREADS"}
+			instance.Spec.Server.ContainerSpec.Env = tc.env
+			if tc.cpuLimit != "" {
+				instance.Spec.Server.ContainerSpec.Resources.Limits = corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse(tc.cpuLimit),
+				}
+			}
+
+			assert.Equal(t, tc.expected, getThreadCountEnv(instance))
+		})
+	}
+}
+
+func TestContainerEnv(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.ContainerSpec.Env = []corev1.EnvVar{
+		{Name: "INFERENCE_MODEL", Value: "llama3"},
+		{Name: "HF_HOME", Value: "/data/hf"},
+	}
+	instance.Spec.Server.ContainerSpec.EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "provider-credentials"}}},
+		{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "tuning"}}, Prefix: "LLAMA_"},
+	}
+
+	container := buildContainerSpec(context.Background(), nil, instance, "test-image:latest")
+	// The user value replaces the operator one in place, and every variable is set once
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "HF_HOME", Value: "/data/hf"},
+		{Name: "INFERENCE_MODEL", Value: "llama3"},
+	}, container.Env)
+	assert.Equal(t, instance.Spec.Server.ContainerSpec.EnvFrom, container.EnvFrom)
+}
+
+func TestGetFeatureGates(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	assert.Empty(t, getFeatureGates(instance))
+
+	instance.Spec.Server.FeatureGates = map[string]bool{"vector-io-v2": true, "agents": false, "experimental-ui": true}
+	assert.Equal(t, "agents=false,experimental-ui=true,vector-io-v2=true", getFeatureGates(instance))
+
+	container := buildContainerSpec(context.Background(), nil, instance, "test-image:latest")
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: featureGatesEnvVar, Value: "agents=false,experimental-ui=true,vector-io-v2=true"})
+}
+
+func TestRecommendedLabels(t *testing.T) {
+	t.Setenv("OPERATOR_VERSION", "v0.3.0")
+	instance := createLSD("ollama", "")
+	instance.Name = "test"
+	instance.Spec.Server.ContainerSpec.Ports = []llamav1alpha1.PortSpec{
+		{Name: "metrics", Port: 9090, Exposure: llamav1alpha1.PortExposureInternal},
+	}
+
+	service := buildInternalService(instance)
+	assert.Equal(t, map[string]string{
+		deploy.NameLabelKey:      "ollama",
+		deploy.ComponentLabelKey: deploy.ComponentLabelValue,
+		deploy.PartOfLabelKey:    llamav1alpha1.DefaultLabelValue,
+		deploy.ManagedByLabelKey: deploy.ManagedByLabelValue,
+		deploy.VersionLabelKey:   "v0.3.0",
+		deploy.InstanceLabelKey:  "test",
+	}, service.Labels)
+
+	// The version and part-of labels are left out of the pod labels, and custom images get a generic name
+	podLabels := deploy.GetPodLabels(createLSD("", "test-image:latest"))
+	assert.Equal(t, "custom", podLabels[deploy.NameLabelKey])
+	assert.NotContains(t, podLabels, deploy.VersionLabelKey)
+	assert.NotContains(t, podLabels, deploy.PartOfLabelKey)
+
+	t.Setenv("OPERATOR_VERSION", "not a valid label")
+	assert.NotContains(t, deploy.GetRecommendedLabels(instance), deploy.VersionLabelKey)
+}
+
+func TestCustomLabelsAndAnnotations(t *testing.T) {
+	instance := createLSD("ollama", "")
+	instance.Name = "test"
+	instance.Spec.Labels = map[string]string{"cost-center": "ml", deploy.InstanceLabelKey: "other"}
+	instance.Spec.Annotations = map[string]string{"sidecar.istio.io/inject": "true", safeToEvictAnnotation: "true"}
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{SafeToEvict: ptr.To(false)}
+	instance.Spec.Server.ContainerSpec.Ports = []llamav1alpha1.PortSpec{
+		{Name: "metrics", Port: 9090, Exposure: llamav1alpha1.PortExposureInternal},
+	}
+
+	service := buildInternalService(instance)
+	assert.Equal(t, "ml", service.Labels["cost-center"])
+	assert.Equal(t, "test", service.Labels[deploy.InstanceLabelKey], "the custom labels can't override the operator ones")
+	assert.Equal(t, instance.Spec.Annotations, service.Annotations)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{
+		Client:      fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:      scheme,
+		ClusterInfo: setupTestClusterInfo(nil),
+	}
+	deployment, err := r.buildDeployment(context.Background(), instance, "ollama-image:latest")
+	require.NoError(t, err)
+	assert.Equal(t, "ml", deployment.Labels["cost-center"])
+	assert.Equal(t, "test", deployment.Spec.Selector.MatchLabels[deploy.InstanceLabelKey])
+	assert.Equal(t, "ml", deployment.Spec.Template.Labels["cost-center"])
+	assert.Equal(t, "test", deployment.Spec.Template.Labels[deploy.InstanceLabelKey])
+	assert.Equal(t, "true", deployment.Spec.Template.Annotations["sidecar.istio.io/inject"])
+	assert.Equal(t, "false", deployment.Spec.Template.Annotations[safeToEvictAnnotation], "the operator annotations win")
+
+	// The merge is stable across reconciles
+	again, err := r.buildDeployment(context.Background(), instance, "ollama-image:latest")
+	require.NoError(t, err)
+	assert.Equal(t, deployment, again)
+}
+
+func TestPodAnnotations(t *testing.T) {
+	instance := createLSD("ollama", "")
+	instance.Name = "test"
+	instance.Spec.Annotations = map[string]string{"sidecar.istio.io/inject": "false", "team": "ml"}
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		SafeToEvict: ptr.To(false),
+		PodAnnotations: map[string]string{
+			"sidecar.istio.io/inject": "true",
+			"linkerd.io/inject":       "enabled",
+			safeToEvictAnnotation:     "true",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{
+		Client:      fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:      scheme,
+		ClusterInfo: setupTestClusterInfo(nil),
+	}
+	deployment, err := r.buildDeployment(context.Background(), instance, "ollama-image:latest")
+	require.NoError(t, err)
+	annotations := deployment.Spec.Template.Annotations
+	assert.Equal(t, "true", annotations["sidecar.istio.io/inject"], "the pod annotations take precedence over spec.annotations")
+	assert.Equal(t, "enabled", annotations["linkerd.io/inject"])
+	assert.Equal(t, "ml", annotations["team"])
+	assert.Equal(t, "false", annotations[safeToEvictAnnotation], "the operator annotations win")
+	assert.NotContains(t, deployment.Annotations, "linkerd.io/inject", "the pod annotations are only set on the pods")
+}
+
+func TestGetNetworkPolicyPorts(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		Spec: llamav1alpha1.LlamaStackDistributionSpec{
+			Server: llamav1alpha1.ServerSpec{
+				ContainerSpec: llamav1alpha1.ContainerSpec{
+					Ports: []llamav1alpha1.PortSpec{
+						{Name: "grpc", Port: 9000},
+						{Name: "stream", Port: 9500, Protocol: corev1.ProtocolUDP},
+					},
+				},
+			},
+		},
+	}
+
+	assert.True(t, instance.HasPorts(), "named ports alone must keep the Service")
+	assert.NotContains(t, (&LlamaStackDistributionReconciler{}).determineKindsToExclude(instance), "Service")
+
+	ports := getNetworkPolicyPorts(instance)
+	require.Len(t, ports, 3)
+	expected := []struct {
+		port     int32
+		protocol corev1.Protocol
+	}{
+		{llamav1alpha1.DefaultServerPort, corev1.ProtocolTCP},
+		{9000, corev1.ProtocolTCP},
+		{9500, corev1.ProtocolUDP},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.port, ports[i].Port.IntVal)
+		assert.Equal(t, e.protocol, *ports[i].Protocol)
+	}
+}
+
+func TestFindHostPortConflict(t *testing.T) {
+	now := metav1.Now()
+	newInstance := func(name string, created metav1.Time, hostNetwork bool, port int32) llamav1alpha1.LlamaStackDistribution {
+		return llamav1alpha1.LlamaStackDistribution{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: created},
+			Spec: llamav1alpha1.LlamaStackDistributionSpec{
+				Server: llamav1alpha1.ServerSpec{
+					ContainerSpec: llamav1alpha1.ContainerSpec{Port: port},
+					PodOverrides:  &llamav1alpha1.PodOverrides{HostNetwork: hostNetwork},
+				},
+			},
+		}
+	}
+	older := metav1.NewTime(now.Add(-time.Hour))
+
+	testCases := []struct {
+		name         string
+		instance     llamav1alpha1.LlamaStackDistribution
+		others       []llamav1alpha1.LlamaStackDistribution
+		expectedName string
+		expectedPort int32
+	}{
+		{
+			name:     "no host network",
+			instance: newInstance("new", now, false, 0),
+			others:   []llamav1alpha1.LlamaStackDistribution{newInstance("old", older, true, 0)},
+		},
+		{
+			name:         "older instance on the default port",
+			instance:     newInstance("new", now, true, 0),
+			others:       []llamav1alpha1.LlamaStackDistribution{newInstance("old", older, true, 0)},
+			expectedName: "old",
+			expectedPort: llamav1alpha1.DefaultServerPort,
+		},
+		{
+			name:     "older instance keeps its port",
+			instance: newInstance("old", older, true, 0),
+			others:   []llamav1alpha1.LlamaStackDistribution{newInstance("new", now, true, 0)},
+		},
+		{
+			name:     "distinct ports",
+			instance: newInstance("new", now, true, 8322),
+			others:   []llamav1alpha1.LlamaStackDistribution{newInstance("old", older, true, 0)},
+		},
+		{
+			name:     "other instance not on the host network",
+			instance: newInstance("new", now, true, 0),
+			others:   []llamav1alpha1.LlamaStackDistribution{newInstance("old", older, false, 0)},
+		},
+		{
+			name:     "instance itself is ignored",
+			instance: newInstance("new", now, true, 0),
+			others:   []llamav1alpha1.LlamaStackDistribution{newInstance("new", now, true, 0)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conflict, port := findHostPortConflict(&tc.instance, tc.others)
+			if tc.expectedName == "" {
+				assert.Nil(t, conflict)
+				return
+			}
+			require.NotNil(t, conflict)
+			assert.Equal(t, tc.expectedName, conflict.Name)
+			assert.Equal(t, tc.expectedPort, port)
+		})
+	}
+}
+
+func TestFindSelectorConflict(t *testing.T) {
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "llsd", Namespace: "default"},
+	}
+	podLabels := getPodSelectorLabels(instance)
+
+	newDeployment := func(name string, selector, templateLabels map[string]string) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: selector},
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: templateLabels}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		deployments      []appsv1.Deployment
+		expectedConflict string
+	}{
+		{
+			name:        "own deployment is ignored",
+			deployments: []appsv1.Deployment{newDeployment("llsd", podLabels, podLabels)},
+		},
+		{
+			name: "unrelated deployment",
+			deployments: []appsv1.Deployment{
+				newDeployment("other", map[string]string{"app": "other"}, map[string]string{"app": "other"}),
+			},
+		},
+		{
+			name: "other selector matches our pods",
+			deployments: []appsv1.Deployment{
+				newDeployment("greedy", map[string]string{"app": "llama-stack"}, map[string]string{"app": "llama-stack", "tier": "x"}),
+			},
+			expectedConflict: "greedy",
+		},
+		{
+			name: "our selector matches other pods",
+			deployments: []appsv1.Deployment{
+				newDeployment("copy", map[string]string{"tier": "x"}, map[string]string{
+					"app": "llama-stack", "app.kubernetes.io/instance": "llsd", "tier": "x",
+				}),
+			},
+			expectedConflict: "copy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conflict, err := findSelectorConflict(instance, tc.deployments)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConflict, conflict)
+		})
+	}
+}
+
+func TestConfigureServiceAccountToken(t *testing.T) {
+	tests := []struct {
+		name               string
+		tokenSpec          *llamav1alpha1.ServiceAccountTokenSpec
+		expectedMountPath  string
+		expectedExpiration int64
+	}{
+		{
+			name:               "defaults",
+			tokenSpec:          &llamav1alpha1.ServiceAccountTokenSpec{Audience: "sts.amazonaws.com"},
+			expectedMountPath:  llamav1alpha1.DefaultServiceAccountTokenMountPath,
+			expectedExpiration: defaultServiceAccountTokenExpirationSeconds,
+		},
+		{
+			name: "custom path and expiration",
+			tokenSpec: &llamav1alpha1.ServiceAccountTokenSpec{
+				Audience:          "sts.amazonaws.com",
+				MountPath:         "/var/run/secrets/eks.amazonaws.com/serviceaccount",
+				ExpirationSeconds: ptr.To(int64(86400)),
+			},
+			expectedMountPath:  "/var/run/secrets/eks.amazonaws.com/serviceaccount",
+			expectedExpiration: 86400,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &llamav1alpha1.LlamaStackDistribution{
+				Spec: llamav1alpha1.LlamaStackDistributionSpec{
+					Server: llamav1alpha1.ServerSpec{ServiceAccountToken: tc.tokenSpec},
+				},
+			}
+			podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}}
+
+			configureServiceAccountToken(instance, &podSpec)
+
+			require.Len(t, podSpec.Volumes, 1)
+			require.NotNil(t, podSpec.Volumes[0].Projected)
+			require.Len(t, podSpec.Volumes[0].Projected.Sources, 1)
+			token := podSpec.Volumes[0].Projected.Sources[0].ServiceAccountToken
+			require.NotNil(t, token)
+			assert.Equal(t, "sts.amazonaws.com", token.Audience)
+			assert.Equal(t, tc.expectedExpiration, *token.ExpirationSeconds)
+			assert.Equal(t, "token", token.Path)
+
+			require.Len(t, podSpec.Containers[0].VolumeMounts, 1)
+			mount := podSpec.Containers[0].VolumeMounts[0]
+			assert.Equal(t, podSpec.Volumes[0].Name, mount.Name)
+			assert.Equal(t, tc.expectedMountPath, mount.MountPath)
+			assert.True(t, mount.ReadOnly)
+		})
+	}
+
+	// nothing is mounted when no token is requested
+	podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}}
+	configureServiceAccountToken(&llamav1alpha1.LlamaStackDistribution{}, &podSpec)
+	assert.Empty(t, podSpec.Volumes)
+	assert.Empty(t, podSpec.Containers[0].VolumeMounts)
+}
+
+func TestConfigurePodScheduling(t *testing.T) {
+	tests := []struct {
+		name          string
+		schedulerName string
+		expected      string
+	}{
+		{
+			name:          "default scheduler when unset",
+			schedulerName: "",
+			expected:      "",
+		},
+		{
+			name:          "custom scheduler",
+			schedulerName: "gpu-binpacking-scheduler",
+			expected:      "gpu-binpacking-scheduler",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &llamav1alpha1.LlamaStackDistribution{
+				Spec: llamav1alpha1.LlamaStackDistributionSpec{
+					Server: llamav1alpha1.ServerSpec{
+						SchedulerName: tc.schedulerName,
+					},
+				},
+			}
+
+			podSpec := corev1.PodSpec{}
+			configurePodScheduling(instance, &podSpec)
+
+			assert.Equal(t, tc.expected, podSpec.SchedulerName)
+		})
+	}
+}
+
+func TestValidateConfigMapKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "valid keys",
+			keys:        []string{DefaultCABundleKey, "intermediate.pem", "root-ca.cert"},
+			expectError: false,
+		},
+		{
+			name:        "empty key",
+			keys:        []string{""},
+			expectError: true,
+			errorMsg:    "ConfigMap key cannot be empty",
+		},
+		{
+			name:        "command injection attempt",
+			keys:        []string{"valid-key; rm -rf /; echo malicious"},
+			expectError: true,
+			errorMsg:    "contains invalid characters",
+		},
+		{
+			name:        "path traversal attempt",
+			keys:        []string{"../../../etc/passwd"},
+			expectError: true,
 			errorMsg:    "contains invalid characters",
 		},
 		{
@@ -648,6 +2265,22 @@ func TestValidateConfigMapKeys(t *testing.T) {
 	}
 }
 
+// newDefaultContainerSecurityContext returns the restricted security context of the server container.
+func newDefaultContainerSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(true),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+// withRunAsUser returns the security context running as the user.
+func withRunAsUser(securityContext *corev1.SecurityContext, user int64) *corev1.SecurityContext {
+	securityContext.RunAsUser = ptr.To(user)
+	return securityContext
+}
+
 // newDefaultReadinessProbe returns a Kubernetes HTTP readiness probe that checks
 // the "/v1/health" endpoint on the given port using default timing and
 // threshold settings.
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// lastKnownGoodTemplateAnnotation stores on the Deployment the last pod template that completed a rollout.
+	lastKnownGoodTemplateAnnotation = "llamastack.io/last-known-good-template"
+	// rolledBackTemplateHashAnnotation stores on the Deployment the hash of the desired pod template
+	// that was rolled back. The rollback holds as long as the spec renders the same template.
+	rolledBackTemplateHashAnnotation = "llamastack.io/rolled-back-template-hash"
+	// appliedTemplateHashAnnotation stores on the Deployment the hash of the pod template rendered from the spec
+	// that the Deployment runs. The live pod template carries the defaults set by the API server, so it is
+	// told apart from the desired one by this hash rather than field by field.
+	appliedTemplateHashAnnotation = "llamastack.io/applied-template-hash"
+	// lastKnownGoodTemplateHashAnnotation stores on the Deployment the applied hash of the last known good template.
+	lastKnownGoodTemplateHashAnnotation = "llamastack.io/last-known-good-template-hash"
+	// reasonProgressDeadlineExceeded is the reason of the Progressing condition of a stuck rollout.
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+// applyRollbackPolicy replaces the pod template of the desired Deployment with the last one that completed
+// a rollout when the rollout of the desired template exceeded its progress deadline. The rollback state is
+// kept in annotations of the Deployment, and only applies when the instance opted in with RollbackPolicyAuto.
+func (r *LlamaStackDistributionReconciler) applyRollbackPolicy(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	desired *appsv1.Deployment) error {
+	if instance.Spec.Server.RollbackPolicy != llamav1alpha1.RollbackPolicyAuto {
+		return nil
+	}
+
+	live := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to fetch deployment for rollback: %w", err)
+	}
+
+	desiredHash, err := getAppliedTemplateHash(desired)
+	if err != nil {
+		return err
+	}
+	rollback, err := getRollbackState(live, &desired.Spec.Template, desiredHash)
+	if err != nil {
+		return err
+	}
+
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if rollback.knownGood != "" {
+		annotations[lastKnownGoodTemplateAnnotation] = rollback.knownGood
+	}
+	if rollback.knownGoodHash != "" {
+		annotations[lastKnownGoodTemplateHashAnnotation] = rollback.knownGoodHash
+	}
+	if rollback.rolledBackHash != desiredHash {
+		desired.SetAnnotations(annotations)
+		SetRolledBackCondition(instance, false, MessageNotRolledBack)
+		return nil
+	}
+
+	template := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal([]byte(rollback.knownGood), &template); err != nil {
+		return fmt.Errorf("failed to parse last known good pod template: %w", err)
+	}
+	if live.Annotations[rolledBackTemplateHashAnnotation] != desiredHash {
+		log.FromContext(ctx).Info("rollout exceeded its progress deadline, rolling back to the last working pod template",
+			"deployment", desired.Name)
+	}
+	annotations[rolledBackTemplateHashAnnotation] = desiredHash
+	setAppliedTemplateHashAnnotation(annotations, rollback.knownGoodHash)
+	desired.SetAnnotations(annotations)
+	desired.Spec.Template = template
+	SetRolledBackCondition(instance, true, MessageRolledBack)
+	return nil
+}
+
+// rollbackState is the rollback bookkeeping of a Deployment.
+type rollbackState struct {
+	// knownGood is the JSON encoded pod template that last completed a rollout.
+	knownGood string
+	// knownGoodHash is the applied hash of knownGood, empty when it was rolled out without one.
+	knownGoodHash string
+	// rolledBackHash is the hash of the desired pod template replaced by knownGood, if any.
+	rolledBackHash string
+}
+
+// getRollbackState derives the rollback state from the live Deployment, for the desired pod template.
+func getRollbackState(live *appsv1.Deployment, desiredTemplate *corev1.PodTemplateSpec, desiredHash string) (rollbackState, error) {
+	state := rollbackState{
+		knownGood:      live.Annotations[lastKnownGoodTemplateAnnotation],
+		knownGoodHash:  live.Annotations[lastKnownGoodTemplateHashAnnotation],
+		rolledBackHash: live.Annotations[rolledBackTemplateHashAnnotation],
+	}
+
+	if isRolloutComplete(live) {
+		data, err := json.Marshal(live.Spec.Template)
+		if err != nil {
+			return state, fmt.Errorf("failed to marshal pod template: %w", err)
+		}
+		state.knownGood = string(data)
+		state.knownGoodHash = live.Annotations[appliedTemplateHashAnnotation]
+	}
+
+	// A new spec retries the rollout
+	if state.rolledBackHash != desiredHash {
+		state.rolledBackHash = ""
+	}
+
+	if state.rolledBackHash == "" && state.knownGood != "" && isProgressDeadlineExceeded(live) {
+		failedRollout, err := isTemplateApplied(live, desiredTemplate, desiredHash)
+		if err != nil {
+			return state, err
+		}
+		knownGood := &appsv1.Deployment{}
+		if state.knownGoodHash != "" {
+			knownGood.Annotations = map[string]string{appliedTemplateHashAnnotation: state.knownGoodHash}
+		}
+		if err := json.Unmarshal([]byte(state.knownGood), &knownGood.Spec.Template); err != nil {
+			return state, fmt.Errorf("failed to parse last known good pod template: %w", err)
+		}
+		alreadyKnownGood, err := isTemplateApplied(knownGood, desiredTemplate, desiredHash)
+		if err != nil {
+			return state, err
+		}
+		// Only roll back the rollout of the desired template, to a template that differs from it
+		if failedRollout && !alreadyKnownGood {
+			state.rolledBackHash = desiredHash
+		}
+	}
+
+	return state, nil
+}
+
+// isRolloutComplete returns true when every replica of the Deployment runs its current pod template.
+func isRolloutComplete(deployment *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		!isProgressDeadlineExceeded(deployment)
+}
+
+// isProgressDeadlineExceeded returns true when the Deployment controller gave up on the current rollout.
+func isProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Status == corev1.ConditionFalse && condition.Reason == reasonProgressDeadlineExceeded
+		}
+	}
+	return false
+}
+
+// isTemplateApplied reports whether the Deployment runs the desired pod template, by its applied hash. The
+// Deployments applied before the hash was recorded are compared field by field, which misses removed fields.
+func isTemplateApplied(deployment *appsv1.Deployment, desired *corev1.PodTemplateSpec, desiredHash string) (bool, error) {
+	if appliedHash, ok := deployment.Annotations[appliedTemplateHashAnnotation]; ok {
+		return appliedHash == desiredHash, nil
+	}
+	return templateMatches(desired, &deployment.Spec.Template)
+}
+
+// getAppliedTemplateHash returns the applied hash of the desired Deployment, the hash of its pod template when
+// it isn't recorded.
+func getAppliedTemplateHash(deployment *appsv1.Deployment) (string, error) {
+	if appliedHash, ok := deployment.Annotations[appliedTemplateHashAnnotation]; ok {
+		return appliedHash, nil
+	}
+	return getPodTemplateHash(&deployment.Spec.Template)
+}
+
+// setAppliedTemplateHashAnnotation records the applied hash in the annotations, or removes it when unknown.
+func setAppliedTemplateHashAnnotation(annotations map[string]string, appliedHash string) {
+	if appliedHash == "" {
+		delete(annotations, appliedTemplateHashAnnotation)
+		return
+	}
+	annotations[appliedTemplateHashAnnotation] = appliedHash
+}
+
+// templateMatches reports whether every field set in the desired pod template holds the same value in the
+// live one, which also carries the defaults set by the API server.
+func templateMatches(desired, live *corev1.PodTemplateSpec) (bool, error) {
+	desiredContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert pod template: %w", err)
+	}
+	liveContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert pod template: %w", err)
+	}
+	return compare.IsSubset(desiredContent, liveContent), nil
+}
+
+// getPodTemplateHash returns a hash identifying the desired pod template.
+func getPodTemplateHash(template *corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal pod template: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRollbackTestTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "llama-stack", Image: image}},
+		},
+	}
+}
+
+func newRollbackTestDeployment(template corev1.PodTemplateSpec, complete bool, annotations map[string]string) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llsd", Generation: 2, Annotations: annotations},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1)), Template: template},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1},
+	}
+	if complete {
+		deployment.Status.AvailableReplicas = 1
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+		}
+	} else {
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: reasonProgressDeadlineExceeded},
+		}
+	}
+	return deployment
+}
+
+func TestGetRollbackState(t *testing.T) {
+	good := newRollbackTestTemplate("llama-stack:good")
+	bad := newRollbackTestTemplate("llama-stack:bad")
+	fixed := newRollbackTestTemplate("llama-stack:fixed")
+	goodJSON, err := json.Marshal(good)
+	require.NoError(t, err)
+	badHash, err := getPodTemplateHash(&bad)
+	require.NoError(t, err)
+	fixedHash, err := getPodTemplateHash(&fixed)
+	require.NoError(t, err)
+	// The good template without its env is a subset of it, only the applied hash tells them apart
+	goodWithEnv := newRollbackTestTemplate("llama-stack:good")
+	goodWithEnv.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "INFERENCE_MODEL", Value: "granite"}}
+	goodWithEnvJSON, err := json.Marshal(goodWithEnv)
+	require.NoError(t, err)
+	goodWithEnvHash, err := getPodTemplateHash(&goodWithEnv)
+	require.NoError(t, err)
+	goodHash, err := getPodTemplateHash(&good)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		live             *appsv1.Deployment
+		desired          corev1.PodTemplateSpec
+		desiredHash      string
+		expectedRollback string
+	}{
+		{
+			name:             "completed rollout becomes the last known good template",
+			live:             newRollbackTestDeployment(good, true, nil),
+			desired:          bad,
+			desiredHash:      badHash,
+			expectedRollback: "",
+		},
+		{
+			name:             "stuck rollout of the desired template is rolled back",
+			live:             newRollbackTestDeployment(bad, false, map[string]string{lastKnownGoodTemplateAnnotation: string(goodJSON)}),
+			desired:          bad,
+			desiredHash:      badHash,
+			expectedRollback: badHash,
+		},
+		{
+			name: "rollback holds while the spec is unchanged",
+			live: newRollbackTestDeployment(good, true, map[string]string{
+				lastKnownGoodTemplateAnnotation:  string(goodJSON),
+				rolledBackTemplateHashAnnotation: badHash,
+			}),
+			desired:          bad,
+			desiredHash:      badHash,
+			expectedRollback: badHash,
+		},
+		{
+			name: "new spec retries the rollout",
+			live: newRollbackTestDeployment(good, true, map[string]string{
+				lastKnownGoodTemplateAnnotation:  string(goodJSON),
+				rolledBackTemplateHashAnnotation: badHash,
+			}),
+			desired:          fixed,
+			desiredHash:      fixedHash,
+			expectedRollback: "",
+		},
+		{
+			name: "stuck rollout of a template removing fields is rolled back",
+			live: newRollbackTestDeployment(good, false, map[string]string{
+				appliedTemplateHashAnnotation:       goodHash,
+				lastKnownGoodTemplateAnnotation:     string(goodWithEnvJSON),
+				lastKnownGoodTemplateHashAnnotation: goodWithEnvHash,
+			}),
+			desired:          good,
+			desiredHash:      goodHash,
+			expectedRollback: goodHash,
+		},
+		{
+			name: "no rollback of a template that was not applied, even when the live one holds all its fields",
+			live: newRollbackTestDeployment(goodWithEnv, false, map[string]string{
+				appliedTemplateHashAnnotation:   goodWithEnvHash,
+				lastKnownGoodTemplateAnnotation: string(goodJSON),
+			}),
+			desired:          good,
+			desiredHash:      goodHash,
+			expectedRollback: "",
+		},
+		{
+			name:             "no rollback without a last known good template",
+			live:             newRollbackTestDeployment(bad, false, nil),
+			desired:          bad,
+			desiredHash:      badHash,
+			expectedRollback: "",
+		},
+		{
+			name:             "no rollback of a template that was not rolled out yet",
+			live:             newRollbackTestDeployment(bad, false, map[string]string{lastKnownGoodTemplateAnnotation: string(goodJSON)}),
+			desired:          fixed,
+			desiredHash:      fixedHash,
+			expectedRollback: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := getRollbackState(tc.live, &tc.desired, tc.desiredHash)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRollback, state.rolledBackHash)
+
+			knownGood := corev1.PodTemplateSpec{}
+			if state.knownGood != "" {
+				require.NoError(t, json.Unmarshal([]byte(state.knownGood), &knownGood))
+			}
+			if tc.live.Annotations[lastKnownGoodTemplateAnnotation] != "" || isRolloutComplete(tc.live) {
+				assert.Equal(t, "llama-stack:good", knownGood.Spec.Containers[0].Image)
+			}
+		})
+	}
+}
+
+func TestApplyRollbackPolicyKeepsImageVolumes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	instance := createLSD("", "llama-stack:bad")
+	instance.Spec.Server.RollbackPolicy = llamav1alpha1.RollbackPolicyAuto
+
+	// The last known good template mounts the image volume of the previous spec
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{
+		ImageVolumes: []llamav1alpha1.ImageVolumeSpec{{Name: "models", Reference: "models:v1", MountPath: "/models"}},
+	}
+	good := newRollbackTestTemplate("llama-stack:good")
+	good.Annotations = map[string]string{}
+	require.NoError(t, addImageVolumes(instance, &good.Spec, good.Annotations))
+	goodJSON, err := json.Marshal(good)
+	require.NoError(t, err)
+
+	instance.Spec.Server.Storage.ImageVolumes[0].Reference = "models:v2"
+	bad := newRollbackTestTemplate("llama-stack:bad")
+	bad.Annotations = map[string]string{}
+	require.NoError(t, addImageVolumes(instance, &bad.Spec, bad.Annotations))
+	badHash, err := getPodTemplateHash(&bad)
+	require.NoError(t, err)
+
+	live := newRollbackTestDeployment(bad, false, map[string]string{
+		lastKnownGoodTemplateAnnotation:  string(goodJSON),
+		rolledBackTemplateHashAnnotation: badHash,
+		appliedTemplateHashAnnotation:    badHash,
+	})
+	live.Namespace = "default"
+	r := &LlamaStackDistributionReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(live).Build(),
+		Scheme: scheme,
+	}
+	desired := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: live.Name, Namespace: live.Namespace},
+		Spec:       appsv1.DeploymentSpec{Template: *bad.DeepCopy()},
+	}
+	require.NoError(t, r.applyRollbackPolicy(context.Background(), instance, desired))
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeRolledBack))
+	assert.Equal(t, "llama-stack:good", desired.Spec.Template.Spec.Containers[0].Image)
+
+	// The rolled back template mounts its own image volume, not the one of the current spec
+	u, err := r.toDesiredWithImageVolumes(desired, "spec", "template")
+	require.NoError(t, err)
+	volumes, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "volumes")
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"name": "models", "image": map[string]any{"reference": "models:v1"}}}, volumes)
+}
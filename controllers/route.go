@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// routesSupported reports whether the cluster serves the OpenShift Route API.
+func (r *LlamaStackDistributionReconciler) routesSupported() bool {
+	return r.ClusterInfo != nil && r.ClusterInfo.RoutesSupported
+}
+
+// reconcileRoute creates or updates the Route exposing the server Service when one is requested, and deletes
+// it otherwise. Nothing is done on clusters without Routes.
+func (r *LlamaStackDistributionReconciler) reconcileRoute(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	if !r.routesSupported() {
+		return nil
+	}
+	route := buildRoute(instance)
+	if instance.Spec.Server.Route == nil {
+		return deploy.DeleteIfControlled(ctx, r.Client, instance, route, log.FromContext(ctx))
+	}
+	return deploy.ApplyRoute(ctx, r.Client, r.Scheme, instance, route, log.FromContext(ctx))
+}
+
+// buildRoute returns the Route sending the external traffic to the server port of the server Service.
+func buildRoute(instance *llamav1alpha1.LlamaStackDistribution) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(deploy.RouteGVK)
+	route.SetName(instance.Name)
+	route.SetNamespace(instance.Namespace)
+	routeSpec := instance.Spec.Server.Route
+	if routeSpec == nil {
+		return route
+	}
+
+	route.SetLabels(deploy.MergeLabels(instance.Spec.Labels, routeSpec.Labels, deploy.GetRecommendedLabels(instance), map[string]string{
+		deploy.InstanceLabelKey: instance.Name,
+	}))
+	route.SetAnnotations(deploy.GetResourceAnnotations(instance))
+	spec := map[string]any{
+		"to": map[string]any{
+			"kind": "Service",
+			"name": deploy.GetServiceName(instance),
+		},
+		"port": map[string]any{
+			"targetPort": llamav1alpha1.DefaultServicePortName,
+		},
+	}
+	if routeSpec.Host != "" {
+		spec["host"] = routeSpec.Host
+	}
+	if routeSpec.TLS != nil {
+		tls := map[string]any{"termination": string(routeSpec.TLS.Termination)}
+		if routeSpec.TLS.InsecureEdgeTerminationPolicy != "" {
+			tls["insecureEdgeTerminationPolicy"] = routeSpec.TLS.InsecureEdgeTerminationPolicy
+		}
+		spec["tls"] = tls
+	}
+	route.Object["spec"] = spec
+	return route
+}
+
+// updateRouteStatus reports the host admitted for the Route, and whether a router admitted it.
+func (r *LlamaStackDistributionReconciler) updateRouteStatus(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
+	if instance.Spec.Server.Route == nil {
+		instance.Status.Route = nil
+		meta.RemoveStatusCondition(&instance.Status.Conditions, ConditionTypeRouteReady)
+		return
+	}
+	if !r.routesSupported() {
+		instance.Status.Route = nil
+		SetRouteReadyCondition(instance, false, "Routes are not supported by the cluster, which doesn't serve the route.openshift.io/v1 API")
+		return
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(deploy.RouteGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, route); err != nil {
+		SetRouteReadyCondition(instance, false, fmt.Sprintf("Failed to get Route: %v", err))
+		return
+	}
+
+	host, admitted, message := getRouteAdmission(route)
+	instance.Status.Route = &llamav1alpha1.RouteStatus{Host: host}
+	SetRouteReadyCondition(instance, admitted, message)
+}
+
+// getRouteAdmission returns the host admitted by a router for the Route, or the reason no router admitted it.
+func getRouteAdmission(route *unstructured.Unstructured) (string, bool, string) {
+	ingresses, _, _ := unstructured.NestedSlice(route.Object, "status", "ingress")
+	message := "Waiting for a router to admit the Route"
+	for _, item := range ingresses {
+		ingress, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		host, _, _ := unstructured.NestedString(ingress, "host")
+		routerName, _, _ := unstructured.NestedString(ingress, "routerName")
+		conditions, _, _ := unstructured.NestedSlice(ingress, "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]any)
+			if !ok || condition["type"] != "Admitted" {
+				continue
+			}
+			if condition["status"] == "True" {
+				return host, true, MessageRouteReady
+			}
+			message = fmt.Sprintf("Route was not admitted by router %s: %v: %v", routerName, condition["reason"], condition["message"])
+		}
+	}
+	return "", false, message
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuildRoute(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.Route = &llamav1alpha1.RouteSpec{
+		Host:   "llsd.apps.example.com",
+		TLS:    &llamav1alpha1.RouteTLSSpec{Termination: llamav1alpha1.RouteTLSTerminationEdge, InsecureEdgeTerminationPolicy: "Redirect"},
+		Labels: map[string]string{"router": "public", deploy.InstanceLabelKey: "other"},
+	}
+
+	route := buildRoute(instance)
+	assert.Equal(t, deploy.RouteGVK, route.GroupVersionKind())
+	assert.Equal(t, "public", route.GetLabels()["router"])
+	assert.Equal(t, "llsd", route.GetLabels()[deploy.InstanceLabelKey], "the operator labels take precedence")
+	assert.Equal(t, map[string]any{
+		"host": "llsd.apps.example.com",
+		"to":   map[string]any{"kind": "Service", "name": "llsd-service"},
+		"port": map[string]any{"targetPort": "http"},
+		"tls":  map[string]any{"termination": "edge", "insecureEdgeTerminationPolicy": "Redirect"},
+	}, route.Object["spec"])
+	assert.NotContains(t, (&LlamaStackDistributionReconciler{}).determineKindsToExclude(instance), "Service", "the Route needs the Service")
+}
+
+func TestGetRouteAdmission(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]any{}}
+	_, admitted, message := getRouteAdmission(route)
+	assert.False(t, admitted)
+	assert.Equal(t, "Waiting for a router to admit the Route", message)
+
+	route.Object["status"] = map[string]any{"ingress": []any{
+		map[string]any{
+			"host":       "llsd.apps.example.com",
+			"routerName": "default",
+			"conditions": []any{map[string]any{"type": "Admitted", "status": "False", "reason": "HostAlreadyClaimed", "message": "host claimed"}},
+		},
+	}}
+	_, admitted, message = getRouteAdmission(route)
+	assert.False(t, admitted)
+	assert.Equal(t, "Route was not admitted by router default: HostAlreadyClaimed: host claimed", message)
+
+	route.Object["status"] = map[string]any{"ingress": []any{
+		map[string]any{
+			"host":       "llsd.apps.example.com",
+			"routerName": "default",
+			"conditions": []any{map[string]any{"type": "Admitted", "status": "True"}},
+		},
+	}}
+	host, admitted, message := getRouteAdmission(route)
+	assert.True(t, admitted)
+	assert.Equal(t, "llsd.apps.example.com", host)
+	assert.Equal(t, MessageRouteReady, message)
+}
+
+func TestRouteWithoutRouteAPI(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{}
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.Route = &llamav1alpha1.RouteSpec{}
+
+	require.NoError(t, r.reconcileRoute(context.Background(), instance))
+	r.updateRouteStatus(context.Background(), instance)
+	condition := GetCondition(&instance.Status, ConditionTypeRouteReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonRouteNotAdmitted, condition.Reason)
+	assert.Nil(t, instance.Status.Route)
+
+	instance.Spec.Server.Route = nil
+	r.updateRouteStatus(context.Background(), instance)
+	assert.Nil(t, GetCondition(&instance.Status, ConditionTypeRouteReady))
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// getPodSecurityContext returns the security context of the server pods: a RuntimeDefault seccomp profile,
+// with the fields set in the pod overrides replacing the defaults. The pod doesn't default to non-root, the
+// init container fixing the ownership of the storage volume runs as root.
+func getPodSecurityContext(instance *llamav1alpha1.LlamaStackDistribution) *corev1.PodSecurityContext {
+	securityContext := &corev1.PodSecurityContext{
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	if instance.Spec.Server.PodOverrides == nil || instance.Spec.Server.PodOverrides.SecurityContext == nil {
+		return securityContext
+	}
+
+	override := instance.Spec.Server.PodOverrides.SecurityContext.DeepCopy()
+	setIfNotNil(&securityContext.SELinuxOptions, override.SELinuxOptions)
+	setIfNotNil(&securityContext.WindowsOptions, override.WindowsOptions)
+	setIfNotNil(&securityContext.RunAsUser, override.RunAsUser)
+	setIfNotNil(&securityContext.RunAsGroup, override.RunAsGroup)
+	setIfNotNil(&securityContext.RunAsNonRoot, override.RunAsNonRoot)
+	setIfNotNil(&securityContext.FSGroup, override.FSGroup)
+	setIfNotNil(&securityContext.FSGroupChangePolicy, override.FSGroupChangePolicy)
+	setIfNotNil(&securityContext.SeccompProfile, override.SeccompProfile)
+	if override.SupplementalGroups != nil {
+		securityContext.SupplementalGroups = override.SupplementalGroups
+	}
+	if override.Sysctls != nil {
+		securityContext.Sysctls = override.Sysctls
+	}
+	return securityContext
+}
+
+// getContainerSecurityContext returns the security context of the server container: the defaults passing the
+// restricted Pod Security Standard, with the fields set in the pod overrides replacing them. Overriding the
+// capabilities replaces the dropped ones as well.
+func getContainerSecurityContext(instance *llamav1alpha1.LlamaStackDistribution) *corev1.SecurityContext {
+	securityContext := &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(true),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	if instance.Spec.Server.PodOverrides == nil || instance.Spec.Server.PodOverrides.ContainerSecurityContext == nil {
+		return securityContext
+	}
+
+	override := instance.Spec.Server.PodOverrides.ContainerSecurityContext.DeepCopy()
+	setIfNotNil(&securityContext.Capabilities, override.Capabilities)
+	setIfNotNil(&securityContext.Privileged, override.Privileged)
+	setIfNotNil(&securityContext.SELinuxOptions, override.SELinuxOptions)
+	setIfNotNil(&securityContext.WindowsOptions, override.WindowsOptions)
+	setIfNotNil(&securityContext.RunAsUser, override.RunAsUser)
+	setIfNotNil(&securityContext.RunAsGroup, override.RunAsGroup)
+	setIfNotNil(&securityContext.RunAsNonRoot, override.RunAsNonRoot)
+	setIfNotNil(&securityContext.ReadOnlyRootFilesystem, override.ReadOnlyRootFilesystem)
+	setIfNotNil(&securityContext.AllowPrivilegeEscalation, override.AllowPrivilegeEscalation)
+	setIfNotNil(&securityContext.ProcMount, override.ProcMount)
+	setIfNotNil(&securityContext.SeccompProfile, override.SeccompProfile)
+	return securityContext
+}
+
+// setIfNotNil replaces the field with the override when the override is set.
+func setIfNotNil[T any](field **T, override *T) {
+	if override != nil {
+		*field = override
+	}
+}
+
+// hasPodFSGroup returns true when the pod overrides set an fsGroup, granting access to the volumes without
+// fixing their ownership.
+func hasPodFSGroup(instance *llamav1alpha1.LlamaStackDistribution) bool {
+	overrides := instance.Spec.Server.PodOverrides
+	return overrides != nil && overrides.SecurityContext != nil && overrides.SecurityContext.FSGroup != nil
+}
+
+// getCABundleInitSecurityContext returns the security context of the init container concatenating the CA
+// bundle keys: the restricted defaults of the server container with a read-only root filesystem, as it only
+// reads its ConfigMap and writes to the ca-bundle volume.
+func getCABundleInitSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(true),
+		AllowPrivilegeEscalation: ptr.To(false),
+		ReadOnlyRootFilesystem:   ptr.To(true),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+// getStoragePermissionsSecurityContext returns the security context of the init container fixing the ownership
+// of the storage volume. It has to run as root, which the restricted Pod Security Standard rejects, so it keeps
+// only the capability to change ownership. Setting an fsGroup in the pod overrides removes the container.
+func getStoragePermissionsSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		RunAsUser:                ptr.To(int64(0)),
+		RunAsGroup:               ptr.To(int64(0)),
+		RunAsNonRoot:             ptr.To(false),
+		AllowPrivilegeEscalation: ptr.To(false),
+		ReadOnlyRootFilesystem:   ptr.To(true),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+			Add:  []corev1.Capability{"CHOWN"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
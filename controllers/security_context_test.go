@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetContainerSecurityContext(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(true),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, getContainerSecurityContext(instance))
+
+	// A GPU distribution running as root keeps the other defaults
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		ContainerSecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot: ptr.To(false),
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}},
+		},
+	}
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(false),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, getContainerSecurityContext(instance))
+}
+
+func TestGetPodSecurityContext(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	assert.Equal(t, &corev1.PodSecurityContext{
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, getPodSecurityContext(instance))
+
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		SecurityContext: &corev1.PodSecurityContext{
+			FSGroup:        ptr.To(int64(1001)),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+		},
+	}
+	assert.Equal(t, &corev1.PodSecurityContext{
+		FSGroup:        ptr.To(int64(1001)),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+	}, getPodSecurityContext(instance))
+}
+
+func TestConfigurePersistentStorageWithFSGroup(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{}
+
+	podSpec := &corev1.PodSpec{}
+	configurePersistentStorage(instance, podSpec)
+	assert.Len(t, podSpec.InitContainers, 1, "the PVC ownership is fixed by an init container")
+
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		SecurityContext: &corev1.PodSecurityContext{FSGroup: ptr.To(int64(1001))},
+	}
+	podSpec = &corev1.PodSpec{}
+	configurePersistentStorage(instance, podSpec)
+	assert.Empty(t, podSpec.InitContainers, "an fsGroup replaces the root init container")
+	assert.Len(t, podSpec.Volumes, 1)
+}
+
+func TestServerPodContainersSecurityContext(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{}
+	instance.Spec.Server.TLSConfig = &llamav1alpha1.TLSConfig{
+		CABundle: &llamav1alpha1.CABundleConfig{ConfigMapName: "ca-bundle", ConfigMapKeys: []string{"root.crt", "intermediate.crt"}},
+	}
+	r := &LlamaStackDistributionReconciler{ClusterInfo: setupTestClusterInfo(nil)}
+	ctx := context.Background()
+
+	podSpec := configurePodStorage(ctx, r, instance, buildContainerSpec(ctx, r, instance, "test-image:latest"))
+	require.Len(t, podSpec.InitContainers, 2, "the pod has the PVC permissions and CA bundle init containers")
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+
+	for _, container := range slices.Concat(podSpec.InitContainers, podSpec.Containers) {
+		securityContext := container.SecurityContext
+		require.NotNil(t, securityContext, container.Name)
+		assert.False(t, ptr.Deref(securityContext.AllowPrivilegeEscalation, true), container.Name)
+		assert.False(t, ptr.Deref(securityContext.Privileged, false), container.Name)
+		assert.Equal(t, []corev1.Capability{"ALL"}, securityContext.Capabilities.Drop, container.Name)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, securityContext.SeccompProfile.Type, container.Name)
+		if container.Name == storagePermissionsInitName {
+			assert.Equal(t, []corev1.Capability{"CHOWN"}, securityContext.Capabilities.Add, "only the ownership fix runs as root")
+			continue
+		}
+		assert.True(t, ptr.Deref(securityContext.RunAsNonRoot, false), container.Name)
+		assert.Empty(t, securityContext.Capabilities.Add, container.Name)
+	}
+	assert.Equal(t, "test-image:latest", podSpec.InitContainers[1].Image, "the CA bundle is concatenated by the server image")
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// getServerScheme returns the URL scheme of the requests made to the server, and of the probes of its pods.
+func getServerScheme(instance *llamav1alpha1.LlamaStackDistribution) string {
+	if healthCheck := instance.Spec.Server.HealthCheck; healthCheck != nil && healthCheck.Scheme == corev1.URISchemeHTTPS {
+		return "https"
+	}
+	return "http"
+}
+
+// getServerHTTPClient returns the client of the requests made to the server. Instances verifying the server
+// certificate with their own CA bundle, or not at all, get a dedicated client; the others share the default one.
+func (r *LlamaStackDistributionReconciler) getServerHTTPClient(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) (*http.Client, error) {
+	httpClient, err := r.getServerTransportClient(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	return r.withServerRequestSettings(ctx, instance, httpClient)
+}
+
+// getPodHTTPClient returns the client of the requests made to a server pod by its IP address, e.g. a canary
+// pod. The requests never go through the proxy, and the server certificate is verified against serverName, the
+// host name of the Service, since the certificate isn't issued for the pod IP.
+func (r *LlamaStackDistributionReconciler) getPodHTTPClient(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	serverName string) (*http.Client, error) {
+	httpClient, err := r.getServerTransportClient(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	return r.withServerRequestSettings(ctx, instance, newPodHTTPClient(httpClient, serverName))
+}
+
+// withServerRequestSettings returns the client sending the API token of the instance, with its request timeout
+// and retries.
+func (r *LlamaStackDistributionReconciler) withServerRequestSettings(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	httpClient *http.Client) (*http.Client, error) {
+	token, err := r.getAPIToken(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	return withHealthCheckRetries(withHealthCheckTimeout(withAPIToken(httpClient, token), instance), instance), nil
+}
+
+// getServerTransportClient returns the shared client of the instance, with its own TLS settings if any.
+func (r *LlamaStackDistributionReconciler) getServerTransportClient(ctx context.Context,
+	instance *llamav1alpha1.LlamaStackDistribution) (*http.Client, error) {
+	name := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	healthCheck := instance.Spec.Server.HealthCheck
+	if getServerScheme(instance) != "https" || (!hasServerCABundle(healthCheck) && !healthCheck.InsecureSkipVerify) {
+		r.tlsClients.remove(name)
+		return r.httpClient, nil
+	}
+
+	var caBundle []byte
+	if hasServerCABundle(healthCheck) {
+		var err error
+		if caBundle, err = r.getServerCABundle(ctx, instance); err != nil {
+			return nil, err
+		}
+	}
+
+	key := fmt.Sprintf("%x/%t", sha256.Sum256(caBundle), healthCheck.InsecureSkipVerify)
+	return r.tlsClients.get(name, key, func() (*http.Client, error) {
+		tlsConfig, err := newServerTLSConfig(caBundle, healthCheck.InsecureSkipVerify)
+		if err != nil {
+			return nil, err
+		}
+		return newTLSHTTPClient(r.httpClient, tlsConfig), nil
+	})
+}
+
+// hasServerCABundle reports whether the server certificate is verified with a CA bundle of the user.
+func hasServerCABundle(healthCheck *llamav1alpha1.HealthCheckSpec) bool {
+	return healthCheck.CABundle != nil || healthCheck.CABundleSecretRef != nil
+}
+
+// getServerCABundle returns the CA certificates of the health check CA bundle, from the key of its Secret or the
+// concatenated keys of its ConfigMap.
+func (r *LlamaStackDistributionReconciler) getServerCABundle(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) ([]byte, error) {
+	if ref := instance.Spec.Server.HealthCheck.CABundleSecretRef; ref != nil {
+		name := types.NamespacedName{Name: ref.Name, Namespace: instance.Namespace}
+		secret := &corev1.Secret{}
+		if err := r.getSecret(ctx, name, secret); err != nil {
+			return nil, fmt.Errorf("failed to get server CA bundle Secret %s: %w", name, err)
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("failed to find key %s in server CA bundle Secret %s", ref.Key, name)
+		}
+		return data, nil
+	}
+
+	caBundle := instance.Spec.Server.HealthCheck.CABundle
+	name := types.NamespacedName{Name: caBundle.ConfigMapName, Namespace: caBundle.ConfigMapNamespace}
+	if name.Namespace == "" {
+		name.Namespace = instance.Namespace
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, name, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get server CA bundle ConfigMap %s: %w", name, err)
+	}
+
+	keys := caBundle.ConfigMapKeys
+	if len(keys) == 0 {
+		keys = []string{DefaultCABundleKey}
+	}
+	var bundle []byte
+	for _, key := range keys {
+		data, ok := configMap.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("failed to find key %s in server CA bundle ConfigMap %s", key, name)
+		}
+		bundle = append(bundle, data...)
+		bundle = append(bundle, '\n')
+	}
+	return bundle, nil
+}
+
+// newServerTLSConfig returns the TLS configuration verifying the server certificate with the CA bundle,
+// or the system CA certificates when empty, unless verification is disabled.
+func newServerTLSConfig(caBundle []byte, insecureSkipVerify bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec // explicitly requested by the user, e.g. on development clusters
+	}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("failed to parse server CA bundle: no PEM certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// newTLSHTTPClient returns a copy of the client using the TLS configuration.
+func newTLSHTTPClient(base *http.Client, tlsConfig *tls.Config) *http.Client {
+	transport, ok := base.Transport.(*http.Transport)
+	if !ok {
+		transport = newHTTPClient().Transport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: base.Timeout, Transport: transport}
+}
+
+// newPodHTTPClient returns a copy of the client reaching the server pods directly: it never uses the proxy, and
+// verifies the server certificate against serverName. Its connections aren't kept alive, since every pod is a
+// new host.
+func newPodHTTPClient(base *http.Client, serverName string) *http.Client {
+	transport, ok := base.Transport.(*http.Transport)
+	if !ok {
+		transport = newHTTPClient().Transport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	transport.Proxy = nil
+	transport.DisableKeepAlives = true
+	tlsConfig := transport.TLSClientConfig.Clone()
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	tlsConfig.ServerName = serverName
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: base.Timeout, Transport: transport}
+}
+
+// tlsClientCache holds the dedicated HTTPS client of each instance, along with the key of the TLS settings
+// it was built from, and replaces it when the settings change.
+type tlsClientCache struct {
+	mu      sync.Mutex
+	clients map[types.NamespacedName]cachedTLSClient
+}
+
+type cachedTLSClient struct {
+	key    string
+	client *http.Client
+}
+
+// get returns the client of the instance built from the TLS settings with the key, building it if needed.
+func (c *tlsClientCache) get(name types.NamespacedName, key string, newClient func() (*http.Client, error)) (*http.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.clients[name]
+	if ok && cached.key == key {
+		return cached.client, nil
+	}
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		cached.client.CloseIdleConnections()
+	}
+	if c.clients == nil {
+		c.clients = map[types.NamespacedName]cachedTLSClient{}
+	}
+	c.clients[name] = cachedTLSClient{key: key, client: client}
+	return client, nil
+}
+
+// remove drops the client of the instance, if any.
+func (c *tlsClientCache) remove(name types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.clients[name]; ok {
+		cached.client.CloseIdleConnections()
+		delete(c.clients, name)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServerTLSClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	get := func(t *testing.T, client *http.Client) error {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err == nil {
+			closeResponseBody(resp.Body)
+		}
+		return err
+	}
+
+	// The system CA certificates don't trust the test server
+	tlsConfig, err := newServerTLSConfig(nil, false)
+	require.NoError(t, err)
+	require.Error(t, get(t, newTLSHTTPClient(newHTTPClient(), tlsConfig)))
+
+	tlsConfig, err = newServerTLSConfig(caBundle, false)
+	require.NoError(t, err)
+	require.NoError(t, get(t, newTLSHTTPClient(newHTTPClient(), tlsConfig)))
+
+	tlsConfig, err = newServerTLSConfig(nil, true)
+	require.NoError(t, err)
+	require.NoError(t, get(t, newTLSHTTPClient(newHTTPClient(), tlsConfig)))
+
+	_, err = newServerTLSConfig([]byte("not a certificate"), false)
+	require.EqualError(t, err, "failed to parse server CA bundle: no PEM certificate found")
+}
+
+func TestGetServerHTTPClient(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{httpClient: newHTTPClient()}
+	instance := newHealthCheckTestInstance(nil)
+
+	client, err := r.getServerTransportClient(context.Background(), instance)
+	require.NoError(t, err)
+	assert.Same(t, r.httpClient, client, "plain HTTP uses the shared client")
+	assert.Equal(t, "http", r.getServerURL(instance, versionEndpoint).Scheme)
+
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Scheme: corev1.URISchemeHTTPS}
+	client, err = r.getServerTransportClient(context.Background(), instance)
+	require.NoError(t, err)
+	assert.Same(t, r.httpClient, client, "HTTPS with the system CA certificates uses the shared client")
+	assert.Equal(t, "https", r.getServerURL(instance, versionEndpoint).Scheme)
+
+	instance.Spec.Server.HealthCheck.InsecureSkipVerify = true
+	insecure, err := r.getServerTransportClient(context.Background(), instance)
+	require.NoError(t, err)
+	assert.NotSame(t, r.httpClient, insecure)
+	again, err := r.getServerTransportClient(context.Background(), instance)
+	require.NoError(t, err)
+	assert.Same(t, insecure, again, "the dedicated client is reused across reconciles")
+
+	instance.Spec.Server.HealthCheck = nil
+	_, err = r.getServerTransportClient(context.Background(), instance)
+	require.NoError(t, err)
+	assert.NotContains(t, r.tlsClients.clients, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace})
+}
+
+func TestGetServerCABundle(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{httpClient: newHTTPClient()}
+	instance := newHealthCheckTestInstance(nil)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "llsd-tls", Namespace: instance.Namespace},
+		Data:       map[string][]byte{"ca.crt": []byte("secret-ca")},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "llsd-ca", Namespace: instance.Namespace},
+		Data:       map[string]string{DefaultCABundleKey: "configmap-ca"},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, configMap).Build()
+	ctx := context.Background()
+
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{
+		Scheme:   corev1.URISchemeHTTPS,
+		CABundle: &llamav1alpha1.CABundleConfig{ConfigMapName: "llsd-ca"},
+	}
+	caBundle, err := r.getServerCABundle(ctx, instance)
+	require.NoError(t, err)
+	assert.Equal(t, "configmap-ca\n", string(caBundle))
+
+	instance.Spec.Server.HealthCheck.CABundle = nil
+	instance.Spec.Server.HealthCheck.CABundleSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "llsd-tls"},
+		Key:                  "ca.crt",
+	}
+	caBundle, err = r.getServerCABundle(ctx, instance)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-ca", string(caBundle))
+
+	// The certificate of the Secret is verified like the one of a ConfigMap
+	_, err = r.getServerTransportClient(ctx, instance)
+	require.ErrorContains(t, err, "failed to parse server CA bundle")
+
+	instance.Spec.Server.HealthCheck.CABundleSecretRef.Key = "tls.crt"
+	_, err = r.getServerCABundle(ctx, instance)
+	require.ErrorContains(t, err, "failed to find key tls.crt in server CA bundle Secret test-namespace/llsd-tls")
+}
+
+func TestReconcileDeletedInstanceDropsTLSClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:     scheme,
+		httpClient: newHTTPClient(),
+	}
+	instance := newHealthCheckTestInstance(nil)
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{Scheme: corev1.URISchemeHTTPS, InsecureSkipVerify: true}
+	_, err := r.getServerTransportClient(context.Background(), instance)
+	require.NoError(t, err)
+	name := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	require.Contains(t, r.tlsClients.clients, name)
+
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: name})
+	require.NoError(t, err)
+	assert.NotContains(t, r.tlsClients.clients, name, "the client of a deleted instance is dropped")
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// serviceAccountComponent is the component label value of the ServiceAccount created for the server pods,
+// and of its Role and RoleBinding.
+const serviceAccountComponent = "service-account"
+
+// createsServiceAccount reports whether the operator creates the ServiceAccount named in the pod overrides.
+func createsServiceAccount(instance *llamav1alpha1.LlamaStackDistribution) bool {
+	overrides := instance.Spec.Server.PodOverrides
+	return overrides != nil && overrides.CreateServiceAccount && overrides.ServiceAccountName != ""
+}
+
+// validateCreatedServiceAccount checks that the ServiceAccount to create isn't the one rendered from the
+// operator manifests, which both would then update.
+func validateCreatedServiceAccount(instance *llamav1alpha1.LlamaStackDistribution) error {
+	if createsServiceAccount(instance) && instance.Spec.Server.PodOverrides.ServiceAccountName == instance.Name+"-sa" {
+		return fmt.Errorf("failed to validate service account: %s-sa is already created by the operator, "+
+			"unset serviceAccountName to use it", instance.Name)
+	}
+	return nil
+}
+
+// reconcileServiceAccount creates or updates the ServiceAccount named in the pod overrides when the operator
+// is asked to create it, together with the Role and RoleBinding granting it access to the ConfigMaps of the
+// instance. A ServiceAccount that already exists without being controlled by the instance is left untouched
+// and reported instead. The resources created for a ServiceAccount that is no longer asked for, because
+// createServiceAccount was unset or serviceAccountName changed, are deleted.
+func (r *LlamaStackDistributionReconciler) reconcileServiceAccount(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	current := ""
+	if createsServiceAccount(instance) {
+		current = instance.Spec.Server.PodOverrides.ServiceAccountName
+	}
+	if err := r.deleteStaleServiceAccounts(ctx, instance, current); err != nil {
+		return err
+	}
+	if current == "" {
+		return nil
+	}
+
+	serviceAccount := buildServiceAccount(instance)
+	existing := &corev1.ServiceAccount{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(serviceAccount), existing)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get ServiceAccount %s: %w", serviceAccount.Name, err)
+	}
+	if err == nil && !metav1.IsControlledBy(existing, instance) {
+		message := fmt.Sprintf("ServiceAccount %s already exists in namespace %s and isn't owned by this distribution; "+
+			"unset createServiceAccount to use it", serviceAccount.Name, instance.Namespace)
+		SetServiceAccountNotOwnedCondition(instance, message)
+		return fmt.Errorf("failed to create ServiceAccount %s: it already exists and isn't owned by the instance", serviceAccount.Name)
+	}
+
+	logger := log.FromContext(ctx)
+	if err := deploy.ApplyServiceAccount(ctx, r.Client, r.Scheme, instance, serviceAccount, logger); err != nil {
+		return err
+	}
+
+	role, roleBinding := buildServiceAccountRole(instance)
+	if len(role.Rules) == 0 {
+		if err := deploy.DeleteIfControlled(ctx, r.Client, instance, roleBinding, logger); err != nil {
+			return err
+		}
+		return deploy.DeleteIfControlled(ctx, r.Client, instance, role, logger)
+	}
+	if err := deploy.ApplyRole(ctx, r.Client, r.Scheme, instance, role, logger); err != nil {
+		return err
+	}
+	return deploy.ApplyRoleBinding(ctx, r.Client, r.Scheme, instance, roleBinding, logger)
+}
+
+// deleteStaleServiceAccounts deletes the ServiceAccounts, Roles and RoleBindings created for the instance
+// other than the ones named current.
+func (r *LlamaStackDistributionReconciler) deleteStaleServiceAccounts(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	current string) error {
+	logger := log.FromContext(ctx)
+	// The RoleBindings go first, so that no binding is left pointing at a deleted Role or ServiceAccount
+	for _, list := range []client.ObjectList{&rbacv1.RoleBindingList{}, &rbacv1.RoleList{}, &corev1.ServiceAccountList{}} {
+		if err := r.List(ctx, list, client.InNamespace(instance.Namespace), client.MatchingLabels{
+			deploy.InstanceLabelKey:  instance.Name,
+			deploy.ComponentLabelKey: serviceAccountComponent,
+		}); err != nil {
+			return fmt.Errorf("failed to list the created ServiceAccount resources: %w", err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("failed to list the created ServiceAccount resources: %w", err)
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || obj.GetName() == current {
+				continue
+			}
+			if err := deploy.DeleteIfControlled(ctx, r.Client, instance, obj, logger); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getServiceAccountLabels returns the labels of the created ServiceAccount and of its Role and RoleBinding.
+func getServiceAccountLabels(instance *llamav1alpha1.LlamaStackDistribution) map[string]string {
+	return deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+		deploy.InstanceLabelKey:  instance.Name,
+		deploy.ComponentLabelKey: serviceAccountComponent,
+	})
+}
+
+// buildServiceAccount returns the ServiceAccount named in the pod overrides.
+func buildServiceAccount(instance *llamav1alpha1.LlamaStackDistribution) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        instance.Spec.Server.PodOverrides.ServiceAccountName,
+			Namespace:   instance.Namespace,
+			Labels:      getServiceAccountLabels(instance),
+			Annotations: deploy.GetResourceAnnotations(instance),
+		},
+	}
+}
+
+// getConfigAccessRules returns the rules granting get and watch on the ConfigMaps of the instance in its
+// namespace, or nil when config access isn't requested. The ConfigMaps of other namespaces can't be granted
+// by a Role and are skipped.
+func getConfigAccessRules(instance *llamav1alpha1.LlamaStackDistribution) []rbacv1.PolicyRule {
+	if !instance.Spec.Server.PodOverrides.GrantConfigAccess {
+		return nil
+	}
+	var names []string
+	if hasInlineUserConfig(instance) {
+		names = append(names, getInlineUserConfigMapName(instance))
+	} else if hasValidUserConfig(instance) && getUserConfigMapNamespaceStandalone(instance) == instance.Namespace {
+		names = append(names, instance.Spec.Server.UserConfig.ConfigMapName)
+	}
+	if hasValidCABundleConfig(instance) && getCABundleConfigMapNamespaceStandalone(instance) == instance.Namespace {
+		names = append(names, instance.Spec.Server.TLSConfig.CABundle.ConfigMapName)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return []rbacv1.PolicyRule{{
+		APIGroups:     []string{""},
+		Resources:     []string{"configmaps"},
+		ResourceNames: names,
+		Verbs:         []string{"get", "watch"},
+	}}
+}
+
+// buildServiceAccountRole returns the Role holding the config access rules of the created ServiceAccount, and
+// the RoleBinding granting it. Both are named after the ServiceAccount.
+func buildServiceAccountRole(instance *llamav1alpha1.LlamaStackDistribution) (*rbacv1.Role, *rbacv1.RoleBinding) {
+	overrides := instance.Spec.Server.PodOverrides
+	objectMeta := metav1.ObjectMeta{
+		Name:        overrides.ServiceAccountName,
+		Namespace:   instance.Namespace,
+		Labels:      getServiceAccountLabels(instance),
+		Annotations: deploy.GetResourceAnnotations(instance),
+	}
+
+	role := &rbacv1.Role{ObjectMeta: *objectMeta.DeepCopy(), Rules: getConfigAccessRules(instance)}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      overrides.ServiceAccountName,
+			Namespace: instance.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+	}
+	return role, roleBinding
+}
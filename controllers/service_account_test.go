@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestReconcileServiceAccount(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		ServiceAccountName:   "llsd-server",
+		CreateServiceAccount: true,
+		GrantConfigAccess:    true,
+	}
+	instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{Inline: "version: 2\n"}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "llsd-server", Namespace: instance.Namespace}
+
+	require.NoError(t, r.reconcileServiceAccount(ctx, instance))
+	require.NoError(t, r.validateServiceAccount(ctx, instance))
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeServiceAccountReady))
+
+	serviceAccount := &corev1.ServiceAccount{}
+	require.NoError(t, r.Get(ctx, key, serviceAccount))
+	assert.True(t, metav1.IsControlledBy(serviceAccount, instance))
+	assert.Equal(t, "llsd", serviceAccount.Labels[deploy.InstanceLabelKey])
+
+	role := &rbacv1.Role{}
+	require.NoError(t, r.Get(ctx, key, role))
+	assert.Equal(t, []rbacv1.PolicyRule{{
+		APIGroups:     []string{""},
+		Resources:     []string{"configmaps"},
+		ResourceNames: []string{"llsd-user-config"},
+		Verbs:         []string{"get", "watch"},
+	}}, role.Rules)
+	roleBinding := &rbacv1.RoleBinding{}
+	require.NoError(t, r.Get(ctx, key, roleBinding))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "llsd-server"}, roleBinding.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "llsd-server", Namespace: "default"}}, roleBinding.Subjects)
+
+	// The Role and RoleBinding are removed with the config access, the ServiceAccount is kept
+	instance.Spec.Server.PodOverrides.GrantConfigAccess = false
+	require.NoError(t, r.reconcileServiceAccount(ctx, instance))
+	require.NoError(t, r.Get(ctx, key, serviceAccount))
+	assert.True(t, k8serrors.IsNotFound(r.Get(ctx, key, &rbacv1.Role{})))
+	assert.True(t, k8serrors.IsNotFound(r.Get(ctx, key, &rbacv1.RoleBinding{})))
+}
+
+func TestReconcileServiceAccountCleanup(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		ServiceAccountName:   "llsd-server",
+		CreateServiceAccount: true,
+		GrantConfigAccess:    true,
+	}
+	instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{Inline: "version: 2\n"}
+
+	// The ServiceAccount rendered from the manifests shares the instance label and must be kept
+	manifestServiceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name:      "llsd-sa",
+		Namespace: "default",
+		Labels:    map[string]string{deploy.InstanceLabelKey: "llsd"},
+	}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	require.NoError(t, controllerutil.SetControllerReference(instance, manifestServiceAccount, scheme))
+	r := &LlamaStackDistributionReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(manifestServiceAccount).Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+	assertCreated := func(name string, created bool) {
+		t.Helper()
+		key := types.NamespacedName{Name: name, Namespace: "default"}
+		for _, obj := range []client.Object{&corev1.ServiceAccount{}, &rbacv1.Role{}, &rbacv1.RoleBinding{}} {
+			err := r.Get(ctx, key, obj)
+			if created {
+				require.NoError(t, err)
+			} else {
+				assert.True(t, k8serrors.IsNotFound(err), "%T %s should be deleted", obj, name)
+			}
+		}
+	}
+	require.NoError(t, r.reconcileServiceAccount(ctx, instance))
+	assertCreated("llsd-server", true)
+
+	// Renaming the ServiceAccount deletes the resources created for the previous name
+	instance.Spec.Server.PodOverrides.ServiceAccountName = "llsd-runtime"
+	require.NoError(t, r.reconcileServiceAccount(ctx, instance))
+	assertCreated("llsd-server", false)
+	assertCreated("llsd-runtime", true)
+
+	// Unsetting createServiceAccount deletes them all
+	instance.Spec.Server.PodOverrides.CreateServiceAccount = false
+	instance.Spec.Server.PodOverrides.GrantConfigAccess = false
+	require.NoError(t, r.reconcileServiceAccount(ctx, instance))
+	assertCreated("llsd-runtime", false)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(manifestServiceAccount), &corev1.ServiceAccount{}))
+}
+
+func TestReconcileServiceAccountNotOwned(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{
+		ServiceAccountName:   "shared",
+		CreateServiceAccount: true,
+	}
+
+	existing := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build(), Scheme: scheme}
+	ctx := context.Background()
+
+	err := r.reconcileServiceAccount(ctx, instance)
+	require.ErrorContains(t, err, "isn't owned by the instance")
+	condition := GetCondition(&instance.Status, ConditionTypeServiceAccountReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonServiceAccountNotOwned, condition.Reason)
+
+	serviceAccount := &corev1.ServiceAccount{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "shared", Namespace: "default"}, serviceAccount))
+	assert.Empty(t, serviceAccount.OwnerReferences)
+}
+
+func TestGetConfigAccessRules(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{ServiceAccountName: "llsd-server", CreateServiceAccount: true}
+	instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{ConfigMapName: "run-config"}
+	instance.Spec.Server.TLSConfig = &llamav1alpha1.TLSConfig{CABundle: &llamav1alpha1.CABundleConfig{ConfigMapName: "ca-bundle"}}
+	assert.Nil(t, getConfigAccessRules(instance), "no access is granted unless requested")
+
+	instance.Spec.Server.PodOverrides.GrantConfigAccess = true
+	rules := getConfigAccessRules(instance)
+	require.Len(t, rules, 1)
+	assert.Equal(t, []string{"run-config", "ca-bundle"}, rules[0].ResourceNames)
+	assert.Equal(t, []string{"get", "watch"}, rules[0].Verbs)
+
+	// A Role can't grant the ConfigMaps of another namespace
+	instance.Spec.Server.UserConfig.ConfigMapNamespace = "shared"
+	instance.Spec.Server.TLSConfig.CABundle.ConfigMapNamespace = "shared"
+	assert.Nil(t, getConfigAccessRules(instance))
+}
+
+func TestValidateCreatedServiceAccount(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	require.NoError(t, validateCreatedServiceAccount(instance))
+
+	instance.Spec.Server.PodOverrides = &llamav1alpha1.PodOverrides{ServiceAccountName: "llsd-sa", CreateServiceAccount: true}
+	require.ErrorContains(t, validateCreatedServiceAccount(instance), "llsd-sa is already created by the operator")
+
+	instance.Spec.Server.PodOverrides.CreateServiceAccount = false
+	require.NoError(t, validateCreatedServiceAccount(instance))
+}
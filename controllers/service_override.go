@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileServiceOverride checks that the Service referenced by the service override exists and selects the
+// server pods, and deletes the Service the operator created before the override was set. A Service without a
+// selector is accepted, its endpoints being managed by someone else.
+func (r *LlamaStackDistributionReconciler) reconcileServiceOverride(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	override := instance.Spec.Server.ServiceOverride
+	if override == nil {
+		return nil
+	}
+
+	managed := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: deploy.GetManagedServiceName(instance), Namespace: instance.Namespace}}
+	if err := deploy.DeleteServiceIfExists(ctx, r.Client, instance, managed, log.FromContext(ctx)); err != nil {
+		return fmt.Errorf("failed to delete the Service replaced by serviceOverride: %w", err)
+	}
+
+	service := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: override.Name, Namespace: instance.Namespace}, service); err != nil {
+		if k8serrors.IsNotFound(err) {
+			message := fmt.Sprintf("Service %s referenced in serviceOverride not found in namespace %s", override.Name, instance.Namespace)
+			SetServiceReadyCondition(instance, false, message)
+			return fmt.Errorf("failed to find Service %s in namespace %s", override.Name, instance.Namespace)
+		}
+		return fmt.Errorf("failed to get Service %s: %w", override.Name, err)
+	}
+
+	if len(service.Spec.Selector) > 0 && !labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(getPodTemplateLabels(instance))) {
+		message := fmt.Sprintf("Service %s referenced in serviceOverride doesn't select the server pods", override.Name)
+		SetServiceReadyCondition(instance, false, message)
+		return fmt.Errorf("failed to validate Service %s: its selector %s doesn't select the server pods", override.Name,
+			labels.SelectorFromSet(service.Spec.Selector))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileServiceOverride(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.ServiceOverride = &llamav1alpha1.ServiceOverrideSpec{Name: "mesh-llsd"}
+	assert.Equal(t, "mesh-llsd", deploy.GetServiceName(instance))
+	assert.Contains(t, (&LlamaStackDistributionReconciler{}).determineKindsToExclude(instance), "Service")
+
+	managed := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      deploy.GetManagedServiceName(instance),
+		Namespace: instance.Namespace,
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: llamav1alpha1.GroupVersion.String(), Kind: "LlamaStackDistribution",
+			Name: instance.Name, UID: instance.UID, Controller: ptr.To(true),
+		}},
+	}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(managed).Build()}
+	ctx := context.Background()
+
+	// The referenced Service must exist
+	err := r.reconcileServiceOverride(ctx, instance)
+	require.ErrorContains(t, err, "failed to find Service mesh-llsd")
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeServiceReady))
+	err = r.Get(ctx, client.ObjectKeyFromObject(managed), &corev1.Service{})
+	assert.True(t, k8serrors.IsNotFound(err), "the Service created by the operator is replaced")
+
+	// Its selector must select the server pods
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh-llsd", Namespace: instance.Namespace},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{deploy.InstanceLabelKey: "other"}},
+	}
+	require.NoError(t, r.Create(ctx, service))
+	err = r.reconcileServiceOverride(ctx, instance)
+	require.ErrorContains(t, err, "doesn't select the server pods")
+
+	service.Spec.Selector = getPodSelectorLabels(instance)
+	require.NoError(t, r.Update(ctx, service))
+	require.NoError(t, r.reconcileServiceOverride(ctx, instance))
+
+	// Services without a selector have their endpoints managed by someone else
+	service.Spec.Selector = nil
+	require.NoError(t, r.Update(ctx, service))
+	require.NoError(t, r.reconcileServiceOverride(ctx, instance))
+}
+
+func TestReconcileStorageWithServiceOverride(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.UID = "llsd-uid"
+	instance.Spec.Server.ContainerSpec.Port = 8321
+	instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{}
+	instance.Spec.Server.ServiceOverride = &llamav1alpha1.ServiceOverrideSpec{Name: "mesh-llsd"}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	r := &LlamaStackDistributionReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+
+	// Only the PVC is applied with the storage, the Service replaced by the override is never created
+	require.NoError(t, r.reconcileStorage(ctx, instance))
+	pvc := &corev1.PersistentVolumeClaim{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Name: getPVCName(instance), Namespace: instance.Namespace}, pvc))
+	err := r.Get(ctx, client.ObjectKey{Name: deploy.GetManagedServiceName(instance), Namespace: instance.Namespace}, &corev1.Service{})
+	assert.True(t, k8serrors.IsNotFound(err), "the storage must not recreate the Service replaced by the override")
+}
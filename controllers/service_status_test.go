@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// renderTestService returns the server Service rendered from the manifests of the instance.
+func renderTestService(t *testing.T, r *LlamaStackDistributionReconciler, instance *llamav1alpha1.LlamaStackDistribution) *corev1.Service {
+	t.Helper()
+	resMap, err := r.renderManifestResources(instance)
+	require.NoError(t, err)
+	var service *corev1.Service
+	for _, res := range (*resMap).Resources() {
+		if res.GetKind() != "Service" {
+			continue
+		}
+		data, err := res.MarshalJSON()
+		require.NoError(t, err)
+		service = &corev1.Service{}
+		require.NoError(t, json.Unmarshal(data, service))
+	}
+	require.NotNil(t, service, "the manifests should render a Service")
+	return service
+}
+
+// TestServiceNameConsistency checks that the status looks up the Service under the name it is rendered with.
+func TestServiceNameConsistency(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.ContainerSpec.Port = 8321
+	r := &LlamaStackDistributionReconciler{}
+
+	service := renderTestService(t, r, instance)
+	assert.Equal(t, deploy.GetServiceName(instance), service.Name)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	service.Namespace = instance.Namespace
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+	r.updateServiceStatus(context.Background(), instance)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeServiceReady), "the status should find the rendered Service")
+	require.Len(t, instance.Status.Services, 1)
+	assert.Equal(t, service.Name, instance.Status.Services[0].Name)
+}
+
+func TestLoadBalancerService(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.ContainerSpec.Port = 8321
+	r := &LlamaStackDistributionReconciler{}
+
+	assert.Equal(t, corev1.ServiceTypeClusterIP, renderTestService(t, r, instance).Spec.Type)
+
+	instance.Spec.Server.Service = &llamav1alpha1.ServiceSpec{
+		Type:                  corev1.ServiceTypeLoadBalancer,
+		LoadBalancerClass:     ptr.To("example.com/metallb"),
+		ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+	}
+	service := renderTestService(t, r, instance)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+	assert.Equal(t, ptr.To("example.com/metallb"), service.Spec.LoadBalancerClass)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyLocal, service.Spec.ExternalTrafficPolicy)
+
+	// The status reports the endpoints assigned to the Service
+	service.Namespace = instance.Namespace
+	service.Spec.Ports[0].NodePort = 30321
+	service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "192.0.2.10"}, {Hostname: "llsd.example.com"}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+	r.updateServiceStatus(context.Background(), instance)
+	require.Len(t, instance.Status.Services, 1)
+	assert.Equal(t, llamav1alpha1.ServiceStatus{
+		Name:              service.Name,
+		Exposure:          llamav1alpha1.PortExposurePublic,
+		Ports:             []int32{8321},
+		Type:              corev1.ServiceTypeLoadBalancer,
+		NodePorts:         []int32{30321},
+		ExternalAddresses: []string{"192.0.2.10", "llsd.example.com"},
+	}, instance.Status.Services[0])
+}
+
+func TestHeadlessService(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.ContainerSpec.Port = 8321
+	r := &LlamaStackDistributionReconciler{}
+
+	service := renderTestService(t, r, instance)
+	assert.Empty(t, service.Spec.ClusterIP)
+	assert.False(t, service.Spec.PublishNotReadyAddresses)
+
+	instance.Spec.Server.Service = &llamav1alpha1.ServiceSpec{
+		Type:                     corev1.ServiceTypeClusterIP,
+		Headless:                 true,
+		PublishNotReadyAddresses: true,
+	}
+	service = renderTestService(t, r, instance)
+	assert.Equal(t, corev1.ClusterIPNone, service.Spec.ClusterIP)
+	assert.True(t, service.Spec.PublishNotReadyAddresses)
+
+	service.Namespace = instance.Namespace
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+	r.updateServiceStatus(context.Background(), instance)
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeServiceReady))
+	require.Len(t, instance.Status.Services, 1)
+	assert.True(t, instance.Status.Services[0].Headless)
+}
+
+func TestServiceSessionAffinity(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.ContainerSpec.Port = 8321
+	r := &LlamaStackDistributionReconciler{}
+
+	service := renderTestService(t, r, instance)
+	assert.Empty(t, service.Spec.SessionAffinity, "the API server defaults the session affinity to None")
+	assert.Nil(t, service.Spec.SessionAffinityConfig)
+
+	instance.Spec.Server.Service = &llamav1alpha1.ServiceSpec{SessionAffinity: corev1.ServiceAffinityClientIP}
+	service = renderTestService(t, r, instance)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, service.Spec.SessionAffinity)
+	assert.Nil(t, service.Spec.SessionAffinityConfig, "the API server defaults the timeout")
+
+	instance.Spec.Server.Service.SessionAffinityTimeoutSeconds = ptr.To(int32(600))
+	service = renderTestService(t, r, instance)
+	require.NotNil(t, service.Spec.SessionAffinityConfig)
+	assert.Equal(t, ptr.To(int32(600)), service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultMetricsPath is the HTTP path of the server metrics when none is configured.
+const defaultMetricsPath = "/metrics"
+
+// serviceMonitorsSupported reports whether the Prometheus Operator CRDs are installed in the cluster.
+func (r *LlamaStackDistributionReconciler) serviceMonitorsSupported() bool {
+	return r.ClusterInfo != nil && r.ClusterInfo.ServiceMonitorsSupported
+}
+
+// metricsEnabled reports whether the instance requests the scraping of the server metrics.
+func metricsEnabled(instance *llamav1alpha1.LlamaStackDistribution) bool {
+	return instance.Spec.Server.Metrics != nil && instance.Spec.Server.Metrics.Enabled
+}
+
+// reconcileServiceMonitor creates or updates the ServiceMonitor scraping the server Services when metrics are
+// enabled, and deletes it otherwise. Nothing is done on clusters without the Prometheus Operator.
+func (r *LlamaStackDistributionReconciler) reconcileServiceMonitor(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	if !r.serviceMonitorsSupported() {
+		if metricsEnabled(instance) {
+			r.serviceMonitorsUnsupported.Do(func() {
+				log.FromContext(ctx).Info("metrics are enabled but the cluster has no Prometheus Operator CRDs, skipping ServiceMonitors")
+			})
+		}
+		return nil
+	}
+	serviceMonitor := buildServiceMonitor(instance)
+	if !metricsEnabled(instance) {
+		return deploy.DeleteIfControlled(ctx, r.Client, instance, serviceMonitor, log.FromContext(ctx))
+	}
+	return deploy.ApplyServiceMonitor(ctx, r.Client, r.Scheme, instance, serviceMonitor, log.FromContext(ctx))
+}
+
+// buildServiceMonitor returns the ServiceMonitor scraping the metrics port of the Services of the instance.
+// The Services are selected by instance, the port name picks the one serving the metrics.
+func buildServiceMonitor(instance *llamav1alpha1.LlamaStackDistribution) *unstructured.Unstructured {
+	serviceMonitor := &unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(deploy.ServiceMonitorGVK)
+	serviceMonitor.SetName(instance.Name)
+	serviceMonitor.SetNamespace(instance.Namespace)
+	if !metricsEnabled(instance) {
+		return serviceMonitor
+	}
+
+	metrics := instance.Spec.Server.Metrics
+	endpoint := map[string]any{
+		"port": metrics.Port,
+		"path": metrics.Path,
+	}
+	if metrics.Port == "" {
+		endpoint["port"] = llamav1alpha1.DefaultServicePortName
+	}
+	if metrics.Path == "" {
+		endpoint["path"] = defaultMetricsPath
+	}
+	if metrics.Interval != "" {
+		endpoint["interval"] = metrics.Interval
+	}
+
+	serviceMonitor.SetLabels(deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+		deploy.InstanceLabelKey: instance.Name,
+	}))
+	serviceMonitor.SetAnnotations(deploy.GetResourceAnnotations(instance))
+	serviceMonitor.Object["spec"] = map[string]any{
+		"selector": map[string]any{
+			"matchLabels": map[string]any{deploy.InstanceLabelKey: instance.Name},
+		},
+		"namespaceSelector": map[string]any{
+			"matchNames": []any{instance.Namespace},
+		},
+		"endpoints": []any{endpoint},
+	}
+	return serviceMonitor
+}
+
+// updateMetricsStatus reports whether a ServiceMonitor scrapes the server metrics.
+func (r *LlamaStackDistributionReconciler) updateMetricsStatus(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) {
+	if !metricsEnabled(instance) {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, ConditionTypeMetricsReady)
+		return
+	}
+	if !r.serviceMonitorsSupported() {
+		SetMetricsReadyCondition(instance, false, "ServiceMonitors are not supported by the cluster, which doesn't have the Prometheus Operator CRDs")
+		return
+	}
+
+	serviceMonitor := &unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(deploy.ServiceMonitorGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, serviceMonitor); err != nil {
+		SetMetricsReadyCondition(instance, false, fmt.Sprintf("Failed to get ServiceMonitor: %v", err))
+		return
+	}
+	SetMetricsReadyCondition(instance, true, "")
+}
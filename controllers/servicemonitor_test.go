@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuildServiceMonitor(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.Metrics = &llamav1alpha1.MetricsSpec{Enabled: true}
+
+	serviceMonitor := buildServiceMonitor(instance)
+	assert.Equal(t, deploy.ServiceMonitorGVK, serviceMonitor.GroupVersionKind())
+	assert.Equal(t, "llsd", serviceMonitor.GetLabels()[deploy.InstanceLabelKey])
+	assert.Equal(t, map[string]any{
+		"selector":          map[string]any{"matchLabels": map[string]any{deploy.InstanceLabelKey: "llsd"}},
+		"namespaceSelector": map[string]any{"matchNames": []any{"default"}},
+		"endpoints":         []any{map[string]any{"port": "http", "path": "/metrics"}},
+	}, serviceMonitor.Object["spec"])
+	assert.NotContains(t, (&LlamaStackDistributionReconciler{}).determineKindsToExclude(instance), "Service", "the ServiceMonitor needs the Service")
+
+	instance.Spec.Server.Metrics = &llamav1alpha1.MetricsSpec{Enabled: true, Path: "/prometheus", Port: "metrics", Interval: "15s"}
+	serviceMonitor = buildServiceMonitor(instance)
+	endpoints, _, err := unstructured.NestedSlice(serviceMonitor.Object, "spec", "endpoints")
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"port": "metrics", "path": "/prometheus", "interval": "15s"}}, endpoints)
+
+	instance.Spec.Server.Metrics.Enabled = false
+	assert.NotContains(t, buildServiceMonitor(instance).Object, "spec")
+}
+
+func TestServiceMonitorWithoutPrometheusOperator(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{}
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.Metrics = &llamav1alpha1.MetricsSpec{Enabled: true}
+
+	require.NoError(t, r.reconcileServiceMonitor(context.Background(), instance))
+	r.updateMetricsStatus(context.Background(), instance)
+	condition := GetCondition(&instance.Status, ConditionTypeMetricsReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonServiceMonitorNotReady, condition.Reason)
+
+	instance.Spec.Server.Metrics.Enabled = false
+	r.updateMetricsStatus(context.Background(), instance)
+	assert.Nil(t, GetCondition(&instance.Status, ConditionTypeMetricsReady))
+}
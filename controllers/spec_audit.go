@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/audit"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// specAuditGenerationAnnotation is the annotation of the audit ConfigMap holding the last audited generation.
+	specAuditGenerationAnnotation = "llamastack.io/audited-generation"
+	// specAuditSpecKey is the key of the audit ConfigMap data holding the last audited spec, as JSON. The spec is
+	// kept in the data rather than in an annotation, which is limited to 256KiB with all the other annotations.
+	specAuditSpecKey = "audited-spec"
+	// legacySpecAuditSpecAnnotation is the annotation that held the last audited spec before it moved to the data.
+	legacySpecAuditSpecAnnotation = "llamastack.io/audited-spec"
+	// maxAuditEventMessageLength keeps audit Events below the API server limit for Event messages.
+	maxAuditEventMessageLength = 1024
+	// reasonSpecChanged is the reason of the audit Event recorded for a spec change.
+	reasonSpecChanged = "SpecChanged"
+)
+
+// parseSpecAudit extracts and validates the spec audit configuration from ConfigMap data.
+// A nil configuration disables auditing.
+func parseSpecAudit(configMapData map[string]string) (*audit.Config, error) {
+	auditYAML, exists := configMapData[audit.ConfigKey]
+	if !exists || strings.TrimSpace(auditYAML) == "" {
+		return nil, nil
+	}
+
+	auditConfig := &audit.Config{}
+	if err := yaml.Unmarshal([]byte(auditYAML), auditConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse spec audit: %w", err)
+	}
+	if err := auditConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	return auditConfig, nil
+}
+
+// RecordSpecChange records the spec diff of the instance since the last audited generation to the configured
+// audit sink. The last audited spec is kept in the audit ConfigMap, so that the changes made while the operator
+// was down are recorded too. The first reconcile of an instance only records the baseline. The ConfigMap sink
+// only keeps the most recent records: the oldest are dropped beyond maxEntries or audit.MaxDataSize. It is a
+// no-op when spec auditing is disabled.
+func (r *LlamaStackDistributionReconciler) RecordSpecChange(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	if r.SpecAudit == nil {
+		return nil
+	}
+	if r.SpecAudit.Sink != audit.SinkEvent && r.SpecAudit.Sink != audit.SinkConfigMap {
+		return fmt.Errorf("failed to record spec change: unsupported sink %q", r.SpecAudit.Sink)
+	}
+
+	spec, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	generation := strconv.FormatInt(instance.Generation, 10)
+
+	var eventRecord *audit.Record
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		eventRecord = nil
+		configMap, err := r.getSpecAuditConfigMap(ctx, instance)
+		if err != nil {
+			return err
+		}
+		if configMap.Annotations[specAuditGenerationAnnotation] == generation {
+			return nil
+		}
+
+		// The baseline is set aside while the records are appended, so that it is never dropped as an old record
+		previous, ok := configMap.Data[specAuditSpecKey]
+		if !ok {
+			previous, ok = configMap.Annotations[legacySpecAuditSpecAnnotation]
+		}
+		delete(configMap.Data, specAuditSpecKey)
+		delete(configMap.Annotations, legacySpecAuditSpecAnnotation)
+		if ok {
+			diff, err := diffAuditedSpec(previous, spec)
+			if err != nil {
+				return err
+			}
+			if diff != "" {
+				record := audit.NewRecord(instance, diff, time.Now())
+				if r.SpecAudit.Sink == audit.SinkEvent {
+					eventRecord = &record
+				} else if configMap.Data, err = audit.Append(configMap.Data, record, r.SpecAudit.MaxEntries); err != nil {
+					return err
+				}
+			}
+		}
+
+		if configMap.Annotations == nil {
+			configMap.Annotations = map[string]string{}
+		}
+		configMap.Annotations[specAuditGenerationAnnotation] = generation
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[specAuditSpecKey] = string(spec)
+		if configMap.ResourceVersion == "" {
+			return r.Create(ctx, configMap)
+		}
+		return r.Update(ctx, configMap)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record spec change to the spec audit ConfigMap: %w", err)
+	}
+
+	// The Event is only emitted once the change is recorded as audited, so that a conflict never repeats it
+	if eventRecord != nil {
+		return r.recordSpecChangeEvent(instance, *eventRecord)
+	}
+	return nil
+}
+
+// getSpecAuditConfigMap returns the audit ConfigMap of the instance, or a new one owned by the instance when it
+// doesn't exist yet.
+func (r *LlamaStackDistributionReconciler) getSpecAuditConfigMap(ctx context.Context,
+	instance *llamav1alpha1.LlamaStackDistribution) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: instance.Name + audit.ConfigMapSuffix, Namespace: instance.Namespace}
+	err := r.Get(ctx, key, configMap)
+	if err == nil {
+		return configMap, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get spec audit ConfigMap: %w", err)
+	}
+
+	configMap = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				deploy.ManagedByLabelKey: deploy.ManagedByLabelValue,
+				deploy.InstanceLabelKey:  instance.Name,
+			},
+		},
+	}
+	if err = controllerutil.SetControllerReference(instance, configMap, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	return configMap, nil
+}
+
+// diffAuditedSpec returns the diff between the last audited spec and the current one, both JSON encoded. Both
+// are decoded the same way, so that encoding details such as empty and nil slices don't show up in the diff.
+func diffAuditedSpec(previous string, current []byte) (string, error) {
+	previousSpec := llamav1alpha1.LlamaStackDistributionSpec{}
+	if err := json.Unmarshal([]byte(previous), &previousSpec); err != nil {
+		return "", fmt.Errorf("failed to unmarshal the last audited spec: %w", err)
+	}
+	currentSpec := llamav1alpha1.LlamaStackDistributionSpec{}
+	if err := json.Unmarshal(current, &currentSpec); err != nil {
+		return "", fmt.Errorf("failed to unmarshal spec: %w", err)
+	}
+	return cmp.Diff(previousSpec, currentSpec), nil
+}
+
+// recordSpecChangeEvent emits the record as a structured Event on the instance.
+// Long diffs are truncated to fit in the Event message.
+func (r *LlamaStackDistributionReconciler) recordSpecChangeEvent(instance *llamav1alpha1.LlamaStackDistribution, record audit.Record) error {
+	if r.Recorder == nil {
+		return fmt.Errorf("failed to record spec change: no event recorder configured")
+	}
+
+	message, err := record.MarshalWithin(maxAuditEventMessageLength)
+	if err != nil {
+		return err
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, reasonSpecChanged, message)
+	return nil
+}
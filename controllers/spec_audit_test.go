@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseSpecAudit(t *testing.T) {
+	config, err := parseSpecAudit(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, config, "auditing should be disabled without configuration")
+
+	config, err = parseSpecAudit(map[string]string{audit.ConfigKey: "sink: ConfigMap\nmaxEntries: 10\n"})
+	require.NoError(t, err)
+	assert.Equal(t, &audit.Config{Sink: audit.SinkConfigMap, MaxEntries: 10}, config)
+
+	_, err = parseSpecAudit(map[string]string{audit.ConfigKey: "sink: Syslog\n"})
+	require.Error(t, err)
+}
+
+func TestRecordSpecChangeEvent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(2)
+	r := &LlamaStackDistributionReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:    scheme,
+		SpecAudit: &audit.Config{Sink: audit.SinkEvent},
+		Recorder:  recorder,
+	}
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Generation = 1
+	ctx := context.Background()
+
+	// The first reconcile only records the baseline
+	require.NoError(t, r.RecordSpecChange(ctx, instance))
+	assert.Empty(t, recorder.Events)
+
+	instance.Generation = 2
+	for i := range 100 {
+		instance.Spec.Server.ContainerSpec.Env = append(instance.Spec.Server.ContainerSpec.Env,
+			corev1.EnvVar{Name: fmt.Sprintf("VAR_%d", i), Value: "value"})
+	}
+	require.NoError(t, r.RecordSpecChange(ctx, instance))
+	// The generation is only recorded once
+	require.NoError(t, r.RecordSpecChange(ctx, instance))
+	require.Len(t, recorder.Events, 1)
+
+	event := <-recorder.Events
+	prefix := "Normal " + reasonSpecChanged + " "
+	require.True(t, strings.HasPrefix(event, prefix))
+	message := strings.TrimPrefix(event, prefix)
+	assert.LessOrEqual(t, len(message), maxAuditEventMessageLength, "long diffs should be truncated")
+
+	var auditRecord audit.Record
+	require.NoError(t, json.Unmarshal([]byte(message), &auditRecord), "the Event message should stay valid JSON")
+	assert.Equal(t, "llsd", auditRecord.Name)
+	assert.Equal(t, int64(2), auditRecord.Generation)
+	assert.Contains(t, auditRecord.Diff, "VAR_0")
+	assert.True(t, strings.HasSuffix(auditRecord.Diff, "..."))
+}
+
+func TestRecordSpecChangeWhileDown(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	r := &LlamaStackDistributionReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:    scheme,
+		SpecAudit: &audit.Config{Sink: audit.SinkConfigMap, MaxEntries: 10},
+	}
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Generation = 1
+	ctx := context.Background()
+	require.NoError(t, r.RecordSpecChange(ctx, instance))
+
+	// Several changes are only seen by the next reconcile, e.g. after a restart of the operator
+	instance.Generation = 3
+	instance.Spec.Replicas = ptr.To[int32](3)
+	instance.Spec.Server.ContainerSpec.Env = []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}
+	require.NoError(t, r.RecordSpecChange(ctx, instance))
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "llsd" + audit.ConfigMapSuffix, Namespace: "default"}, configMap))
+	require.Len(t, configMap.Data, 2, "the changes since the last audited generation are recorded at once")
+	assert.Equal(t, "3", configMap.Annotations[specAuditGenerationAnnotation])
+	spec, err := json.Marshal(instance.Spec)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(spec), configMap.Data[specAuditSpecKey], "the baseline should be kept in the data")
+	for key, value := range configMap.Data {
+		if key == specAuditSpecKey {
+			continue
+		}
+		var auditRecord audit.Record
+		require.NoError(t, json.Unmarshal([]byte(value), &auditRecord))
+		assert.Equal(t, int64(3), auditRecord.Generation)
+		assert.Contains(t, auditRecord.Diff, "Replicas")
+		assert.Contains(t, auditRecord.Diff, "LOG_LEVEL")
+	}
+}
+
+func TestRecordSpecChangeKeepsBaseline(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Generation = 2
+	instance.Spec.Replicas = ptr.To[int32](2)
+	previous, err := json.Marshal(createLSD("", "test-image:latest").Spec)
+	require.NoError(t, err)
+
+	// A ConfigMap written by an older operator keeps the baseline in an annotation
+	legacy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "llsd" + audit.ConfigMapSuffix,
+			Namespace: "default",
+			Annotations: map[string]string{
+				specAuditGenerationAnnotation: "1",
+				legacySpecAuditSpecAnnotation: string(previous),
+			},
+		},
+	}
+	r := &LlamaStackDistributionReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(legacy).Build(),
+		Scheme:    scheme,
+		SpecAudit: &audit.Config{Sink: audit.SinkConfigMap, MaxEntries: 1},
+	}
+	ctx := context.Background()
+	require.NoError(t, r.RecordSpecChange(ctx, instance))
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: "llsd" + audit.ConfigMapSuffix, Namespace: "default"}
+	require.NoError(t, r.Get(ctx, key, configMap))
+	assert.NotContains(t, configMap.Annotations, legacySpecAuditSpecAnnotation, "the baseline should move to the data")
+	require.Len(t, configMap.Data, 2, "the change against the legacy baseline should be recorded")
+
+	// The oldest records are dropped beyond maxEntries, but never the baseline
+	instance.Generation = 3
+	instance.Spec.Replicas = ptr.To[int32](3)
+	require.NoError(t, r.RecordSpecChange(ctx, instance))
+	require.NoError(t, r.Get(ctx, key, configMap))
+	require.Len(t, configMap.Data, 2)
+	assert.Contains(t, configMap.Data, specAuditSpecKey)
+	for key, value := range configMap.Data {
+		if key != specAuditSpecKey {
+			assert.Contains(t, value, `"generation":3`)
+		}
+	}
+}
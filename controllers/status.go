@@ -1,7 +1,11 @@
 package controllers
 
 import (
+	"fmt"
+	"time"
+
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,6 +19,49 @@ const (
 	ConditionTypeStorageReady = "StorageReady"
 	// ConditionTypeServiceReady indicates whether the service is ready.
 	ConditionTypeServiceReady = "ServiceReady"
+	// ConditionTypeServiceAccountReady indicates whether the ServiceAccount used by the pods exists.
+	ConditionTypeServiceAccountReady = "ServiceAccountReady"
+	// ConditionTypeSelectorValid indicates whether the Deployment selector is free of overlaps with other Deployments.
+	ConditionTypeSelectorValid = "SelectorValid"
+	// ConditionTypeHostPortsAvailable indicates whether the ports bound in the host network are free of collisions.
+	ConditionTypeHostPortsAvailable = "HostPortsAvailable"
+	// ConditionTypeDistributionSource indicates whether the server image is resolved from the distribution catalog.
+	ConditionTypeDistributionSource = "DistributionSource"
+	// ConditionTypeRolledBack indicates whether the Deployment runs the last working pod template after a failed rollout.
+	ConditionTypeRolledBack = "RolledBack"
+	// ConditionTypeStable indicates whether the distribution stayed Ready for the configured stability period.
+	ConditionTypeStable = "Stable"
+	// ConditionTypePortsValid indicates whether the server ports are valid and can be bound by the server.
+	ConditionTypePortsValid = "PortsValid"
+	// ConditionTypeNetworkPolicyValid indicates whether the NetworkPolicy selectors match the operator namespace.
+	ConditionTypeNetworkPolicyValid = "NetworkPolicyValid"
+	// ConditionTypeTemplateApplied indicates whether the Deployment runs the pod template of the current spec,
+	// or the changes are deferred until the maintenance window opens.
+	ConditionTypeTemplateApplied = "TemplateApplied"
+	// ConditionTypeVersionSkew indicates whether the server runs a version outside of the range supported by the operator.
+	ConditionTypeVersionSkew = "VersionSkew"
+	// ConditionTypePreStartJobComplete indicates whether the pre-start Job of the pod template succeeded.
+	ConditionTypePreStartJobComplete = "PreStartJobComplete"
+	// ConditionTypeThrottled indicates whether the API server throttled the requests of the last reconcile.
+	ConditionTypeThrottled = "Throttled"
+	// ConditionTypeAutoscalingReady indicates whether the HorizontalPodAutoscaler is able to scale the Deployment.
+	ConditionTypeAutoscalingReady = "AutoscalingReady"
+	// ConditionTypeRouteReady indicates whether a router admitted the Route exposing the server.
+	ConditionTypeRouteReady = "RouteReady"
+	// ConditionTypeIngressReady indicates whether the ingress controller serves the Ingress exposing the server.
+	ConditionTypeIngressReady = "IngressReady"
+	// ConditionTypeMetricsReady indicates whether a ServiceMonitor scrapes the server metrics.
+	ConditionTypeMetricsReady = "MetricsReady"
+	// ConditionTypeProvidersHealthy indicates whether every provider of the distribution reports healthy.
+	ConditionTypeProvidersHealthy = "ProvidersHealthy"
+	// ConditionTypeUpgradeInProgress indicates whether a new server image is verified on a canary pod before the rollout.
+	ConditionTypeUpgradeInProgress = "UpgradeInProgress"
+	// ConditionTypeSpecValid indicates whether the spec passed the validation of the operator.
+	ConditionTypeSpecValid = "SpecValid"
+	// ConditionTypeRequiredProvidersAvailable indicates whether the server reports every required provider.
+	ConditionTypeRequiredProvidersAvailable = "RequiredProvidersAvailable"
+	// ConditionTypePriorityClassAvailable indicates whether the PriorityClass of the server pods exists.
+	ConditionTypePriorityClassAvailable = "PriorityClassAvailable"
 )
 
 // Condition reasons.
@@ -25,6 +72,8 @@ const (
 	ReasonDeploymentFailed = "DeploymentFailed"
 	// ReasonDeploymentPending indicates the deployment is pending.
 	ReasonDeploymentPending = "DeploymentPending"
+	// ReasonDeploymentScalingDown indicates the desired replicas are ready while extra replicas are being removed.
+	ReasonDeploymentScalingDown = "DeploymentScalingDown"
 	// ReasonHealthCheckPassed indicates the health check passed.
 	ReasonHealthCheckPassed = "HealthCheckPassed"
 	// ReasonHealthCheckFailed indicates the health check failed.
@@ -33,10 +82,104 @@ const (
 	ReasonStorageReady = "StorageReady"
 	// ReasonStorageFailed indicates the storage failed.
 	ReasonStorageFailed = "StorageFailed"
+	// ReasonStorageWaitingForConsumer indicates the PVC waits for a pod to be scheduled before binding.
+	ReasonStorageWaitingForConsumer = "WaitingForFirstConsumer"
 	// ReasonServiceReady indicates the service is ready.
 	ReasonServiceReady = "ServiceReady"
 	// ReasonServiceFailed indicates the service failed.
 	ReasonServiceFailed = "ServiceFailed"
+	// ReasonServiceAccountReady indicates the ServiceAccount exists.
+	ReasonServiceAccountReady = "ServiceAccountReady"
+	// ReasonServiceAccountNotFound indicates the referenced ServiceAccount does not exist.
+	ReasonServiceAccountNotFound = "ServiceAccountNotFound"
+	// ReasonServiceAccountNotOwned indicates the ServiceAccount to create already exists and isn't owned by the instance.
+	ReasonServiceAccountNotOwned = "ServiceAccountNotOwned"
+	// ReasonSelectorValid indicates the Deployment selector does not overlap with other Deployments.
+	ReasonSelectorValid = "SelectorValid"
+	// ReasonSelectorConflict indicates the Deployment selector overlaps with another Deployment.
+	ReasonSelectorConflict = "SelectorConflict"
+	// ReasonHostPortsAvailable indicates the ports bound in the host network don't collide with other instances.
+	ReasonHostPortsAvailable = "HostPortsAvailable"
+	// ReasonHostPortConflict indicates a port bound in the host network collides with another instance.
+	ReasonHostPortConflict = "HostPortConflict"
+	// ReasonDistributionCatalog indicates the server image is resolved from the distribution catalog.
+	ReasonDistributionCatalog = "Catalog"
+	// ReasonDistributionCustom indicates the server image is set directly in the spec.
+	ReasonDistributionCustom = "Custom"
+	// ReasonRolledBack indicates the failed rollout was rolled back to the last working pod template.
+	ReasonRolledBack = "ProgressDeadlineExceeded"
+	// ReasonNotRolledBack indicates the Deployment runs the pod template of the current spec.
+	ReasonNotRolledBack = "NotRolledBack"
+	// ReasonStable indicates the distribution stayed Ready for the configured stability period.
+	ReasonStable = "Stable"
+	// ReasonStabilizing indicates the distribution is not Ready, or not Ready for long enough yet.
+	ReasonStabilizing = "Stabilizing"
+	// ReasonPortsValid indicates the server ports are valid and can be bound by the server.
+	ReasonPortsValid = "PortsValid"
+	// ReasonPortInvalid indicates a server port is out of range, or privileged without the capability to bind it.
+	ReasonPortInvalid = "PortInvalid"
+	// ReasonNetworkPolicyValid indicates the NetworkPolicy selectors match the operator namespace.
+	ReasonNetworkPolicyValid = "NetworkPolicyValid"
+	// ReasonNamespaceLabelMissing indicates the operator namespace lacks the label matched by the NetworkPolicy.
+	ReasonNamespaceLabelMissing = "NamespaceLabelMissing"
+	// ReasonOperatorNamespaceNotFound indicates the operator namespace, whose label the NetworkPolicy matches, doesn't exist.
+	ReasonOperatorNamespaceNotFound = "OperatorNamespaceNotFound"
+	// ReasonTemplateApplied indicates the Deployment runs the pod template of the current spec.
+	ReasonTemplateApplied = "TemplateApplied"
+	// ReasonMaintenanceWindowClosed indicates the pod template changes wait for the maintenance window to open.
+	ReasonMaintenanceWindowClosed = "MaintenanceWindowClosed"
+	// ReasonSupportedServerVersion indicates the server version is within the range supported by the operator.
+	ReasonSupportedServerVersion = "SupportedVersion"
+	// ReasonUnsupportedServerVersion indicates the server version is outside of the range supported by the operator.
+	ReasonUnsupportedServerVersion = "UnsupportedVersion"
+	// ReasonPreStartJobSucceeded indicates the pre-start Job of the pod template succeeded.
+	ReasonPreStartJobSucceeded = "PreStartJobSucceeded"
+	// ReasonPreStartJobRunning indicates the rollout waits for the pre-start Job to complete.
+	ReasonPreStartJobRunning = "PreStartJobRunning"
+	// ReasonPreStartJobFailed indicates the pre-start Job failed and the rollout is held.
+	ReasonPreStartJobFailed = "PreStartJobFailed"
+	// ReasonThrottled indicates the API server rejected a request of the last reconcile with 429 Too Many Requests.
+	ReasonThrottled = "TooManyRequests"
+	// ReasonNotThrottled indicates the last reconcile was not throttled by the API server.
+	ReasonNotThrottled = "NotThrottled"
+	// ReasonAutoscalingReady indicates the HorizontalPodAutoscaler is able to scale the Deployment.
+	ReasonAutoscalingReady = "AutoscalingReady"
+	// ReasonAutoscalingNotReady indicates the HorizontalPodAutoscaler is missing, or can't compute the replica count.
+	ReasonAutoscalingNotReady = "AutoscalingNotReady"
+	// ReasonRouteAdmitted indicates a router admitted the Route.
+	ReasonRouteAdmitted = "RouteAdmitted"
+	// ReasonRouteNotAdmitted indicates the Route is missing, unsupported, or wasn't admitted by any router.
+	ReasonRouteNotAdmitted = "RouteNotAdmitted"
+	// ReasonIngressReady indicates the ingress controller assigned an address to the Ingress.
+	ReasonIngressReady = "IngressReady"
+	// ReasonIngressNotReady indicates the Ingress is missing or has no address yet.
+	ReasonIngressNotReady = "IngressNotReady"
+	// ReasonServiceMonitorReady indicates the ServiceMonitor scraping the server exists.
+	ReasonServiceMonitorReady = "ServiceMonitorReady"
+	// ReasonServiceMonitorNotReady indicates the ServiceMonitor is missing or unsupported by the cluster.
+	ReasonServiceMonitorNotReady = "ServiceMonitorNotReady"
+	// ReasonProvidersHealthy indicates no provider reports an error.
+	ReasonProvidersHealthy = "ProvidersHealthy"
+	// ReasonProvidersUnhealthy indicates providers report an error, or their health is unknown.
+	ReasonProvidersUnhealthy = "ProvidersUnhealthy"
+	// ReasonCanaryVerifying indicates the new server image is verified on a canary pod.
+	ReasonCanaryVerifying = "CanaryVerifying"
+	// ReasonCanaryFailed indicates the canary pod failed the checks and the upgrade is held.
+	ReasonCanaryFailed = "CanaryFailed"
+	// ReasonUpgradeComplete indicates the Deployment runs the server image of the current spec.
+	ReasonUpgradeComplete = "UpgradeComplete"
+	// ReasonSpecValid indicates the spec passed the validation of the operator.
+	ReasonSpecValid = "SpecValid"
+	// ReasonInvalidSpec indicates the spec failed the validation of the operator, and nothing is deployed for it.
+	ReasonInvalidSpec = "InvalidSpec"
+	// ReasonRequiredProvidersAvailable indicates the server reports every required provider.
+	ReasonRequiredProvidersAvailable = "RequiredProvidersAvailable"
+	// ReasonRequiredProvidersMissing indicates required providers are missing, or the providers are unknown.
+	ReasonRequiredProvidersMissing = "RequiredProvidersMissing"
+	// ReasonPriorityClassAvailable indicates the PriorityClass of the server pods exists.
+	ReasonPriorityClassAvailable = "PriorityClassAvailable"
+	// ReasonPriorityClassNotFound indicates the PriorityClass of the server pods doesn't exist.
+	ReasonPriorityClassNotFound = "PriorityClassNotFound"
 )
 
 // Condition messages.
@@ -55,16 +198,65 @@ const (
 	MessageStorageReady = "Storage is ready"
 	// MessageStorageFailed indicates the storage failed.
 	MessageStorageFailed = "Storage failed"
+	// MessageStorageWaitingForConsumer indicates the PVC waits for a pod to be scheduled before binding.
+	MessageStorageWaitingForConsumer = "PVC is waiting for the first consumer to be scheduled before binding"
 	// MessageServiceReady indicates the service is ready.
 	MessageServiceReady = "Service is ready"
 	// MessageServiceFailed indicates the service failed.
 	MessageServiceFailed = "Service failed"
+	// MessageServiceAccountReady indicates the ServiceAccount exists.
+	MessageServiceAccountReady = "ServiceAccount is ready"
+	// MessageSelectorValid indicates the Deployment selector does not overlap with other Deployments.
+	MessageSelectorValid = "Deployment selector does not overlap with other Deployments"
+	// MessageHostPortsAvailable indicates the ports bound in the host network don't collide with other instances.
+	MessageHostPortsAvailable = "Host ports are available"
+	// MessageDistributionCatalog indicates the server image is resolved from the distribution catalog.
+	MessageDistributionCatalog = "Server image is resolved from the distribution catalog"
+	// MessageDistributionCustom indicates the server image is set directly in the spec.
+	MessageDistributionCustom = "Server image is a custom image set in distribution.image"
+	// MessageRolledBack indicates the failed rollout was rolled back to the last working pod template.
+	MessageRolledBack = "Rollout exceeded its progress deadline and was rolled back to the last working pod template, update the spec to retry"
+	// MessageNotRolledBack indicates the Deployment runs the pod template of the current spec.
+	MessageNotRolledBack = "Deployment runs the pod template of the current spec"
+	// MessageStable indicates the distribution stayed Ready for the configured stability period.
+	MessageStable = "Distribution stayed Ready for the stability period"
+	// MessagePortsValid indicates the server ports are valid and can be bound by the server.
+	MessagePortsValid = "Ports are valid"
+	// MessageNetworkPolicyValid indicates the NetworkPolicy selectors match the operator namespace.
+	MessageNetworkPolicyValid = "NetworkPolicy allows ingress from the operator namespace"
+	// MessageTemplateApplied indicates the Deployment runs the pod template of the current spec.
+	MessageTemplateApplied = "Deployment runs the pod template of the current spec"
+	// MessageSupportedServerVersion indicates the server version is within the range supported by the operator.
+	MessageSupportedServerVersion = "Server version is supported by the operator"
+	// MessagePreStartJobSucceeded indicates the pre-start Job of the pod template succeeded.
+	MessagePreStartJobSucceeded = "Pre-start Job succeeded"
+	// MessageNotThrottled indicates the last reconcile was not throttled by the API server.
+	MessageNotThrottled = "Requests to the API server are not throttled"
+	// MessageAutoscalingReady indicates the HorizontalPodAutoscaler is able to scale the Deployment.
+	MessageAutoscalingReady = "HorizontalPodAutoscaler is scaling the Deployment"
+	// MessageRouteReady indicates a router admitted the Route.
+	MessageRouteReady = "Route is admitted by the router"
+	// MessageIngressReady indicates the ingress controller assigned an address to the Ingress.
+	MessageIngressReady = "Ingress is served by the ingress controller"
+	// MessageMetricsReady indicates the ServiceMonitor scraping the server exists.
+	MessageMetricsReady = "ServiceMonitor is configured to scrape the server metrics"
+	// MessageProvidersHealthy indicates no provider reports an error.
+	MessageProvidersHealthy = "All providers are healthy"
+	// MessageUpgradeComplete indicates the Deployment runs the server image of the current spec.
+	MessageUpgradeComplete = "Deployment runs the server image of the current spec"
+	// MessageSpecValid indicates the spec passed the validation of the operator.
+	MessageSpecValid = "Spec is valid"
+	// MessageRequiredProvidersAvailable indicates the server reports every required provider.
+	MessageRequiredProvidersAvailable = "All required providers are available"
+	// MessagePriorityClassAvailable indicates the PriorityClass of the server pods exists.
+	MessagePriorityClassAvailable = "PriorityClass of the server pods exists"
 )
 
 // SetDeploymentReadyCondition sets the deployment ready condition.
-func SetDeploymentReadyCondition(status *llamav1alpha1.LlamaStackDistributionStatus, ready bool, message string) {
+func SetDeploymentReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
 	condition := metav1.Condition{
 		Type:               ConditionTypeDeploymentReady,
+		ObservedGeneration: instance.Generation,
 		Status:             metav1.ConditionTrue,
 		Reason:             ReasonDeploymentReady,
 		Message:            MessageDeploymentReady,
@@ -77,13 +269,44 @@ func SetDeploymentReadyCondition(status *llamav1alpha1.LlamaStackDistributionSta
 		condition.Message = message
 	}
 
-	SetCondition(status, condition)
+	SetCondition(&instance.Status, condition)
+}
+
+// SetDeploymentInvalidSpecCondition marks the deployment failed because the spec failed validation.
+func SetDeploymentInvalidSpecCondition(instance *llamav1alpha1.LlamaStackDistribution, message string) {
+	SetCondition(&instance.Status, metav1.Condition{
+		Type:               ConditionTypeDeploymentReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonInvalidSpec,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	})
+}
+
+// SetDeploymentScalingDownCondition marks the deployment ready while replicas above the desired count are removed.
+func SetDeploymentScalingDownCondition(instance *llamav1alpha1.LlamaStackDistribution, message string) {
+	SetCondition(&instance.Status, metav1.Condition{
+		Type:               ConditionTypeDeploymentReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonDeploymentScalingDown,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	})
+}
+
+// IsScalingDown returns true when the deployment is removing replicas above the desired count.
+func IsScalingDown(status *llamav1alpha1.LlamaStackDistributionStatus) bool {
+	condition := GetCondition(status, ConditionTypeDeploymentReady)
+	return condition != nil && condition.Reason == ReasonDeploymentScalingDown
 }
 
 // SetHealthCheckCondition sets the health check condition.
-func SetHealthCheckCondition(status *llamav1alpha1.LlamaStackDistributionStatus, healthy bool, message string) {
+func SetHealthCheckCondition(instance *llamav1alpha1.LlamaStackDistribution, healthy bool, message string) {
 	condition := metav1.Condition{
 		Type:               ConditionTypeHealthCheck,
+		ObservedGeneration: instance.Generation,
 		Status:             metav1.ConditionTrue,
 		Reason:             ReasonHealthCheckPassed,
 		Message:            MessageHealthCheckPassed,
@@ -96,13 +319,14 @@ func SetHealthCheckCondition(status *llamav1alpha1.LlamaStackDistributionStatus,
 		condition.Message = message
 	}
 
-	SetCondition(status, condition)
+	SetCondition(&instance.Status, condition)
 }
 
 // SetStorageReadyCondition sets the storage ready condition.
-func SetStorageReadyCondition(status *llamav1alpha1.LlamaStackDistributionStatus, ready bool, message string) {
+func SetStorageReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
 	condition := metav1.Condition{
 		Type:               ConditionTypeStorageReady,
+		ObservedGeneration: instance.Generation,
 		Status:             metav1.ConditionTrue,
 		Reason:             ReasonStorageReady,
 		Message:            MessageStorageReady,
@@ -115,13 +339,27 @@ func SetStorageReadyCondition(status *llamav1alpha1.LlamaStackDistributionStatus
 		condition.Message = message
 	}
 
-	SetCondition(status, condition)
+	SetCondition(&instance.Status, condition)
+}
+
+// SetStorageWaitingForConsumerCondition marks the storage as not yet known while a PVC using the
+// WaitForFirstConsumer binding mode waits for its pod to be scheduled. This is not a failure.
+func SetStorageWaitingForConsumerCondition(instance *llamav1alpha1.LlamaStackDistribution) {
+	SetCondition(&instance.Status, metav1.Condition{
+		Type:               ConditionTypeStorageReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionUnknown,
+		Reason:             ReasonStorageWaitingForConsumer,
+		Message:            MessageStorageWaitingForConsumer,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	})
 }
 
 // SetServiceReadyCondition sets the service ready condition.
-func SetServiceReadyCondition(status *llamav1alpha1.LlamaStackDistributionStatus, ready bool, message string) {
+func SetServiceReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
 	condition := metav1.Condition{
 		Type:               ConditionTypeServiceReady,
+		ObservedGeneration: instance.Generation,
 		Status:             metav1.ConditionTrue,
 		Reason:             ReasonServiceReady,
 		Message:            MessageServiceReady,
@@ -134,7 +372,153 @@ func SetServiceReadyCondition(status *llamav1alpha1.LlamaStackDistributionStatus
 		condition.Message = message
 	}
 
-	SetCondition(status, condition)
+	SetCondition(&instance.Status, condition)
+}
+
+// SetServiceAccountReadyCondition sets the ServiceAccount ready condition.
+func SetServiceAccountReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeServiceAccountReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonServiceAccountReady,
+		Message:            MessageServiceAccountReady,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !ready {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonServiceAccountNotFound
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetServiceAccountNotOwnedCondition marks the ServiceAccount not ready because the operator won't take over
+// a ServiceAccount it didn't create.
+func SetServiceAccountNotOwnedCondition(instance *llamav1alpha1.LlamaStackDistribution, message string) {
+	SetCondition(&instance.Status, metav1.Condition{
+		Type:               ConditionTypeServiceAccountReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonServiceAccountNotOwned,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	})
+}
+
+// SetSelectorValidCondition sets the selector valid condition.
+func SetSelectorValidCondition(instance *llamav1alpha1.LlamaStackDistribution, valid bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeSelectorValid,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonSelectorValid,
+		Message:            MessageSelectorValid,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !valid {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonSelectorConflict
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetHostPortsAvailableCondition sets the host ports available condition.
+func SetHostPortsAvailableCondition(instance *llamav1alpha1.LlamaStackDistribution, available bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeHostPortsAvailable,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonHostPortsAvailable,
+		Message:            MessageHostPortsAvailable,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !available {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonHostPortConflict
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetPortsValidCondition sets the ports valid condition.
+func SetPortsValidCondition(instance *llamav1alpha1.LlamaStackDistribution, valid bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypePortsValid,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonPortsValid,
+		Message:            MessagePortsValid,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !valid {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonPortInvalid
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetSpecValidCondition sets the spec valid condition, with the validation error as message when invalid.
+func SetSpecValidCondition(instance *llamav1alpha1.LlamaStackDistribution, valid bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeSpecValid,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonSpecValid,
+		Message:            MessageSpecValid,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !valid {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonInvalidSpec
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetNetworkPolicyValidCondition sets the network policy valid condition.
+func SetNetworkPolicyValidCondition(instance *llamav1alpha1.LlamaStackDistribution, valid bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeNetworkPolicyValid,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonNetworkPolicyValid,
+		Message:            MessageNetworkPolicyValid,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !valid {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonNamespaceLabelMissing
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetOperatorNamespaceNotFoundCondition marks the NetworkPolicy invalid because the operator namespace, whose
+// label the NetworkPolicy matches, doesn't exist.
+func SetOperatorNamespaceNotFoundCondition(instance *llamav1alpha1.LlamaStackDistribution, message string) {
+	SetCondition(&instance.Status, metav1.Condition{
+		Type:               ConditionTypeNetworkPolicyValid,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonOperatorNamespaceNotFound,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	})
 }
 
 // SetCondition sets a condition in the status.
@@ -181,3 +565,422 @@ func IsConditionFalse(status *llamav1alpha1.LlamaStackDistributionStatus, condit
 	condition := GetCondition(status, conditionType)
 	return condition != nil && condition.Status == metav1.ConditionFalse
 }
+
+// getReplicaStatus derives the readiness of a single pod from its Ready condition. A pod whose server container
+// is ready and whose readiness gates pass is ready as well, whatever the state of its sidecars. The reason is
+// taken from the most specific source available: termination, a waiting container, the Ready condition, then
+// the pod phase.
+func getReplicaStatus(pod *corev1.Pod, containerName string) llamav1alpha1.ReplicaStatus {
+	status := llamav1alpha1.ReplicaStatus{PodName: pod.Name}
+
+	if !pod.DeletionTimestamp.IsZero() {
+		status.Reason = "Terminating"
+		return status
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodReady {
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue {
+			status.Ready = true
+			return status
+		}
+		status.Reason = condition.Reason
+	}
+
+	if isServerContainerReady(pod, containerName) && areReadinessGatesPassed(pod) {
+		status.Ready = true
+		status.Reason = ""
+		return status
+	}
+
+	for _, containerStatuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, containerStatus := range containerStatuses {
+			if containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason != "" {
+				status.Reason = containerStatus.State.Waiting.Reason
+				return status
+			}
+		}
+	}
+
+	if status.Reason == "" {
+		status.Reason = string(pod.Status.Phase)
+	}
+	return status
+}
+
+// isServerContainerReady returns whether the server container of the pod passes its readiness probe.
+func isServerContainerReady(pod *corev1.Pod, containerName string) bool {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == containerName {
+			return containerStatus.Ready
+		}
+	}
+	return false
+}
+
+// areReadinessGatesPassed returns whether every readiness gate of the pod has its condition set to true.
+func areReadinessGatesPassed(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		passed := false
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType {
+				passed = condition.Status == corev1.ConditionTrue
+				break
+			}
+		}
+		if !passed {
+			return false
+		}
+	}
+	return true
+}
+
+// SetDistributionSourceCondition sets the distribution source condition. The condition is true when
+// the server image comes from the distribution catalog and false when it is a custom image.
+func SetDistributionSourceCondition(instance *llamav1alpha1.LlamaStackDistribution, fromCatalog bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeDistributionSource,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonDistributionCatalog,
+		Message:            MessageDistributionCatalog,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !fromCatalog {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonDistributionCustom
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetRolledBackCondition sets the rolled back condition.
+func SetRolledBackCondition(instance *llamav1alpha1.LlamaStackDistribution, rolledBack bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeRolledBack,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonRolledBack,
+		Message:            MessageRolledBack,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !rolledBack {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonNotRolledBack
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetStableCondition sets the stable condition.
+func SetStableCondition(instance *llamav1alpha1.LlamaStackDistribution, stable bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeStable,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonStable,
+		Message:            MessageStable,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !stable {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonStabilizing
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetTemplateAppliedCondition sets the template applied condition.
+func SetTemplateAppliedCondition(instance *llamav1alpha1.LlamaStackDistribution, applied bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeTemplateApplied,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonTemplateApplied,
+		Message:            MessageTemplateApplied,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !applied {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonMaintenanceWindowClosed
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetVersionSkewCondition sets the version skew condition, which is true when the server version is unsupported.
+func SetVersionSkewCondition(instance *llamav1alpha1.LlamaStackDistribution, skewed bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeVersionSkew,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonSupportedServerVersion,
+		Message:            MessageSupportedServerVersion,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if skewed {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonUnsupportedServerVersion
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetPreStartJobCompleteCondition sets the pre-start Job complete condition.
+func SetPreStartJobCompleteCondition(instance *llamav1alpha1.LlamaStackDistribution, complete bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypePreStartJobComplete,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonPreStartJobSucceeded,
+		Message:            MessagePreStartJobSucceeded,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !complete {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonPreStartJobRunning
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetPreStartJobFailedCondition marks the pre-start Job failed, holding the rollout.
+func SetPreStartJobFailedCondition(instance *llamav1alpha1.LlamaStackDistribution, message string) {
+	SetCondition(&instance.Status, metav1.Condition{
+		Type:               ConditionTypePreStartJobComplete,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonPreStartJobFailed,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	})
+}
+
+// SetUpgradeInProgressCondition sets the upgrade in progress condition, which is true while a new server image
+// is verified on a canary pod.
+func SetUpgradeInProgressCondition(instance *llamav1alpha1.LlamaStackDistribution, inProgress bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeUpgradeInProgress,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonUpgradeComplete,
+		Message:            MessageUpgradeComplete,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if inProgress {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonCanaryVerifying
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetUpgradeCanaryFailedCondition marks the canary pod failed, holding the upgrade.
+func SetUpgradeCanaryFailedCondition(instance *llamav1alpha1.LlamaStackDistribution, message string) {
+	SetCondition(&instance.Status, metav1.Condition{
+		Type:               ConditionTypeUpgradeInProgress,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonCanaryFailed,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	})
+}
+
+// SetThrottledCondition sets the throttled condition, which is true when the API server throttled the reconcile.
+func SetThrottledCondition(instance *llamav1alpha1.LlamaStackDistribution, throttled bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeThrottled,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonNotThrottled,
+		Message:            MessageNotThrottled,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if throttled {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonThrottled
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetAutoscalingReadyCondition sets the autoscaling ready condition.
+func SetAutoscalingReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeAutoscalingReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonAutoscalingReady,
+		Message:            MessageAutoscalingReady,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !ready {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonAutoscalingNotReady
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetRouteReadyCondition sets the route ready condition.
+func SetRouteReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeRouteReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonRouteAdmitted,
+		Message:            MessageRouteReady,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !ready {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonRouteNotAdmitted
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetIngressReadyCondition sets the ingress ready condition.
+func SetIngressReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeIngressReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonIngressReady,
+		Message:            MessageIngressReady,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !ready {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonIngressNotReady
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetMetricsReadyCondition sets the metrics ready condition.
+func SetMetricsReadyCondition(instance *llamav1alpha1.LlamaStackDistribution, ready bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeMetricsReady,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonServiceMonitorReady,
+		Message:            MessageMetricsReady,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !ready {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonServiceMonitorNotReady
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetRequiredProvidersAvailableCondition sets the required providers available condition.
+func SetRequiredProvidersAvailableCondition(instance *llamav1alpha1.LlamaStackDistribution, available bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeRequiredProvidersAvailable,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonRequiredProvidersAvailable,
+		Message:            MessageRequiredProvidersAvailable,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !available {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonRequiredProvidersMissing
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetProvidersHealthyCondition sets the providers healthy condition.
+func SetProvidersHealthyCondition(instance *llamav1alpha1.LlamaStackDistribution, healthy bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeProvidersHealthy,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonProvidersHealthy,
+		Message:            MessageProvidersHealthy,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !healthy {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonProvidersUnhealthy
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// SetPriorityClassAvailableCondition sets the priority class available condition.
+func SetPriorityClassAvailableCondition(instance *llamav1alpha1.LlamaStackDistribution, available bool, message string) {
+	condition := metav1.Condition{
+		Type:               ConditionTypePriorityClassAvailable,
+		ObservedGeneration: instance.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonPriorityClassAvailable,
+		Message:            MessagePriorityClassAvailable,
+		LastTransitionTime: metav1.NewTime(metav1.Now().UTC()),
+	}
+
+	if !available {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonPriorityClassNotFound
+		condition.Message = message
+	}
+
+	SetCondition(&instance.Status, condition)
+}
+
+// readyDepartureConditionTypes lists, by priority, the conditions explaining why a distribution left Ready.
+var readyDepartureConditionTypes = []string{ConditionTypeDeploymentReady, ConditionTypeHealthCheck, ConditionTypeProvidersHealthy}
+
+// recordReadyDeparture records the cause of a transition from the Ready phase to another phase, so that it
+// outlives the condition messages overwritten by later reconciles.
+func recordReadyDeparture(status *llamav1alpha1.LlamaStackDistributionStatus, previousPhase llamav1alpha1.DistributionPhase, now time.Time) {
+	if previousPhase != llamav1alpha1.LlamaStackDistributionPhaseReady || status.Phase == llamav1alpha1.LlamaStackDistributionPhaseReady {
+		return
+	}
+
+	status.LastTransitionReason = string(status.Phase)
+	status.LastTransitionMessage = fmt.Sprintf("Distribution left Ready for %s", status.Phase)
+	for _, conditionType := range readyDepartureConditionTypes {
+		if condition := GetCondition(status, conditionType); condition != nil && condition.Status == metav1.ConditionFalse {
+			status.LastTransitionReason = condition.Reason
+			status.LastTransitionMessage = condition.Message
+			break
+		}
+	}
+	status.LastTransitionTime = &metav1.Time{Time: now}
+}
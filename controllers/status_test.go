@@ -0,0 +1,343 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestGetReplicaStatus(t *testing.T) {
+	now := metav1.Now()
+
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected llamav1alpha1.ReplicaStatus
+	}{
+		{
+			name: "ready pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-a"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-a", Ready: true},
+		},
+		{
+			name: "waiting container reason takes precedence",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-b"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-b", Reason: "ImagePullBackOff"},
+		},
+		{
+			name: "waiting init container",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-c"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					InitContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-c", Reason: "PodInitializing"},
+		},
+		{
+			name: "ready condition reason",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-d"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-d", Reason: "ContainersNotReady"},
+		},
+		{
+			name: "falls back to pod phase",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-e"},
+				Status:     corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-e", Reason: string(corev1.PodPending)},
+		},
+		{
+			name: "ready server container with a failing sidecar",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-g"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: llamav1alpha1.DefaultContainerName, Ready: true},
+						{Name: "log-shipper", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-g", Ready: true},
+		},
+		{
+			name: "ready server container with a pending readiness gate",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-h"},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{{ConditionType: "target-health.elbv2.k8s.aws/llama"}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ReadinessGatesNotReady"},
+						{Type: "target-health.elbv2.k8s.aws/llama", Status: corev1.ConditionFalse},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: llamav1alpha1.DefaultContainerName, Ready: true},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-h", Reason: "ReadinessGatesNotReady"},
+		},
+		{
+			name: "ready server container with a passed readiness gate and a failing sidecar",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-i"},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{{ConditionType: "target-health.elbv2.k8s.aws/llama"}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"},
+						{Type: "target-health.elbv2.k8s.aws/llama", Status: corev1.ConditionTrue},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: llamav1alpha1.DefaultContainerName, Ready: true},
+						{Name: "log-shipper", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-i", Ready: true},
+		},
+		{
+			name: "terminating pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-f", DeletionTimestamp: &now},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+			expected: llamav1alpha1.ReplicaStatus{PodName: "pod-f", Reason: "Terminating"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getReplicaStatus(tc.pod, llamav1alpha1.DefaultContainerName))
+		})
+	}
+}
+
+func TestUpdateDistributionConfigSource(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{ClusterInfo: setupTestClusterInfo(nil)}
+
+	tests := []struct {
+		name           string
+		distribution   llamav1alpha1.DistributionType
+		expectedActive string
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "catalog distribution",
+			distribution:   llamav1alpha1.DistributionType{Name: "ollama"},
+			expectedActive: "ollama",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: ReasonDistributionCatalog,
+		},
+		{
+			name:           "custom image",
+			distribution:   llamav1alpha1.DistributionType{Image: "quay.io/example/llama-stack:custom"},
+			expectedActive: "custom",
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: ReasonDistributionCustom,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &llamav1alpha1.LlamaStackDistribution{}
+			instance.Spec.Server.Distribution = tc.distribution
+
+			r.updateDistributionConfig(instance)
+
+			assert.Equal(t, tc.expectedActive, instance.Status.DistributionConfig.ActiveDistribution)
+			condition := GetCondition(&instance.Status, ConditionTypeDistributionSource)
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, tc.expectedStatus, condition.Status)
+				assert.Equal(t, tc.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestUpdateStability(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{Recorder: recorder}
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	instance.Spec.Server.HealthCheck = &llamav1alpha1.HealthCheckSpec{StableAfter: &metav1.Duration{Duration: 5 * time.Minute}}
+	start := time.Now()
+
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	r.updateStability(instance, start)
+	require.NotNil(t, instance.Status.ReadySince)
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeStable))
+	assert.Equal(t, 5*time.Minute, getStabilityRemaining(instance, start))
+
+	r.updateStability(instance, start.Add(5*time.Minute))
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeStable))
+	assert.Zero(t, getStabilityRemaining(instance, start.Add(5*time.Minute)))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, ReasonStable)
+
+	// No new Event while it stays stable
+	r.updateStability(instance, start.Add(10*time.Minute))
+	assert.Empty(t, recorder.Events)
+
+	// A flap restarts the period
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
+	r.updateStability(instance, start.Add(11*time.Minute))
+	assert.Nil(t, instance.Status.ReadySince)
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeStable))
+
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	r.updateStability(instance, start.Add(12*time.Minute))
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeStable))
+	assert.Equal(t, 5*time.Minute, getStabilityRemaining(instance, start.Add(12*time.Minute)))
+}
+
+func TestUpdateStabilityNotConfigured(t *testing.T) {
+	r := &LlamaStackDistributionReconciler{}
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+	instance.Status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+
+	r.updateStability(instance, time.Now())
+
+	assert.NotNil(t, instance.Status.ReadySince)
+	assert.Nil(t, GetCondition(&instance.Status, ConditionTypeStable))
+	assert.Zero(t, getStabilityRemaining(instance, time.Now()))
+}
+
+func TestRecordReadyDeparture(t *testing.T) {
+	now := time.Now()
+
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		Status: llamav1alpha1.LlamaStackDistributionStatus{Phase: llamav1alpha1.LlamaStackDistributionPhaseFailed},
+	}
+	status := &instance.Status
+	SetDeploymentReadyCondition(instance, true, MessageDeploymentReady)
+	SetHealthCheckCondition(instance, false, "connection refused")
+	recordReadyDeparture(status, llamav1alpha1.LlamaStackDistributionPhaseReady, now)
+	assert.Equal(t, ReasonHealthCheckFailed, status.LastTransitionReason)
+	assert.Equal(t, "connection refused", status.LastTransitionMessage)
+	require.NotNil(t, status.LastTransitionTime)
+
+	// The record outlives the recovery and later transitions between other phases
+	status.Phase = llamav1alpha1.LlamaStackDistributionPhaseReady
+	SetHealthCheckCondition(instance, true, MessageHealthCheckPassed)
+	recordReadyDeparture(status, llamav1alpha1.LlamaStackDistributionPhaseFailed, now)
+	recordReadyDeparture(status, llamav1alpha1.LlamaStackDistributionPhaseReady, now)
+	assert.Equal(t, ReasonHealthCheckFailed, status.LastTransitionReason)
+
+	// The Deployment condition takes precedence
+	status.Phase = llamav1alpha1.LlamaStackDistributionPhaseInitializing
+	SetDeploymentReadyCondition(instance, false, "Deployment is scaling: 1/2 replicas ready")
+	recordReadyDeparture(status, llamav1alpha1.LlamaStackDistributionPhaseReady, now)
+	assert.Equal(t, ReasonDeploymentFailed, status.LastTransitionReason)
+	assert.Equal(t, "Deployment is scaling: 1/2 replicas ready", status.LastTransitionMessage)
+}
+
+func TestObservedGeneration(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Generation = 2
+	SetHealthCheckCondition(instance, true, MessageHealthCheckPassed)
+	instance.Status.ObservedGeneration = 2
+
+	// A condition carries the generation it was computed from, the ones left over keep theirs
+	instance.Generation = 3
+	SetDeploymentReadyCondition(instance, false, MessageDeploymentPending)
+	assert.Equal(t, int64(3), GetCondition(&instance.Status, ConditionTypeDeploymentReady).ObservedGeneration)
+	assert.Equal(t, int64(2), GetCondition(&instance.Status, ConditionTypeHealthCheck).ObservedGeneration)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+	updateErr := errors.New("etcd unavailable")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).WithStatusSubresource(instance).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				return updateErr
+			},
+		}).Build()
+	r := &LlamaStackDistributionReconciler{Client: c, Scheme: scheme}
+	invalidSpec := &invalidSpecError{err: errors.New("invalid port")}
+
+	// The generation isn't observed until the status computed from it is stored
+	err := r.updateStatus(context.Background(), instance, invalidSpec)
+	require.ErrorIs(t, err, updateErr)
+	assert.Equal(t, int64(2), instance.Status.ObservedGeneration)
+
+	updateErr = nil
+	require.NoError(t, r.updateStatus(context.Background(), instance, invalidSpec))
+	assert.Equal(t, int64(3), instance.Status.ObservedGeneration)
+}
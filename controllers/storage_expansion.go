@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// getDesiredStorageSize returns the size of the PVC requested by the spec.
+func getDesiredStorageSize(instance *llamav1alpha1.LlamaStackDistribution) resource.Quantity {
+	return instance.Spec.Server.Storage.GetSize()
+}
+
+// expandPVC requests the size of the spec from the existing PVC when it grew and the StorageClass of the PVC
+// allows volume expansion. PVCs can't shrink, so smaller sizes are ignored, like the other changes to the PVC.
+func (r *LlamaStackDistributionReconciler) expandPVC(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	logger := log.FromContext(ctx)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: getPVCName(instance), Namespace: instance.Namespace}, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get PVC: %w", err)
+	}
+
+	desired := getDesiredStorageSize(instance)
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if desired.Cmp(current) == 0 {
+		return nil
+	}
+	if desired.Cmp(current) < 0 {
+		logger.Info("ignoring storage size smaller than the PVC, PVCs can't shrink",
+			"pvc", pvc.Name, "size", current.String(), "requested", desired.String())
+		return nil
+	}
+
+	storageClass, err := r.getStorageClass(ctx, pvc.Spec.StorageClassName)
+	if err != nil {
+		return err
+	}
+	if storageClass == nil || storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		logger.Info("ignoring storage size increase, the StorageClass of the PVC doesn't allow volume expansion",
+			"pvc", pvc.Name, "size", current.String(), "requested", desired.String())
+		return nil
+	}
+
+	patch := client.MergeFrom(pvc.DeepCopy())
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = desired
+	if err := r.Patch(ctx, pvc, patch); err != nil {
+		return fmt.Errorf("failed to expand PVC: %w", err)
+	}
+	logger.Info("expanding PVC", "pvc", pvc.Name, "from", current.String(), "to", desired.String())
+	r.recordEvent(instance, corev1.EventTypeNormal, reasonStorageResized,
+		fmt.Sprintf("Expanding PersistentVolumeClaim %s from %s to %s", pvc.Name, current.String(), desired.String()))
+	return nil
+}
+
+// getPVCExpansion describes the expansion of a bound PVC whose capacity is smaller than its request, or returns
+// an empty string when the PVC isn't expanding.
+func getPVCExpansion(pvc *corev1.PersistentVolumeClaim) string {
+	requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return ""
+	}
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok || capacity.Cmp(requested) >= 0 {
+		return ""
+	}
+	return fmt.Sprintf("PVC is expanding from %s to %s", capacity.String(), requested.String())
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExpandPVC(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		allowVolumeExpansion *bool
+		size                 string
+		expectedSize         string
+		expectedEvent        bool
+	}{
+		{name: "expands on increase", allowVolumeExpansion: ptr.To(true), size: "20Gi", expectedSize: "20Gi", expectedEvent: true},
+		{name: "never shrinks", allowVolumeExpansion: ptr.To(true), size: "5Gi", expectedSize: "10Gi"},
+		{name: "keeps the size when the class doesn't allow expansion", allowVolumeExpansion: ptr.To(false), size: "20Gi", expectedSize: "10Gi"},
+		{name: "keeps the size when the class doesn't say", size: "20Gi", expectedSize: "10Gi"},
+		{name: "keeps an unchanged size", allowVolumeExpansion: ptr.To(true), size: "10Gi", expectedSize: "10Gi"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, llamav1alpha1.AddToScheme(scheme))
+
+			instance := createLSD("", "test-image:latest")
+			instance.Name = "llsd"
+			instance.Namespace = "default"
+			instance.Spec.Server.Storage = &llamav1alpha1.StorageSpec{
+				Size:             ptr.To(resource.MustParse(tc.size)),
+				StorageClassName: ptr.To("fast"),
+			}
+			storageClass := &storagev1.StorageClass{
+				ObjectMeta:           metav1.ObjectMeta{Name: "fast"},
+				Provisioner:          "example.com/fast",
+				AllowVolumeExpansion: tc.allowVolumeExpansion,
+			}
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: getPVCName(instance), Namespace: instance.Namespace},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: ptr.To("fast"),
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					},
+				},
+			}
+			recorder := record.NewFakeRecorder(1)
+			r := &LlamaStackDistributionReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(storageClass, pvc).Build(),
+				Scheme:   scheme,
+				Recorder: recorder,
+			}
+
+			require.NoError(t, r.expandPVC(context.Background(), instance))
+
+			updated := &corev1.PersistentVolumeClaim{}
+			require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(pvc), updated))
+			requested := updated.Spec.Resources.Requests[corev1.ResourceStorage]
+			assert.Equal(t, tc.expectedSize, requested.String())
+			if tc.expectedEvent {
+				require.Len(t, recorder.Events, 1)
+				assert.Equal(t, "Normal StorageResized Expanding PersistentVolumeClaim llsd-pvc from 10Gi to 20Gi", <-recorder.Events)
+			} else {
+				assert.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestGetPVCExpansion(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase:    corev1.ClaimBound,
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+		},
+	}
+	assert.Equal(t, "PVC is expanding from 10Gi to 20Gi", getPVCExpansion(pvc))
+
+	pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20Gi")
+	assert.Empty(t, getPVCExpansion(pvc))
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -48,7 +49,7 @@ func NewDistributionBuilder() *DistributionBuilder {
 				Namespace: "default", // Will be overridden in tests
 			},
 			Spec: llamav1alpha1.LlamaStackDistributionSpec{
-				Replicas: 1,
+				Replicas: ptr.To(int32(1)),
 				Server: llamav1alpha1.ServerSpec{
 					Distribution: llamav1alpha1.DistributionType{
 						Name: "starter", // Real distribution from distributions.json
@@ -79,7 +80,7 @@ func (b *DistributionBuilder) WithPort(port int32) *DistributionBuilder {
 }
 
 func (b *DistributionBuilder) WithReplicas(replicas int32) *DistributionBuilder {
-	b.instance.Spec.Replicas = replicas
+	b.instance.Spec.Replicas = &replicas
 	return b
 }
 
@@ -106,6 +107,11 @@ func (b *DistributionBuilder) WithServiceAccountName(serviceAccountName string)
 	return b
 }
 
+func (b *DistributionBuilder) WithAdditionalPorts(ports ...llamav1alpha1.PortSpec) *DistributionBuilder {
+	b.instance.Spec.Server.ContainerSpec.Ports = append(b.instance.Spec.Server.ContainerSpec.Ports, ports...)
+	return b
+}
+
 func (b *DistributionBuilder) WithUserConfig(configMapName string) *DistributionBuilder {
 	b.instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{
 		ConfigMapName: configMapName,
@@ -0,0 +1,40 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// throttledRequests counts the API server requests of the reconciler that failed because the API server
+// throttled them, after the retries of the client.
+var throttledRequests = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "llamastackdistribution_throttled_requests_total",
+	Help: "Total number of API server requests of the LlamaStackDistribution controller rejected with 429 Too Many Requests",
+})
+
+func init() { //nolint:gochecknoinits
+	metrics.Registry.MustRegister(throttledRequests)
+}
+
+// isThrottled returns true when the error is the API server throttling a request. Throttled requests are
+// transient, and are retried with backoff without failing the instance.
+func isThrottled(err error) bool {
+	return err != nil && k8serrors.IsTooManyRequests(err)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestRequeueThrottled(t *testing.T) {
+	throttled := fmt.Errorf("failed to reconcile Deployment: %w", k8serrors.NewTooManyRequests("the server is busy", 1))
+	misconfigured := errors.New("failed to validate distribution: unknown")
+	assert.True(t, isThrottled(throttled), "wrapped 429 errors are throttling")
+	assert.False(t, isThrottled(misconfigured))
+	assert.False(t, isThrottled(nil))
+
+	r := &LlamaStackDistributionReconciler{}
+	before := testutil.ToFloat64(throttledRequests)
+	result, err := r.requeueThrottled(context.Background(), throttled, nil, misconfigured, throttled)
+	require.NoError(t, err, "throttling must not be reported as a reconcile failure")
+	assert.True(t, result.Requeue)
+	assert.InDelta(t, before+2, testutil.ToFloat64(throttledRequests), 0)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// userConfigFileName is the file name of the run.yaml read by the server from the user config mount.
+const userConfigFileName = "run.yaml"
+
+// hasInlineUserConfig checks if the instance sets the content of its run.yaml inline.
+func hasInlineUserConfig(instance *llamav1alpha1.LlamaStackDistribution) bool {
+	return instance.Spec.Server.UserConfig != nil && instance.Spec.Server.UserConfig.Inline != ""
+}
+
+// usesUserConfig checks if the server runs with a run.yaml supplied by the user, from a ConfigMap or inline.
+func usesUserConfig(instance *llamav1alpha1.LlamaStackDistribution) bool {
+	return hasValidUserConfig(instance) || hasInlineUserConfig(instance)
+}
+
+// getUserConfigVolumeConfigMapName returns the name of the ConfigMap mounted as the user config.
+func getUserConfigVolumeConfigMapName(instance *llamav1alpha1.LlamaStackDistribution) string {
+	if hasInlineUserConfig(instance) {
+		return getInlineUserConfigMapName(instance)
+	}
+	return instance.Spec.Server.UserConfig.ConfigMapName
+}
+
+// getInlineUserConfigMapName returns the name of the ConfigMap holding the inline run.yaml.
+func getInlineUserConfigMapName(instance *llamav1alpha1.LlamaStackDistribution) string {
+	return instance.Name + "-user-config"
+}
+
+// reconcileInlineUserConfigMap creates or updates the ConfigMap holding the inline run.yaml, and deletes it
+// once the run.yaml is no longer set inline.
+func (r *LlamaStackDistributionReconciler) reconcileInlineUserConfigMap(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution) error {
+	if !hasInlineUserConfig(instance) {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: getInlineUserConfigMapName(instance), Namespace: instance.Namespace}}
+		return deploy.DeleteIfControlled(ctx, r.Client, instance, configMap, log.FromContext(ctx))
+	}
+	if err := validateInlineUserConfig(instance.Spec.Server.UserConfig.Inline); err != nil {
+		r.recordEvent(instance, corev1.EventTypeWarning, reasonValidationFailed, err.Error())
+		return err
+	}
+	return deploy.ApplyConfigMap(ctx, r.Client, r.Scheme, instance, buildInlineUserConfigMap(instance), log.FromContext(ctx))
+}
+
+// validateInlineUserConfig checks that the inline run.yaml is a YAML mapping.
+func validateInlineUserConfig(content string) error {
+	var config map[string]any
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return fmt.Errorf("failed to parse inline user config: %w", err)
+	}
+	if config == nil {
+		return errors.New("failed to parse inline user config: the run.yaml must be a YAML mapping")
+	}
+	return nil
+}
+
+// buildInlineUserConfigMap returns the ConfigMap holding the inline run.yaml.
+func buildInlineUserConfigMap(instance *llamav1alpha1.LlamaStackDistribution) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getInlineUserConfigMapName(instance),
+			Namespace: instance.Namespace,
+			Labels: deploy.MergeLabels(deploy.GetResourceLabels(instance), map[string]string{
+				deploy.InstanceLabelKey: instance.Name,
+			}),
+			Annotations: deploy.GetResourceAnnotations(instance),
+		},
+		Data: map[string]string{userConfigFileName: instance.Spec.Server.UserConfig.Inline},
+	}
+}
+
+// getInlineUserConfigHash returns the hash of the inline run.yaml, which changes the pod template, and rolls
+// out the pods, when the content changes.
+func getInlineUserConfigHash(instance *llamav1alpha1.LlamaStackDistribution) string {
+	sum := sha256.Sum256([]byte(instance.Spec.Server.UserConfig.Inline))
+	return hex.EncodeToString(sum[:])
+}
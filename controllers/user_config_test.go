@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateInlineUserConfig(t *testing.T) {
+	require.NoError(t, validateInlineUserConfig("version: '2'\nimage_name: starter\n"))
+	require.ErrorContains(t, validateInlineUserConfig("version: '2'\n  image_name: starter\n"), "failed to parse inline user config")
+	require.ErrorContains(t, validateInlineUserConfig("# only a comment\n"), "must be a YAML mapping")
+	require.Error(t, validateInlineUserConfig("- inference\n"))
+}
+
+func TestInlineUserConfig(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Name = "llsd"
+	instance.Namespace = "default"
+	instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{Inline: "version: '2'\n"}
+
+	configMap := buildInlineUserConfigMap(instance)
+	assert.Equal(t, "llsd-user-config", configMap.Name)
+	assert.Equal(t, map[string]string{"run.yaml": "version: '2'\n"}, configMap.Data)
+
+	podSpec := &corev1.PodSpec{}
+	configureUserConfig(instance, podSpec)
+	require.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "llsd-user-config", podSpec.Volumes[0].ConfigMap.Name)
+	assert.Empty(t, podSpec.Volumes[0].ConfigMap.Items)
+
+	container := &corev1.Container{}
+	configureContainerCommands(instance, container)
+	assert.Equal(t, []string{"--config", "/etc/llama-stack/run.yaml"}, container.Args)
+
+	hash := getInlineUserConfigHash(instance)
+	instance.Spec.Server.UserConfig.Inline = "version: '3'\n"
+	assert.NotEqual(t, hash, getInlineUserConfigHash(instance), "a new run.yaml must roll out the pods")
+}
+
+func TestUserConfigMapKey(t *testing.T) {
+	instance := createLSD("", "test-image:latest")
+	instance.Spec.Server.UserConfig = &llamav1alpha1.UserConfigSpec{ConfigMapName: "configs", ConfigMapKey: "starter.yaml"}
+
+	podSpec := &corev1.PodSpec{}
+	configureUserConfig(instance, podSpec)
+	require.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "configs", podSpec.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "starter.yaml", Path: "run.yaml"}}, podSpec.Volumes[0].ConfigMap.Items)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// supportedServerVersionsConfigKey is the key used in the operator ConfigMap to store the supported server versions.
+	supportedServerVersionsConfigKey = "supportedServerVersions"
+	// defaultMinServerVersion is the oldest server version serving the API paths used by the operator.
+	defaultMinServerVersion = "0.2.0"
+	// defaultMaxServerVersion is the first server version the operator is not known to be compatible with.
+	defaultMaxServerVersion = "1.0.0"
+)
+
+// ServerVersionRange is the range of LlamaStack server versions the operator is compatible with.
+type ServerVersionRange struct {
+	// MinVersion is the oldest supported version.
+	MinVersion string `yaml:"minVersion,omitempty"`
+	// MaxVersion is the first unsupported version, leave empty for no upper bound.
+	MaxVersion string `yaml:"maxVersion,omitempty"`
+}
+
+// Validate checks that the bounds of the range are versions.
+func (v *ServerVersionRange) Validate() error {
+	for _, bound := range []string{v.MinVersion, v.MaxVersion} {
+		if bound == "" {
+			continue
+		}
+		if _, err := version.ParseGeneric(bound); err != nil {
+			return fmt.Errorf("failed to parse supported server version %q: %w", bound, err)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the server version is within the range.
+func (v *ServerVersionRange) Contains(serverVersion *version.Version) bool {
+	if v.MinVersion != "" && serverVersion.LessThan(version.MustParseGeneric(v.MinVersion)) {
+		return false
+	}
+	return v.MaxVersion == "" || serverVersion.LessThan(version.MustParseGeneric(v.MaxVersion))
+}
+
+// String describes the range, e.g. >= 0.2.0, < 1.0.0.
+func (v *ServerVersionRange) String() string {
+	var bounds []string
+	if v.MinVersion != "" {
+		bounds = append(bounds, ">= "+v.MinVersion)
+	}
+	if v.MaxVersion != "" {
+		bounds = append(bounds, "< "+v.MaxVersion)
+	}
+	return strings.Join(bounds, ", ")
+}
+
+// parseSupportedServerVersions extracts and validates the supported server versions from ConfigMap data.
+// A nil range uses the defaults of the operator.
+func parseSupportedServerVersions(configMapData map[string]string) (*ServerVersionRange, error) {
+	rangeYAML, exists := configMapData[supportedServerVersionsConfigKey]
+	if !exists || strings.TrimSpace(rangeYAML) == "" {
+		return nil, nil
+	}
+
+	versionRange := &ServerVersionRange{}
+	if err := yaml.Unmarshal([]byte(rangeYAML), versionRange); err != nil {
+		return nil, fmt.Errorf("failed to parse supported server versions: %w", err)
+	}
+	if err := versionRange.Validate(); err != nil {
+		return nil, err
+	}
+
+	return versionRange, nil
+}
+
+// getSupportedServerVersions returns the configured server version range, or the default one.
+func (r *LlamaStackDistributionReconciler) getSupportedServerVersions() *ServerVersionRange {
+	if r.SupportedServerVersions != nil {
+		return r.SupportedServerVersions
+	}
+	return &ServerVersionRange{MinVersion: defaultMinServerVersion, MaxVersion: defaultMaxServerVersion}
+}
+
+// checkVersionSkew sets the VersionSkew condition from the version reported by the server, and emits a
+// Warning Event when the server starts running a version outside of the supported range.
+func (r *LlamaStackDistributionReconciler) checkVersionSkew(ctx context.Context, instance *llamav1alpha1.LlamaStackDistribution,
+	serverVersion string) {
+	parsed, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		// Development builds may not report a release version, there is nothing to compare
+		log.FromContext(ctx).V(1).Info("server version is not a release version, skipping the compatibility check",
+			"version", serverVersion)
+		return
+	}
+
+	supported := r.getSupportedServerVersions()
+	if supported.Contains(parsed) {
+		SetVersionSkewCondition(instance, false, "")
+		return
+	}
+
+	message := fmt.Sprintf("Server version %s is outside of the range supported by the operator (%s)", serverVersion, supported)
+	if !IsConditionTrue(&instance.Status, ConditionTypeVersionSkew) && r.Recorder != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, ReasonUnsupportedServerVersion, message)
+	}
+	SetVersionSkewCondition(instance, true, message)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestParseSupportedServerVersions(t *testing.T) {
+	versionRange, err := parseSupportedServerVersions(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, versionRange)
+
+	versionRange, err = parseSupportedServerVersions(map[string]string{supportedServerVersionsConfigKey: "minVersion: 0.2.10\n"})
+	require.NoError(t, err)
+	assert.Equal(t, &ServerVersionRange{MinVersion: "0.2.10"}, versionRange)
+
+	_, err = parseSupportedServerVersions(map[string]string{supportedServerVersionsConfigKey: "maxVersion: latest\n"})
+	require.Error(t, err)
+}
+
+func TestCheckVersionSkew(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &LlamaStackDistributionReconciler{Recorder: recorder}
+	instance := &llamav1alpha1.LlamaStackDistribution{}
+
+	r.checkVersionSkew(context.Background(), instance, "0.2.23")
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeVersionSkew))
+
+	r.checkVersionSkew(context.Background(), instance, "1.1.0rc1")
+	condition := GetCondition(&instance.Status, ConditionTypeVersionSkew)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonUnsupportedServerVersion, condition.Reason)
+	assert.Equal(t, "Server version 1.1.0rc1 is outside of the range supported by the operator (>= 0.2.0, < 1.0.0)", condition.Message)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning UnsupportedVersion")
+
+	// The Event is only emitted when the skew starts
+	r.checkVersionSkew(context.Background(), instance, "1.1.0rc1")
+	assert.Empty(t, recorder.Events)
+
+	// Versions that can't be compared leave the condition untouched
+	r.checkVersionSkew(context.Background(), instance, "dev")
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeVersionSkew))
+
+	r.SupportedServerVersions = &ServerVersionRange{MinVersion: "0.3.0"}
+	r.checkVersionSkew(context.Background(), instance, "v1.1.0")
+	assert.True(t, IsConditionFalse(&instance.Status, ConditionTypeVersionSkew))
+	r.checkVersionSkew(context.Background(), instance, "0.2.23")
+	assert.True(t, IsConditionTrue(&instance.Status, ConditionTypeVersionSkew))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 
 	llamaxk8siov1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
@@ -28,7 +29,9 @@ import (
 	"go.uber.org/zap/zapcore"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -38,6 +41,8 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	_ "embed"
+	// Embed the time zone database so that maintenance window time zones resolve whatever the base image.
+	_ "time/tzdata"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -58,17 +63,56 @@ func init() { //nolint:gochecknoinits
 	//+kubebuilder:scaffold:scheme
 }
 
-func setupReconciler(ctx context.Context, cli client.Client, mgr ctrl.Manager, clusterInfo *cluster.ClusterInfo) error {
-	reconciler, err := controllers.NewLlamaStackDistributionReconciler(ctx, cli, scheme, clusterInfo)
+func setupReconciler(ctx context.Context, cli client.Client, mgr ctrl.Manager, clusterInfo *cluster.ClusterInfo,
+	createOperatorConfig bool, manifestsHandler *controllers.ManifestsHandler) error {
+	reconciler, err := controllers.NewLlamaStackDistributionReconciler(ctx, cli, scheme, clusterInfo, createOperatorConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create reconciler: %w", err)
 	}
+	manifestsHandler.Reconciler = reconciler
 	if err = reconciler.SetupWithManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
 	return nil
 }
 
+// detectClusterFeatures records the optional features served by the cluster in the cluster info.
+// The features are disabled when their detection fails, so that the operator starts anyway.
+func detectClusterFeatures(ctx context.Context, cfg *rest.Config, cli client.Client, clusterInfo *cluster.ClusterInfo) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		setupLog.Error(err, "failed to create discovery client, running without the optional cluster features")
+		return
+	}
+	if clusterInfo.ImageVolumesSupported, err = detectImageVolumeSupport(ctx, discoveryClient, cli, clusterInfo.OperatorNamespace); err != nil {
+		// Image volumes are optional, keep running without them
+		setupLog.Error(err, "failed to detect image volume support")
+	}
+	if clusterInfo.RoutesSupported, err = cluster.SupportsRoutes(discoveryClient); err != nil {
+		// Routes are optional, keep running without them
+		setupLog.Error(err, "failed to detect OpenShift Route support")
+	}
+	if clusterInfo.ServiceMonitorsSupported, err = cluster.SupportsServiceMonitors(discoveryClient); err != nil {
+		// ServiceMonitors are optional, keep running without them
+		setupLog.Error(err, "failed to detect Prometheus Operator support")
+	}
+}
+
+// detectImageVolumeSupport checks whether the Kubernetes server version supports image volumes, and then
+// whether the API server accepts them, which it doesn't when the ImageVolume feature gate is disabled.
+func detectImageVolumeSupport(ctx context.Context, discoveryClient discovery.DiscoveryInterface, cli client.Client,
+	namespace string) (bool, error) {
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return false, fmt.Errorf("failed to get server version: %w", err)
+	}
+	supported, err := cluster.SupportsImageVolumes(serverVersion)
+	if err != nil || !supported {
+		return false, err
+	}
+	return cluster.ServesImageVolumes(ctx, cli, namespace)
+}
+
 func setupHealthChecks(mgr ctrl.Manager) error {
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return fmt.Errorf("failed to set up health check: %w", err)
@@ -83,11 +127,19 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var createOperatorConfig bool
+	var enableWebhooks bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&createOperatorConfig, "create-operator-config", true,
+		"Create the operator config ConfigMap with default values when it does not exist. "+
+			"Disable this when the ConfigMap is managed externally, e.g. by a GitOps tool.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the defaulting webhook of the LlamaStackDistributions. "+
+			"Requires the webhook serving certificate, e.g. issued by cert-manager.")
 	opts := zap.Options{
 		Development:     false,
 		StacktraceLevel: zapcore.PanicLevel, // Set higher than ErrorLevel to avoid stack traces in logs
@@ -101,9 +153,14 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// The handler is registered before the reconciler exists, it serves requests once setupReconciler wired it
+	manifestsHandler := &controllers.ManifestsHandler{}
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                     scheme,
-		Metrics:                    metricsserver.Options{BindAddress: metricsAddr},
+		Scheme: scheme,
+		Metrics: metricsserver.Options{
+			BindAddress:   metricsAddr,
+			ExtraHandlers: map[string]http.Handler{controllers.ManifestsPath: manifestsHandler},
+		},
 		HealthProbeBindAddress:     probeAddr,
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionID:           "54e06e98.llamastack.io",
@@ -145,8 +202,17 @@ func main() {
 		setupLog.Error(err, "failed to initialize cluster config")
 		os.Exit(1)
 	}
+	detectClusterFeatures(ctx, cfg, setupClient, clusterInfo)
+
+	if enableWebhooks {
+		defaulter := &llamaxk8siov1alpha1.LlamaStackDistributionDefaulter{DistributionReplicas: clusterInfo.DistributionReplicas}
+		if err := defaulter.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "failed to set up webhook")
+			os.Exit(1)
+		}
+	}
 
-	if err := setupReconciler(ctx, setupClient, mgr, clusterInfo); err != nil {
+	if err := setupReconciler(ctx, setupClient, mgr, clusterInfo, createOperatorConfig, manifestsHandler); err != nil {
 		setupLog.Error(err, "failed to set up reconciler")
 		os.Exit(1)
 	}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Sink selects where spec changes are recorded.
+type Sink string
+
+const (
+	// ConfigKey is the key used in the operator ConfigMap to store the spec audit configuration.
+	ConfigKey = "specAudit"
+
+	// SinkEvent records each spec change as a Kubernetes Event on the LlamaStackDistribution.
+	SinkEvent Sink = "Event"
+	// SinkConfigMap appends each spec change to a ConfigMap next to the LlamaStackDistribution.
+	SinkConfigMap Sink = "ConfigMap"
+
+	// DefaultMaxEntries is the number of records kept in the audit ConfigMap when not configured.
+	DefaultMaxEntries = 100
+	// ConfigMapSuffix is appended to the LlamaStackDistribution name to form the audit ConfigMap name.
+	ConfigMapSuffix = "-spec-audit"
+	// MaxRecordSize caps the size of a record in the audit ConfigMap; longer diffs are truncated.
+	MaxRecordSize = 16 * 1024
+	// MaxDataSize caps the size of the records kept in the audit ConfigMap, well below the 1MiB limit of
+	// Kubernetes objects; the oldest are dropped first.
+	MaxDataSize = 512 * 1024
+
+	// unknownManager is reported when the field manager of a change can't be determined.
+	unknownManager = "unknown"
+	// specFieldsPrefix identifies managedFields entries that own fields under .spec.
+	specFieldsPrefix = `"f:spec"`
+)
+
+// Config describes how spec changes of LlamaStackDistributions are audited.
+type Config struct {
+	// Sink is either Event or ConfigMap.
+	Sink Sink `yaml:"sink"`
+	// MaxEntries caps the number of records kept in the audit ConfigMap; the oldest are dropped first, as they
+	// are beyond MaxDataSize.
+	MaxEntries int `yaml:"maxEntries,omitempty"`
+}
+
+// Validate checks the configuration and applies defaults.
+func (c *Config) Validate() error {
+	switch c.Sink {
+	case SinkEvent, SinkConfigMap:
+	default:
+		return fmt.Errorf("failed to validate spec audit sink %q: must be %s or %s", c.Sink, SinkEvent, SinkConfigMap)
+	}
+	if c.MaxEntries < 0 {
+		return fmt.Errorf("failed to validate spec audit maxEntries %d: must not be negative", c.MaxEntries)
+	}
+	if c.MaxEntries == 0 {
+		c.MaxEntries = DefaultMaxEntries
+	}
+	return nil
+}
+
+// Record is a single audited spec change.
+type Record struct {
+	Timestamp       time.Time `json:"timestamp"`
+	Namespace       string    `json:"namespace"`
+	Name            string    `json:"name"`
+	Generation      int64     `json:"generation"`
+	ResourceVersion string    `json:"resourceVersion"`
+	// Manager is the field manager that last updated the spec, taken from managedFields.
+	Manager string `json:"manager"`
+	Diff    string `json:"diff"`
+}
+
+// NewRecord builds an audit record for a spec change of obj.
+func NewRecord(obj metav1.Object, diff string, now time.Time) Record {
+	return Record{
+		Timestamp:       now.UTC(),
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		Generation:      obj.GetGeneration(),
+		ResourceVersion: obj.GetResourceVersion(),
+		Manager:         SpecManager(obj),
+		Diff:            diff,
+	}
+}
+
+// Key returns the ConfigMap key of the record. Keys sort in chronological order.
+func (r Record) Key() string {
+	return fmt.Sprintf("%s-%s", r.Timestamp.Format("20060102T150405.000000000Z"), r.ResourceVersion)
+}
+
+// Marshal returns the JSON encoding of the record.
+func (r Record) Marshal() (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r); err != nil {
+		return "", fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// MarshalWithin returns the JSON encoding of the record, shortening the diff until the encoding fits in limit
+// bytes. The diff of a shortened record ends with "...", and the encoding stays valid JSON.
+func (r Record) MarshalWithin(limit int) (string, error) {
+	message, err := r.Marshal()
+	if err != nil {
+		return "", err
+	}
+	diff := r.Diff
+	for len(message) > limit && diff != "" {
+		diff = strings.ToValidUTF8(diff[:max(0, len(diff)-(len(message)-limit))], "")
+		r.Diff = diff + "..."
+		if message, err = r.Marshal(); err != nil {
+			return "", err
+		}
+	}
+	return message, nil
+}
+
+// SpecManager returns the field manager of the most recent managedFields entry owning fields
+// under .spec. Kubernetes doesn't record the requesting user, so this is the closest identity
+// available, e.g. "kubectl-edit" or the name of a GitOps controller.
+func SpecManager(obj metav1.Object) string {
+	manager := unknownManager
+	var latest time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource != "" || entry.FieldsV1 == nil || !bytes.Contains(entry.FieldsV1.Raw, []byte(specFieldsPrefix)) {
+			continue
+		}
+		var entryTime time.Time
+		if entry.Time != nil {
+			entryTime = entry.Time.Time
+		}
+		if manager == unknownManager || !entryTime.Before(latest) {
+			manager = entry.Manager
+			latest = entryTime
+		}
+	}
+	return manager
+}
+
+// Append adds the record, truncated to MaxRecordSize, to the ConfigMap data and drops the oldest records beyond
+// maxEntries or beyond MaxDataSize.
+func Append(data map[string]string, record Record, maxEntries int) (map[string]string, error) {
+	value, err := record.MarshalWithin(MaxRecordSize)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	data[record.Key()] = value
+
+	keys := make([]string, 0, len(data))
+	size := 0
+	for key, value := range data {
+		keys = append(keys, key)
+		size += len(key) + len(value)
+	}
+	sort.Strings(keys)
+	for _, key := range keys[:len(keys)-1] {
+		if (maxEntries <= 0 || len(data) <= maxEntries) && size <= MaxDataSize {
+			break
+		}
+		size -= len(key) + len(data[key])
+		delete(data, key)
+	}
+	return data, nil
+}
@@ -0,0 +1,87 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigValidate(t *testing.T) {
+	config := &audit.Config{Sink: audit.SinkConfigMap}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, audit.DefaultMaxEntries, config.MaxEntries)
+
+	require.Error(t, (&audit.Config{Sink: "Syslog"}).Validate())
+	require.Error(t, (&audit.Config{Sink: audit.SinkEvent, MaxEntries: -1}).Validate())
+}
+
+func TestSpecManager(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+	specFields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}
+	labelFields := &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{}}}`)}
+
+	obj := &metav1.ObjectMeta{
+		ManagedFields: []metav1.ManagedFieldsEntry{
+			{Manager: "kubectl-create", Operation: metav1.ManagedFieldsOperationUpdate, Time: &earlier, FieldsV1: specFields},
+			{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: &later, FieldsV1: specFields},
+			{Manager: "kubectl-label", Operation: metav1.ManagedFieldsOperationUpdate, Time: &later, FieldsV1: labelFields},
+			{Manager: "operator", Operation: metav1.ManagedFieldsOperationUpdate, Time: &later, FieldsV1: specFields, Subresource: "status"},
+		},
+	}
+	assert.Equal(t, "kubectl-edit", audit.SpecManager(obj))
+	assert.Equal(t, "unknown", audit.SpecManager(&metav1.ObjectMeta{}))
+}
+
+func TestAppend(t *testing.T) {
+	obj := &metav1.ObjectMeta{Name: "llsd", Namespace: "default", ResourceVersion: "1"}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var data map[string]string
+	var err error
+	for i := range 3 {
+		record := audit.NewRecord(obj, "diff", start.Add(time.Duration(i)*time.Minute))
+		data, err = audit.Append(data, record, 2)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, data, 2, "oldest records should be dropped beyond maxEntries")
+	require.NotContains(t, data, audit.NewRecord(obj, "diff", start).Key())
+
+	var record audit.Record
+	require.NoError(t, json.Unmarshal([]byte(data[audit.NewRecord(obj, "diff", start.Add(2*time.Minute)).Key()]), &record))
+	assert.Equal(t, "llsd", record.Name)
+	assert.Equal(t, "diff", record.Diff)
+	assert.Equal(t, "unknown", record.Manager)
+}
+
+func TestAppendSizeLimits(t *testing.T) {
+	obj := &metav1.ObjectMeta{Name: "llsd", Namespace: "default", ResourceVersion: "1"}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	diff := strings.Repeat("- replicas: 1\n+ replicas: 2\n", 2000)
+
+	var data map[string]string
+	var err error
+	for i := range 100 {
+		data, err = audit.Append(data, audit.NewRecord(obj, diff, start.Add(time.Duration(i)*time.Minute)), 100)
+		require.NoError(t, err)
+	}
+
+	size := 0
+	for key, value := range data {
+		assert.LessOrEqual(t, len(value), audit.MaxRecordSize, "long diffs should be truncated")
+		size += len(key) + len(value)
+	}
+	assert.LessOrEqual(t, size, audit.MaxDataSize, "the oldest records should be dropped beyond the size limit")
+	require.Contains(t, data, audit.NewRecord(obj, diff, start.Add(99*time.Minute)).Key(), "the latest record should be kept")
+
+	var record audit.Record
+	require.NoError(t, json.Unmarshal([]byte(data[audit.NewRecord(obj, diff, start.Add(99*time.Minute)).Key()]), &record))
+	assert.True(t, strings.HasSuffix(record.Diff, "..."))
+}
@@ -7,12 +7,135 @@ import (
 	"os"
 
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/version"
+	apimachineryversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type ClusterInfo struct {
 	OperatorNamespace  string
 	DistributionImages map[string]string
+	// DistributionReplicas holds the catalog default replica count per distribution name.
+	// It is used when a LlamaStackDistribution does not set spec.replicas.
+	DistributionReplicas map[string]int32
+	// DistributionStartupSeconds holds the catalog warm-up hint, in seconds, per distribution name.
+	// It extends the startup probe budget of distributions known to start slowly.
+	DistributionStartupSeconds map[string]int32
+	// ImageVolumesSupported is true when the cluster runs a Kubernetes version supporting image volumes
+	// and its API server accepts them.
+	ImageVolumesSupported bool
+	// RoutesSupported is true when the cluster serves the OpenShift Route API.
+	RoutesSupported bool
+	// ServiceMonitorsSupported is true when the Prometheus Operator CRDs are installed in the cluster.
+	ServiceMonitorsSupported bool
+}
+
+// RouteGroupVersion is the group version of the OpenShift Route API.
+const RouteGroupVersion = "route.openshift.io/v1"
+
+// ServiceMonitorGroupVersion is the group version of the Prometheus Operator ServiceMonitor API.
+const ServiceMonitorGroupVersion = "monitoring.coreos.com/v1"
+
+// SupportsRoutes reports whether the cluster serves the OpenShift Route API.
+func SupportsRoutes(discoveryClient discovery.DiscoveryInterface) (bool, error) {
+	return servesKind(discoveryClient, RouteGroupVersion, "Route")
+}
+
+// SupportsServiceMonitors reports whether the Prometheus Operator CRDs are installed in the cluster.
+func SupportsServiceMonitors(discoveryClient discovery.DiscoveryInterface) (bool, error) {
+	return servesKind(discoveryClient, ServiceMonitorGroupVersion, "ServiceMonitor")
+}
+
+// servesKind reports whether the cluster serves the kind in the group version.
+func servesKind(discoveryClient discovery.DiscoveryInterface, groupVersion, kind string) (bool, error) {
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to discover %s resources: %w", groupVersion, err)
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// minImageVolumeVersion is the first Kubernetes version supporting image volumes.
+var minImageVolumeVersion = version.MustParseGeneric("1.31.0")
+
+// SupportsImageVolumes reports whether the Kubernetes server version supports image volumes. The
+// ImageVolume feature gate must also be enabled on clusters where it is not enabled by default, which
+// ServesImageVolumes checks.
+func SupportsImageVolumes(serverVersion *apimachineryversion.Info) (bool, error) {
+	parsed, err := version.ParseGeneric(serverVersion.GitVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse server version %q: %w", serverVersion.GitVersion, err)
+	}
+	return parsed.AtLeast(minImageVolumeVersion), nil
+}
+
+// imageVolumeProbeName is the name of the Deployment created in dry-run mode to probe image volume support.
+const imageVolumeProbeName = "llama-stack-image-volume-probe"
+
+// ServesImageVolumes reports whether the API server accepts image volumes, by creating a Deployment using one
+// in the namespace in dry-run mode. The API server silently drops the image volume source when the
+// ImageVolume feature gate is disabled, so the volume of the returned Deployment tells whether it is enabled.
+// Nothing is persisted.
+func ServesImageVolumes(ctx context.Context, c client.Client, namespace string) (bool, error) {
+	probe := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": imageVolumeProbeName, "namespace": namespace},
+		"spec": map[string]any{
+			"replicas": int64(0),
+			"selector": map[string]any{"matchLabels": map[string]any{"app": imageVolumeProbeName}},
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": map[string]any{"app": imageVolumeProbeName}},
+				"spec": map[string]any{
+					"containers": []any{map[string]any{"name": "probe", "image": "probe"}},
+					"volumes":    []any{map[string]any{"name": "probe", "image": map[string]any{"reference": "probe"}}},
+				},
+			},
+		},
+	}}
+	if err := c.Create(ctx, probe, client.DryRunAll); err != nil {
+		return false, fmt.Errorf("failed to create the image volume probe Deployment in dry-run mode: %w", err)
+	}
+
+	volumes, _, err := unstructured.NestedSlice(probe.Object, "spec", "template", "spec", "volumes")
+	if err != nil {
+		return false, fmt.Errorf("failed to read the volumes of the image volume probe Deployment: %w", err)
+	}
+	for _, item := range volumes {
+		if volume, ok := item.(map[string]any); ok && volume["image"] != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DistributionEntry is the extended form of a distributions.json entry. An entry is either
+// a plain image reference or an object carrying the image and distribution defaults.
+type DistributionEntry struct {
+	Image    string `json:"image"`
+	Replicas *int32 `json:"replicas,omitempty"`
+	// StartupSeconds is the expected time for the distribution to warm up, e.g. to load a large model.
+	StartupSeconds *int32 `json:"startupSeconds,omitempty"`
+}
+
+// DistributionCatalog is the parsed distributions catalog, indexed by distribution name.
+type DistributionCatalog struct {
+	Images map[string]string
+	// Replicas holds the default replica counts of the distributions that declare one.
+	Replicas map[string]int32
+	// StartupSeconds holds the warm-up hints of the distributions that declare one.
+	StartupSeconds map[string]int32
 }
 
 // NewClusterInfo creates a new ClusterInfo object using embedded distributions data.
@@ -22,19 +145,67 @@ func NewClusterInfo(ctx context.Context, client client.Client, embeddedDistribut
 		return nil, fmt.Errorf("failed to find operator namespace: %w", err)
 	}
 
-	var distributionImages map[string]string
+	catalog := &DistributionCatalog{}
 	if os.Getenv("RELATED_IMAGE_RH_DISTRIBUTION") != "" {
-		distributionImages = map[string]string{
+		catalog.Images = map[string]string{
 			"rh-dev": os.Getenv("RELATED_IMAGE_RH_DISTRIBUTION"),
 		}
 	} else {
-		if err := json.Unmarshal(embeddedDistributions, &distributionImages); err != nil {
-			return nil, fmt.Errorf("failed to parse embedded distributions JSON: %w", err)
+		catalog, err = ParseDistributions(embeddedDistributions)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return &ClusterInfo{
-		OperatorNamespace:  operatorNamespace,
-		DistributionImages: distributionImages,
+		OperatorNamespace:          operatorNamespace,
+		DistributionImages:         catalog.Images,
+		DistributionReplicas:       catalog.Replicas,
+		DistributionStartupSeconds: catalog.StartupSeconds,
 	}, nil
 }
+
+// ParseDistributions parses the distributions catalog into the image map and the
+// defaults of the distributions that declare them.
+func ParseDistributions(data []byte) (*DistributionCatalog, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded distributions JSON: %w", err)
+	}
+
+	catalog := &DistributionCatalog{
+		Images:         make(map[string]string, len(raw)),
+		Replicas:       map[string]int32{},
+		StartupSeconds: map[string]int32{},
+	}
+	for name, value := range raw {
+		var image string
+		if err := json.Unmarshal(value, &image); err == nil {
+			catalog.Images[name] = image
+			continue
+		}
+
+		var entry DistributionEntry
+		if err := json.Unmarshal(value, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse distribution %q: %w", name, err)
+		}
+		if entry.Image == "" {
+			return nil, fmt.Errorf("failed to parse distribution %q: image must be set", name)
+		}
+		catalog.Images[name] = entry.Image
+		if entry.Replicas != nil {
+			if *entry.Replicas < 0 {
+				return nil, fmt.Errorf("failed to parse distribution %q: replicas must not be negative", name)
+			}
+			catalog.Replicas[name] = *entry.Replicas
+		}
+		if entry.StartupSeconds != nil {
+			if *entry.StartupSeconds < 0 {
+				return nil, fmt.Errorf("failed to parse distribution %q: startupSeconds must not be negative", name)
+			}
+			catalog.StartupSeconds[name] = *entry.StartupSeconds
+		}
+	}
+
+	return catalog, nil
+}
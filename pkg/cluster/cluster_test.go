@@ -1,9 +1,19 @@
 package cluster
 
 import (
-	"encoding/json"
+	"context"
 	"os"
 	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 // TestDistributionsJSONIsValid ensures that the distributions.json file always
@@ -14,10 +24,11 @@ func TestDistributionsJSONIsValid(t *testing.T) {
 		t.Fatalf("failed to read distributions.json: %v", err)
 	}
 
-	var dist map[string]string
-	if err := json.Unmarshal(data, &dist); err != nil {
+	catalog, err := ParseDistributions(data)
+	if err != nil {
 		t.Fatalf("failed to validate distributions.json: %v", err)
 	}
+	dist := catalog.Images
 
 	for k, v := range dist {
 		if k == "" {
@@ -27,4 +38,188 @@ func TestDistributionsJSONIsValid(t *testing.T) {
 			t.Fatalf("failed to validate distributions.json: contains an empty value for key %q", k)
 		}
 	}
+
+	for k := range catalog.Replicas {
+		if _, ok := dist[k]; !ok {
+			t.Fatalf("failed to validate distributions.json: replicas set for unknown distribution %q", k)
+		}
+	}
+	for k := range catalog.StartupSeconds {
+		if _, ok := dist[k]; !ok {
+			t.Fatalf("failed to validate distributions.json: startupSeconds set for unknown distribution %q", k)
+		}
+	}
+}
+
+func TestNewClusterInfoKeepsCatalogDefaults(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "llama-stack-k8s-operator-system")
+	data := []byte(`{"starter": {"image": "docker.io/llamastack/distribution-starter:latest", "replicas": 3, "startupSeconds": 60}}`)
+
+	info, err := NewClusterInfo(context.Background(), nil, data)
+	if err != nil {
+		t.Fatalf("failed to create the cluster info: %v", err)
+	}
+	if info.DistributionReplicas["starter"] != 3 {
+		t.Fatalf("expected the catalog replica default of starter, got %v", info.DistributionReplicas)
+	}
+	if info.DistributionStartupSeconds["starter"] != 60 {
+		t.Fatalf("expected the catalog warm-up hint of starter, got %v", info.DistributionStartupSeconds)
+	}
+}
+
+func TestParseDistributions(t *testing.T) {
+	data := []byte(`{
+"starter": "docker.io/llamastack/distribution-starter:latest",
+"vllm-gpu": {"image": "docker.io/llamastack/distribution-vllm-gpu:latest", "replicas": 1, "startupSeconds": 300},
+"remote-vllm": {"image": "docker.io/llamastack/distribution-remote-vllm:latest"}
+}`)
+
+	catalog, err := ParseDistributions(data)
+	if err != nil {
+		t.Fatalf("failed to parse distributions: %v", err)
+	}
+	images, replicas := catalog.Images, catalog.Replicas
+
+	if len(images) != 3 {
+		t.Fatalf("expected 3 images, got %d", len(images))
+	}
+	if images["vllm-gpu"] != "docker.io/llamastack/distribution-vllm-gpu:latest" {
+		t.Fatalf("unexpected image for vllm-gpu: %q", images["vllm-gpu"])
+	}
+	if len(replicas) != 1 || replicas["vllm-gpu"] != 1 {
+		t.Fatalf("expected only vllm-gpu to carry a replica default, got %v", replicas)
+	}
+	if len(catalog.StartupSeconds) != 1 || catalog.StartupSeconds["vllm-gpu"] != 300 {
+		t.Fatalf("expected only vllm-gpu to carry a warm-up hint, got %v", catalog.StartupSeconds)
+	}
+
+	for _, invalid := range []string{
+		`{"starter": {"replicas": 1}}`,
+		`{"starter": {"image": "img", "replicas": -1}}`,
+		`{"starter": {"image": "img", "startupSeconds": -1}}`,
+		`{"starter": 1}`,
+	} {
+		if _, err := ParseDistributions([]byte(invalid)); err == nil {
+			t.Fatalf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestSupportsImageVolumes(t *testing.T) {
+	tests := map[string]bool{
+		"v1.29.2":          false,
+		"v1.30.0+k3s1":     false,
+		"v1.31.0":          true,
+		"v1.33.4-eks-1234": true,
+		"v1.34.1+rhel-9.6": true,
+	}
+
+	for gitVersion, expected := range tests {
+		supported, err := SupportsImageVolumes(&version.Info{GitVersion: gitVersion})
+		if err != nil {
+			t.Fatalf("failed to check %s: %v", gitVersion, err)
+		}
+		if supported != expected {
+			t.Fatalf("failed to check %s: expected %v, got %v", gitVersion, expected, supported)
+		}
+	}
+
+	if _, err := SupportsImageVolumes(&version.Info{GitVersion: "unknown"}); err == nil {
+		t.Fatalf("failed to reject an invalid version")
+	}
+}
+
+func TestServesImageVolumes(t *testing.T) {
+	ctx := context.Background()
+	served, err := ServesImageVolumes(ctx, fake.NewClientBuilder().Build(), "llama-stack-operator")
+	if err != nil {
+		t.Fatalf("failed to probe image volumes: %v", err)
+	}
+	if !served {
+		t.Fatalf("failed to detect an API server accepting image volumes")
+	}
+
+	// The API server drops the image volume source when the feature gate is disabled
+	dropImageSource := interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			t.Fatalf("expected an unstructured probe, got %T", obj)
+		}
+		volumes, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "volumes")
+		for _, item := range volumes {
+			delete(item.(map[string]any), "image")
+		}
+		if err := unstructured.SetNestedSlice(u.Object, volumes, "spec", "template", "spec", "volumes"); err != nil {
+			return err
+		}
+		return c.Create(ctx, obj, opts...)
+	}}
+	served, err = ServesImageVolumes(ctx, fake.NewClientBuilder().WithInterceptorFuncs(dropImageSource).Build(), "llama-stack-operator")
+	if err != nil {
+		t.Fatalf("failed to probe image volumes: %v", err)
+	}
+	if served {
+		t.Fatalf("failed to detect an API server dropping image volumes")
+	}
+
+	// The probe is never persisted
+	c := fake.NewClientBuilder().Build()
+	if _, err := ServesImageVolumes(ctx, c, "llama-stack-operator"); err != nil {
+		t.Fatalf("failed to probe image volumes: %v", err)
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deployments); err != nil {
+		t.Fatalf("failed to list deployments: %v", err)
+	}
+	if len(deployments.Items) != 0 {
+		t.Fatalf("failed to probe in dry-run mode: the probe Deployment was created")
+	}
+}
+
+func TestSupportsRoutes(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	supported, err := SupportsRoutes(discoveryClient)
+	if err != nil {
+		t.Fatalf("failed to check a cluster without Routes: %v", err)
+	}
+	if supported {
+		t.Fatalf("failed to detect a cluster without Routes")
+	}
+
+	discoveryClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: RouteGroupVersion,
+		APIResources: []metav1.APIResource{{Name: "routes", Kind: "Route"}},
+	}}
+	supported, err = SupportsRoutes(discoveryClient)
+	if err != nil {
+		t.Fatalf("failed to check an OpenShift cluster: %v", err)
+	}
+	if !supported {
+		t.Fatalf("failed to detect the Routes of an OpenShift cluster")
+	}
+}
+
+func TestSupportsServiceMonitors(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	discoveryClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: ServiceMonitorGroupVersion,
+		APIResources: []metav1.APIResource{{Name: "podmonitors", Kind: "PodMonitor"}},
+	}}
+	supported, err := SupportsServiceMonitors(discoveryClient)
+	if err != nil {
+		t.Fatalf("failed to check a cluster without ServiceMonitors: %v", err)
+	}
+	if supported {
+		t.Fatalf("failed to detect a cluster without ServiceMonitors")
+	}
+
+	discoveryClient.Resources[0].APIResources = append(discoveryClient.Resources[0].APIResources,
+		metav1.APIResource{Name: "servicemonitors", Kind: "ServiceMonitor"})
+	supported, err = SupportsServiceMonitors(discoveryClient)
+	if err != nil {
+		t.Fatalf("failed to check a cluster with the Prometheus Operator: %v", err)
+	}
+	if !supported {
+		t.Fatalf("failed to detect the ServiceMonitors of a cluster with the Prometheus Operator")
+	}
 }
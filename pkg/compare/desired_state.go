@@ -0,0 +1,133 @@
+package compare
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DesiredStateHashAnnotation records the hash of the desired state last applied to a resource.
+// It lets the operator detect fields removed from the desired state, which a plain comparison
+// against the live object can't tell apart from fields defaulted by the API server.
+const DesiredStateHashAnnotation = "llamastack.io/desired-state-hash"
+
+// SetDesiredStateHash stores the hash of the desired object in its DesiredStateHashAnnotation.
+// It must be called on the desired object before any server-assigned field is copied into it.
+func SetDesiredStateHash(obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	delete(annotations, DesiredStateHashAnnotation)
+	obj.SetAnnotations(annotations)
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal desired state: %w", err)
+	}
+	sum := sha256.Sum256(data)
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DesiredStateHashAnnotation] = hex.EncodeToString(sum[:])
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// IsUpToDate reports whether the live object already matches the desired object, so that an
+// update can be skipped. The desired state hash must be unchanged, and every field set in the
+// desired object must hold the same value in the live object. Fields only present in the live
+// object, such as defaults and other server-managed fields, are ignored, and so is the status.
+func IsUpToDate(desired, live client.Object) (bool, error) {
+	desiredHash := desired.GetAnnotations()[DesiredStateHashAnnotation]
+	if desiredHash == "" || live.GetAnnotations()[DesiredStateHashAnnotation] != desiredHash {
+		return false, nil
+	}
+
+	desiredContent, err := toUnstructuredContent(desired)
+	if err != nil {
+		return false, err
+	}
+	liveContent, err := toUnstructuredContent(live)
+	if err != nil {
+		return false, err
+	}
+	delete(desiredContent, "status")
+	if metadata, ok := desiredContent["metadata"].(map[string]any); ok {
+		for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields"} {
+			delete(metadata, field)
+		}
+	}
+
+	return IsSubset(desiredContent, liveContent), nil
+}
+
+// IsSubset reports whether every value set in desired is equal in live. Maps in live may hold
+// additional keys; lists must have the same length and match element by element.
+func IsSubset(desired, live any) bool {
+	switch desiredValue := desired.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		liveValue, ok := live.(map[string]any)
+		if !ok {
+			return len(desiredValue) == 0 && live == nil
+		}
+		for key, value := range desiredValue {
+			if !IsSubset(value, liveValue[key]) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		liveValue, ok := live.([]any)
+		if !ok {
+			return len(desiredValue) == 0 && live == nil
+		}
+		if len(desiredValue) != len(liveValue) {
+			return false
+		}
+		for i := range desiredValue {
+			if !IsSubset(desiredValue[i], liveValue[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		if desiredNumber, ok := toFloat(desired); ok {
+			liveNumber, liveOk := toFloat(live)
+			return liveOk && desiredNumber == liveNumber
+		}
+		return reflect.DeepEqual(desired, live)
+	}
+}
+
+func toUnstructuredContent(obj client.Object) (map[string]any, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return runtime.DeepCopyJSON(u.UnstructuredContent()), nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T to unstructured: %w", obj, err)
+	}
+	return content, nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
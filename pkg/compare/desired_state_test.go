@@ -0,0 +1,97 @@
+package compare_test
+
+import (
+	"testing"
+
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIsSubset(t *testing.T) {
+	live := map[string]any{
+		"replicas": int64(1),
+		"template": map[string]any{
+			"containers": []any{
+				map[string]any{"name": "server", "image": "img:v1", "terminationMessagePath": "/dev/termination-log"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		desired  map[string]any
+		expected bool
+	}{
+		{"defaulted fields are ignored", map[string]any{
+			"template": map[string]any{"containers": []any{map[string]any{"name": "server", "image": "img:v1"}}},
+		}, true},
+		{"numbers of different types", map[string]any{"replicas": 1}, true},
+		{"changed value", map[string]any{"replicas": int64(2)}, false},
+		{"changed nested value", map[string]any{
+			"template": map[string]any{"containers": []any{map[string]any{"name": "server", "image": "img:v2"}}},
+		}, false},
+		{"added list element", map[string]any{
+			"template": map[string]any{"containers": []any{map[string]any{"name": "server"}, map[string]any{"name": "sidecar"}}},
+		}, false},
+		{"missing field", map[string]any{"paused": true}, false},
+		{"unset fields are ignored", map[string]any{"strategy": nil, "selector": map[string]any{}}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, compare.IsSubset(tc.desired, live))
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	desired := baseService()
+	desired.ResourceVersion = ""
+	require.NoError(t, compare.SetDesiredStateHash(desired))
+	require.NotEmpty(t, desired.Annotations[compare.DesiredStateHashAnnotation])
+
+	// the live object carries server-assigned fields
+	live := desired.DeepCopy()
+	live.ResourceVersion = "42"
+	live.UID = "uid"
+	live.CreationTimestamp = metav1.Now()
+	live.Spec.ClusterIP = "10.0.0.1"
+	live.Spec.SessionAffinity = corev1.ServiceAffinityNone
+
+	upToDate, err := compare.IsUpToDate(desired, live)
+	require.NoError(t, err)
+	assert.True(t, upToDate, "server-assigned fields should not trigger an update")
+
+	// the hash is stable for the same desired state
+	again := baseService()
+	again.ResourceVersion = ""
+	require.NoError(t, compare.SetDesiredStateHash(again))
+	assert.Equal(t, desired.Annotations[compare.DesiredStateHashAnnotation], again.Annotations[compare.DesiredStateHashAnnotation])
+
+	// drift of a desired field is detected
+	drifted := live.DeepCopy()
+	drifted.Spec.Ports[0].TargetPort = intstr.FromInt(9090)
+	upToDate, err = compare.IsUpToDate(desired, drifted)
+	require.NoError(t, err)
+	assert.False(t, upToDate, "drift of a desired field should trigger an update")
+
+	// fields removed from the desired state change the hash
+	removed := baseService()
+	removed.ResourceVersion = ""
+	removed.Labels = nil
+	require.NoError(t, compare.SetDesiredStateHash(removed))
+	upToDate, err = compare.IsUpToDate(removed, live)
+	require.NoError(t, err)
+	assert.False(t, upToDate, "removing a field from the desired state should trigger an update")
+
+	// objects applied before the hash was recorded are updated once
+	unannotated := live.DeepCopy()
+	delete(unannotated.Annotations, compare.DesiredStateHashAnnotation)
+	upToDate, err = compare.IsUpToDate(desired, unannotated)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+}
@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyConfigMap creates or updates a ConfigMap built by the controller.
+func ApplyConfigMap(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, configMap *corev1.ConfigMap, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, configMap, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(configMap); err != nil {
+		return err
+	}
+
+	existing := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(configMap), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, configMap); err != nil {
+				return fmt.Errorf("failed to create ConfigMap: %w", err)
+			}
+			log.Info("Created ConfigMap", "name", configMap.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get ConfigMap: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(configMap, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare ConfigMap: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("ConfigMap is up to date, skipping update", "name", configMap.Name)
+		return nil
+	}
+
+	configMap.ResourceVersion = existing.ResourceVersion
+	if err := c.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update ConfigMap: %w", err)
+	}
+	log.Info("Updated ConfigMap", "name", configMap.Name)
+	return nil
+}
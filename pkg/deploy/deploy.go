@@ -3,45 +3,107 @@ package deploy
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	"github.com/go-logr/logr"
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// ApplyDeployment creates or updates the Deployment.
+// ApplyDeployment creates or updates the Deployment, and reports which of the two it did.
 func ApplyDeployment(ctx context.Context, cli client.Client, scheme *runtime.Scheme,
-	instance *llamav1alpha1.LlamaStackDistribution, deployment *appsv1.Deployment, logger logr.Logger) error {
+	instance *llamav1alpha1.LlamaStackDistribution, deployment *appsv1.Deployment, logger logr.Logger) (controllerutil.OperationResult, error) {
 	if err := ctrl.SetControllerReference(instance, deployment, scheme); err != nil {
-		return fmt.Errorf("failed to set controller reference: %w", err)
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	if err := compare.SetDesiredStateHash(deployment); err != nil {
+		return controllerutil.OperationResultNone, err
 	}
 
 	found := &appsv1.Deployment{}
 	err := cli.Get(ctx, client.ObjectKeyFromObject(deployment), found)
 	if err != nil && errors.IsNotFound(err) {
 		logger.Info("Creating Deployment", "deployment", deployment.Name)
-		return cli.Create(ctx, deployment)
+		return controllerutil.OperationResultCreated, cli.Create(ctx, deployment)
 	} else if err != nil {
-		return fmt.Errorf("failed to fetch deployment: %w", err)
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to fetch deployment: %w", err)
+	}
+
+	// Preserve the existing selector to avoid immutable field error during upgrades
+	deployment.Spec.Selector = found.Spec.Selector
+
+	upToDate, err := compare.IsUpToDate(deployment, found)
+	if err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to compare Deployment: %w", err)
+	}
+	if upToDate {
+		logger.V(1).Info("Deployment is up to date, skipping update", "deployment", deployment.Name)
+		return controllerutil.OperationResultNone, nil
+	}
+
+	// Server-side apply leaves the rolling update parameters defaulted at creation, which the Recreate strategy
+	// rejects, so they are cleared first
+	if deployment.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType && found.Spec.Strategy.RollingUpdate != nil {
+		patch := client.MergeFrom(found.DeepCopy())
+		found.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		if err := cli.Patch(ctx, found, patch); err != nil {
+			return controllerutil.OperationResultNone, fmt.Errorf("failed to switch Deployment to the Recreate strategy: %w", err)
+		}
+	}
+
+	logger.Info("Updating Deployment", "deployment", deployment.Name)
+	// Use server-side apply to merge changes properly
+	// Ensure the deployment has proper TypeMeta for server-side apply
+	deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	return controllerutil.OperationResultUpdated, cli.Patch(ctx, deployment, client.Apply, client.ForceOwnership, client.FieldOwner("llama-stack-operator"))
+}
+
+// ApplyUnstructuredDeployment creates or updates a Deployment holding fields unknown to the typed API,
+// such as volume sources introduced in newer Kubernetes versions, which would be dropped by ApplyDeployment.
+func ApplyUnstructuredDeployment(ctx context.Context, cli client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, deployment *unstructured.Unstructured, logger logr.Logger) (controllerutil.OperationResult, error) {
+	deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	if err := ctrl.SetControllerReference(instance, deployment, scheme); err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to set controller reference: %w", err)
 	}
 
-	// For updates, preserve the existing selector since it's immutable
-	// and use server-side apply for other fields
-	if !reflect.DeepEqual(found.Spec, deployment.Spec) {
-		logger.Info("Updating Deployment", "deployment", deployment.Name)
+	if err := compare.SetDesiredStateHash(deployment); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
 
-		// Preserve the existing selector to avoid immutable field error during upgrades
-		deployment.Spec.Selector = found.Spec.Selector
+	found := &unstructured.Unstructured{}
+	found.SetGroupVersionKind(deployment.GroupVersionKind())
+	err := cli.Get(ctx, client.ObjectKeyFromObject(deployment), found)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating Deployment", "deployment", deployment.GetName())
+		return controllerutil.OperationResultCreated, cli.Create(ctx, deployment)
+	} else if err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to fetch deployment: %w", err)
+	}
+
+	// Preserve the existing selector to avoid immutable field error during upgrades
+	if selector, ok, err := unstructured.NestedFieldCopy(found.Object, "spec", "selector"); err == nil && ok {
+		if err := unstructured.SetNestedField(deployment.Object, selector, "spec", "selector"); err != nil {
+			return controllerutil.OperationResultNone, fmt.Errorf("failed to preserve deployment selector: %w", err)
+		}
+	}
 
-		// Use server-side apply to merge changes properly
-		// Ensure the deployment has proper TypeMeta for server-side apply
-		deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
-		return cli.Patch(ctx, deployment, client.Apply, client.ForceOwnership, client.FieldOwner("llama-stack-operator"))
+	upToDate, err := compare.IsUpToDate(deployment, found)
+	if err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to compare Deployment: %w", err)
 	}
-	return nil
+	if upToDate {
+		logger.V(1).Info("Deployment is up to date, skipping update", "deployment", deployment.GetName())
+		return controllerutil.OperationResultNone, nil
+	}
+
+	logger.Info("Updating Deployment", "deployment", deployment.GetName())
+	return controllerutil.OperationResultUpdated, cli.Patch(ctx, deployment, client.Apply, client.ForceOwnership, client.FieldOwner("llama-stack-operator"))
 }
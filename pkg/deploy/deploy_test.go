@@ -8,8 +8,13 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -54,8 +59,9 @@ func TestApplyDeploymentPreservesSelector(t *testing.T) {
 		},
 	}
 
-	err := ApplyDeployment(ctx, k8sClient, k8sClient.Scheme(), instance, initialDeployment.DeepCopy(), logger)
+	result, err := ApplyDeployment(ctx, k8sClient, k8sClient.Scheme(), instance, initialDeployment.DeepCopy(), logger)
 	require.NoError(t, err)
+	require.Equal(t, controllerutil.OperationResultCreated, result)
 
 	// Verify the deployment was created
 	foundDeployment := &appsv1.Deployment{}
@@ -91,8 +97,9 @@ func TestApplyDeploymentPreservesSelector(t *testing.T) {
 		},
 	}
 
-	err = ApplyDeployment(ctx, k8sClient, k8sClient.Scheme(), instance, updatedDeployment.DeepCopy(), logger)
+	result, err = ApplyDeployment(ctx, k8sClient, k8sClient.Scheme(), instance, updatedDeployment.DeepCopy(), logger)
 	require.NoError(t, err)
+	require.Equal(t, controllerutil.OperationResultUpdated, result)
 
 	err = k8sClient.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: namespace}, foundDeployment)
 	require.NoError(t, err)
@@ -104,3 +111,118 @@ func TestApplyDeploymentPreservesSelector(t *testing.T) {
 	// And the other updates should be applied
 	require.Equal(t, "quay.io/llamastack/llama-stack-k8s-operator:v0.0.2", foundDeployment.Spec.Template.Spec.Containers[0].Image)
 }
+
+func TestApplySkipsNoOpUpdates(t *testing.T) {
+	ctx := context.Background()
+	logger := logf.Log.WithName("test-apply-no-op")
+
+	instance := &llamav1alpha1.LlamaStackDistribution{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+	}
+	labels := map[string]string{"app": "no-op"}
+
+	newDeployment := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deployment-no-op", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "llamastack", Image: image}},
+					},
+				},
+			},
+		}
+	}
+	newService := func(port int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-service-no-op", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports:    []corev1.ServicePort{{Name: "http", Port: port, TargetPort: intstr.FromInt32(port), Protocol: corev1.ProtocolTCP}},
+			},
+		}
+	}
+	newNetworkPolicy := func(port int32) *networkingv1.NetworkPolicy {
+		return &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-network-policy-no-op", Namespace: "default"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: labels},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(port))}},
+				}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		objectName string
+		object     client.Object
+		apply      func(changed bool) error
+	}{
+		{
+			name:       "Deployment",
+			objectName: "test-deployment-no-op",
+			object:     &appsv1.Deployment{},
+			apply: func(changed bool) error {
+				image := "quay.io/llamastack/distribution:v1"
+				if changed {
+					image = "quay.io/llamastack/distribution:v2"
+				}
+				_, err := ApplyDeployment(ctx, k8sClient, k8sClient.Scheme(), instance, newDeployment(image), logger)
+				return err
+			},
+		},
+		{
+			name:       "Service",
+			objectName: "test-service-no-op",
+			object:     &corev1.Service{},
+			apply: func(changed bool) error {
+				port := int32(8321)
+				if changed {
+					port = 8322
+				}
+				return ApplyService(ctx, k8sClient, k8sClient.Scheme(), instance, newService(port), logger)
+			},
+		},
+		{
+			name:       "NetworkPolicy",
+			objectName: "test-network-policy-no-op",
+			object:     &networkingv1.NetworkPolicy{},
+			apply: func(changed bool) error {
+				port := int32(8321)
+				if changed {
+					port = 8322
+				}
+				return ApplyNetworkPolicy(ctx, k8sClient, k8sClient.Scheme(), instance, newNetworkPolicy(port), logger)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			key := types.NamespacedName{Name: tc.objectName, Namespace: "default"}
+
+			require.NoError(t, tc.apply(false))
+			require.NoError(t, k8sClient.Get(ctx, key, tc.object))
+			createdVersion := tc.object.GetResourceVersion()
+
+			// applying the same desired state again must not write to the API server
+			require.NoError(t, tc.apply(false))
+			require.NoError(t, k8sClient.Get(ctx, key, tc.object))
+			require.Equal(t, createdVersion, tc.object.GetResourceVersion(), "no-op apply should not update the object")
+
+			// a changed desired state is still applied
+			require.NoError(t, tc.apply(true))
+			require.NoError(t, k8sClient.Get(ctx, key, tc.object))
+			require.NotEqual(t, createdVersion, tc.object.GetResourceVersion(), "changed desired state should update the object")
+		})
+	}
+}
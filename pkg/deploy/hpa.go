@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyHorizontalPodAutoscaler creates or updates a HorizontalPodAutoscaler built by the controller.
+func ApplyHorizontalPodAutoscaler(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, hpa *autoscalingv2.HorizontalPodAutoscaler, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, hpa, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(hpa); err != nil {
+		return err
+	}
+
+	existing := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(hpa), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, hpa); err != nil {
+				return fmt.Errorf("failed to create HorizontalPodAutoscaler: %w", err)
+			}
+			log.Info("Created HorizontalPodAutoscaler", "name", hpa.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get HorizontalPodAutoscaler: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(hpa, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare HorizontalPodAutoscaler: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("HorizontalPodAutoscaler is up to date, skipping update", "name", hpa.Name)
+		return nil
+	}
+
+	hpa.ResourceVersion = existing.ResourceVersion
+	if err := c.Update(ctx, hpa); err != nil {
+		return fmt.Errorf("failed to update HorizontalPodAutoscaler: %w", err)
+	}
+	log.Info("Updated HorizontalPodAutoscaler", "name", hpa.Name)
+	return nil
+}
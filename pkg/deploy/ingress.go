@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyIngress creates or updates an Ingress built by the controller.
+func ApplyIngress(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, ingress *networkingv1.Ingress, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, ingress, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(ingress); err != nil {
+		return err
+	}
+
+	existing := &networkingv1.Ingress{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(ingress), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, ingress); err != nil {
+				return fmt.Errorf("failed to create Ingress: %w", err)
+			}
+			log.Info("Created Ingress", "name", ingress.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get Ingress: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(ingress, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare Ingress: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("Ingress is up to date, skipping update", "name", ingress.Name)
+		return nil
+	}
+
+	ingress.ResourceVersion = existing.ResourceVersion
+	if err := c.Update(ctx, ingress); err != nil {
+		return fmt.Errorf("failed to update Ingress: %w", err)
+	}
+	log.Info("Updated Ingress", "name", ingress.Name)
+	return nil
+}
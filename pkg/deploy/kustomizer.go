@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"slices"
 
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
 	"github.com/llamastack/llama-stack-k8s-operator/pkg/deploy/plugins"
+	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,6 +100,11 @@ func manageResource(
 		}
 	}
 
+	// Record the desired state before any server-assigned field is added
+	if err := compare.SetDesiredStateHash(u); err != nil {
+		return err
+	}
+
 	kGvk := res.GetGvk()
 	gvk := schema.GroupVersionKind{
 		Group:   kGvk.Group,
@@ -113,9 +120,38 @@ func manageResource(
 		}
 		return createResource(ctx, cli, u, ownerInstance, scheme, gvk)
 	}
+	if isHeadlessChange(u, found) && isOwnedBy(found, ownerInstance) {
+		// The cluster IP of a Service is immutable, the Service is replaced to switch to or from headless
+		log.FromContext(ctx).Info("Replacing Service to change its cluster IP", "name", found.GetName())
+		if err := cli.Delete(ctx, found); err != nil && !k8serr.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Service: %w", err)
+		}
+		return createResource(ctx, cli, u, ownerInstance, scheme, gvk)
+	}
 	return patchResource(ctx, cli, u, found, ownerInstance)
 }
 
+// isHeadlessChange returns true when the desired Service is headless and the live one is not, or the other way
+// around.
+func isHeadlessChange(desired, existing *unstructured.Unstructured) bool {
+	if desired.GetKind() != "Service" {
+		return false
+	}
+	desiredClusterIP, _, _ := unstructured.NestedString(desired.Object, "spec", "clusterIP")
+	existingClusterIP, _, _ := unstructured.NestedString(existing.Object, "spec", "clusterIP")
+	return (desiredClusterIP == corev1.ClusterIPNone) != (existingClusterIP == corev1.ClusterIPNone)
+}
+
+// isOwnedBy returns true when the resource has an owner reference to the instance.
+func isOwnedBy(obj *unstructured.Unstructured, ownerInstance *llamav1alpha1.LlamaStackDistribution) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == ownerInstance.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
 // createResource creates a new resource, setting an owner reference only if it's namespace-scoped.
 func createResource(
 	ctx context.Context,
@@ -154,14 +190,7 @@ func patchResource(ctx context.Context, cli client.Client, desired, existing *un
 
 	// Critical safety check to prevent the operator from "stealing" or
 	// overwriting a resource that was created by another user or controller.
-	isOwner := false
-	for _, ref := range existing.GetOwnerReferences() {
-		if ref.UID == ownerInstance.GetUID() {
-			isOwner = true
-			break
-		}
-	}
-	if !isOwner {
+	if !isOwnedBy(existing, ownerInstance) {
 		logger.Info("Skipping resource not owned by this instance",
 			"kind", existing.GetKind(),
 			"name", existing.GetName(),
@@ -170,16 +199,28 @@ func patchResource(ctx context.Context, cli client.Client, desired, existing *un
 	}
 
 	if existing.GetKind() == "PersistentVolumeClaim" {
-		logger.Info("Skipping PVC patch - PVCs are immutable after creation",
+		logger.V(1).Info("Skipping PVC spec patch - PVCs are immutable after creation",
 			"name", existing.GetName(),
 			"namespace", existing.GetNamespace())
-		return nil
+		return patchMetadata(ctx, cli, desired, existing, ownerInstance)
 	} else if existing.GetKind() == "Service" {
 		if err := compare.CheckAndLogServiceChanges(ctx, cli, desired); err != nil {
 			return fmt.Errorf("failed to validate resource mutations while patching: %w", err)
 		}
 	}
 
+	upToDate, err := compare.IsUpToDate(desired, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare resource: %w", err)
+	}
+	if upToDate {
+		logger.V(1).Info("Resource is up to date, skipping patch",
+			"kind", existing.GetKind(),
+			"name", existing.GetName(),
+			"namespace", existing.GetNamespace())
+		return nil
+	}
+
 	data, err := json.Marshal(desired)
 	if err != nil {
 		return fmt.Errorf("failed to marshal desired state: %w", err)
@@ -194,6 +235,45 @@ func patchResource(ctx context.Context, cli client.Client, desired, existing *un
 	)
 }
 
+// patchMetadata applies the labels and annotations of a resource whose spec isn't patched, when the live
+// resource misses some of them.
+func patchMetadata(ctx context.Context, cli client.Client, desired, existing *unstructured.Unstructured,
+	ownerInstance *llamav1alpha1.LlamaStackDistribution) error {
+	annotations := maps.Clone(desired.GetAnnotations())
+	delete(annotations, compare.DesiredStateHashAnnotation)
+	if containsAll(existing.GetLabels(), desired.GetLabels()) && containsAll(existing.GetAnnotations(), annotations) {
+		return nil
+	}
+
+	metadata := &unstructured.Unstructured{}
+	metadata.SetGroupVersionKind(desired.GroupVersionKind())
+	metadata.SetName(desired.GetName())
+	metadata.SetNamespace(desired.GetNamespace())
+	metadata.SetLabels(desired.GetLabels())
+	metadata.SetAnnotations(annotations)
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal desired metadata: %w", err)
+	}
+	return cli.Patch(
+		ctx,
+		existing,
+		client.RawPatch(k8stypes.ApplyPatchType, data),
+		client.ForceOwnership,
+		client.FieldOwner(ownerInstance.GetName()),
+	)
+}
+
+// containsAll returns true when every entry of want is set to the same value in got.
+func containsAll(got, want map[string]string) bool {
+	for key, value := range want {
+		if current, ok := got[key]; !ok || current != value {
+			return false
+		}
+	}
+	return true
+}
+
 // applyPlugins runs all Go-based transformations on the resource map.
 func applyPlugins(resMap *resmap.ResMap, ownerInstance *llamav1alpha1.LlamaStackDistribution) error {
 	namePrefixPlugin := plugins.CreateNamePrefixPlugin(plugins.NamePrefixConfig{
@@ -211,6 +291,21 @@ func applyPlugins(resMap *resmap.ResMap, ownerInstance *llamav1alpha1.LlamaStack
 		return fmt.Errorf("failed to apply namespace setter plugin: %w", err)
 	}
 
+	// Record the owning instance on every resource so orphans can be identified,
+	// including cluster-scoped resources that have no owner reference.
+	labelsPlugin := plugins.CreateLabelsPlugin(MergeLabels(GetResourceLabels(ownerInstance), map[string]string{
+		InstanceLabelKey:          ownerInstance.GetName(),
+		InstanceNamespaceLabelKey: ownerInstance.GetNamespace(),
+	}))
+	if err := labelsPlugin.Transform(*resMap); err != nil {
+		return fmt.Errorf("failed to apply labels plugin: %w", err)
+	}
+
+	annotationsPlugin := plugins.CreateAnnotationsPlugin(GetResourceAnnotations(ownerInstance))
+	if err := annotationsPlugin.Transform(*resMap); err != nil {
+		return fmt.Errorf("failed to apply annotations plugin: %w", err)
+	}
+
 	fieldTransformerPlugin := plugins.CreateFieldMutator(plugins.FieldMutatorConfig{
 		Mappings: []plugins.FieldMapping{
 			{
@@ -220,6 +315,19 @@ func applyPlugins(resMap *resmap.ResMap, ownerInstance *llamav1alpha1.LlamaStack
 				TargetKind:        "PersistentVolumeClaim",
 				CreateIfNotExists: true,
 			},
+			{
+				SourceValue:       getStorageClassName(ownerInstance),
+				TargetField:       "/spec/storageClassName",
+				TargetKind:        "PersistentVolumeClaim",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getStorageAccessModes(ownerInstance),
+				DefaultValue:      []any{string(llamav1alpha1.DefaultStorageAccessMode)},
+				TargetField:       "/spec/accessModes",
+				TargetKind:        "PersistentVolumeClaim",
+				CreateIfNotExists: true,
+			},
 			{
 				SourceValue:       ownerInstance.GetNamespace(),
 				TargetField:       "/subjects/0/namespace",
@@ -246,6 +354,54 @@ func applyPlugins(resMap *resmap.ResMap, ownerInstance *llamav1alpha1.LlamaStack
 				TargetKind:        "Service",
 				CreateIfNotExists: true,
 			},
+			{
+				SourceValue:       getServiceType(ownerInstance),
+				TargetField:       "/spec/type",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getServiceLoadBalancerClass(ownerInstance),
+				TargetField:       "/spec/loadBalancerClass",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getServiceExternalTrafficPolicy(ownerInstance),
+				TargetField:       "/spec/externalTrafficPolicy",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getServiceClusterIP(ownerInstance),
+				TargetField:       "/spec/clusterIP",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getServicePublishNotReadyAddresses(ownerInstance),
+				TargetField:       "/spec/publishNotReadyAddresses",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getServiceSessionAffinity(ownerInstance),
+				TargetField:       "/spec/sessionAffinity",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getServiceSessionAffinityTimeout(ownerInstance),
+				TargetField:       "/spec/sessionAffinityConfig/clientIP/timeoutSeconds",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
+			{
+				SourceValue:       getPublicServicePorts(ownerInstance),
+				TargetField:       "/spec/ports",
+				TargetKind:        "Service",
+				CreateIfNotExists: true,
+			},
 			{
 				SourceValue:       nil,
 				DefaultValue:      llamav1alpha1.DefaultLabelValue,
@@ -278,6 +434,26 @@ func getStorageSize(instance *llamav1alpha1.LlamaStackDistribution) string {
 	return ""
 }
 
+// getStorageClassName returns the StorageClass of the PVC, or an empty string to use the cluster default.
+func getStorageClassName(instance *llamav1alpha1.LlamaStackDistribution) string {
+	if instance.Spec.Server.Storage != nil && instance.Spec.Server.Storage.StorageClassName != nil {
+		return *instance.Spec.Server.Storage.StorageClassName
+	}
+	return ""
+}
+
+// getStorageAccessModes returns the access modes of the PVC, or nil to use the default access mode.
+func getStorageAccessModes(instance *llamav1alpha1.LlamaStackDistribution) []any {
+	if instance.Spec.Server.Storage == nil {
+		return nil
+	}
+	accessModes := make([]any, 0, len(instance.Spec.Server.Storage.AccessModes))
+	for _, accessMode := range instance.Spec.Server.Storage.AccessModes {
+		accessModes = append(accessModes, string(accessMode))
+	}
+	return accessModes
+}
+
 // getServicePort returns the service port or nil if not specified.
 func getServicePort(instance *llamav1alpha1.LlamaStackDistribution) any {
 	if instance.Spec.Server.ContainerSpec.Port != 0 {
@@ -287,10 +463,116 @@ func getServicePort(instance *llamav1alpha1.LlamaStackDistribution) any {
 	return nil
 }
 
+// getServiceType returns the type of the server Service, or an empty string to keep ClusterIP.
+func getServiceType(instance *llamav1alpha1.LlamaStackDistribution) string {
+	if instance.Spec.Server.Service == nil {
+		return ""
+	}
+	return string(instance.Spec.Server.Service.Type)
+}
+
+// getServiceLoadBalancerClass returns the load balancer class of a LoadBalancer server Service, or an empty
+// string to use the default load balancer.
+func getServiceLoadBalancerClass(instance *llamav1alpha1.LlamaStackDistribution) string {
+	service := instance.Spec.Server.Service
+	if service == nil || service.Type != corev1.ServiceTypeLoadBalancer || service.LoadBalancerClass == nil {
+		return ""
+	}
+	return *service.LoadBalancerClass
+}
+
+// getServiceExternalTrafficPolicy returns the external traffic policy of a NodePort or LoadBalancer server
+// Service, or an empty string to use the default policy.
+func getServiceExternalTrafficPolicy(instance *llamav1alpha1.LlamaStackDistribution) string {
+	service := instance.Spec.Server.Service
+	if service == nil || (service.Type != corev1.ServiceTypeNodePort && service.Type != corev1.ServiceTypeLoadBalancer) {
+		return ""
+	}
+	return string(service.ExternalTrafficPolicy)
+}
+
+// getServiceClusterIP returns None for a headless server Service, or an empty string to allocate a cluster IP.
+func getServiceClusterIP(instance *llamav1alpha1.LlamaStackDistribution) string {
+	service := instance.Spec.Server.Service
+	if service == nil || !service.Headless {
+		return ""
+	}
+	return corev1.ClusterIPNone
+}
+
+// getServicePublishNotReadyAddresses returns true when the server Service publishes the addresses of the pods
+// that aren't ready, or nil to keep the default.
+func getServicePublishNotReadyAddresses(instance *llamav1alpha1.LlamaStackDistribution) any {
+	service := instance.Spec.Server.Service
+	if service == nil || !service.PublishNotReadyAddresses {
+		return nil
+	}
+	return true
+}
+
+// getServiceSessionAffinity returns the session affinity of the server Service, or an empty string to keep None.
+func getServiceSessionAffinity(instance *llamav1alpha1.LlamaStackDistribution) string {
+	if instance.Spec.Server.Service == nil {
+		return ""
+	}
+	return string(instance.Spec.Server.Service.SessionAffinity)
+}
+
+// getServiceSessionAffinityTimeout returns the client IP session affinity timeout of the server Service, or nil
+// to use the default timeout.
+func getServiceSessionAffinityTimeout(instance *llamav1alpha1.LlamaStackDistribution) any {
+	service := instance.Spec.Server.Service
+	if service == nil || service.SessionAffinity != corev1.ServiceAffinityClientIP || service.SessionAffinityTimeoutSeconds == nil {
+		return nil
+	}
+	return *service.SessionAffinityTimeoutSeconds
+}
+
+// getPublicServicePorts returns all the ports of the server Service when additional public ports
+// are declared, or nil to keep the single server port.
+func getPublicServicePorts(instance *llamav1alpha1.LlamaStackDistribution) any {
+	publicPorts := instance.GetPortsByExposure(llamav1alpha1.PortExposurePublic)
+	if len(publicPorts) == 0 {
+		return nil
+	}
+
+	serverPort := GetServicePort(instance)
+	ports := []any{
+		map[string]any{
+			"name":       llamav1alpha1.DefaultServicePortName,
+			"port":       serverPort,
+			"targetPort": serverPort,
+			"protocol":   "TCP",
+		},
+	}
+	for _, port := range publicPorts {
+		ports = append(ports, map[string]any{
+			"name":       port.Name,
+			"port":       port.Port,
+			"targetPort": port.Port,
+			"protocol":   string(port.GetProtocol()),
+		})
+	}
+	return ports
+}
+
 func FilterExcludeKinds(resMap *resmap.ResMap, kindsToExclude []string) (*resmap.ResMap, error) {
+	return filterKinds(resMap, func(kind string) bool {
+		return !slices.Contains(kindsToExclude, kind)
+	})
+}
+
+// FilterIncludeKinds returns the resources of the given kinds only.
+func FilterIncludeKinds(resMap *resmap.ResMap, kindsToInclude []string) (*resmap.ResMap, error) {
+	return filterKinds(resMap, func(kind string) bool {
+		return slices.Contains(kindsToInclude, kind)
+	})
+}
+
+func filterKinds(resMap *resmap.ResMap, keep func(kind string) bool) (*resmap.ResMap, error) {
 	filteredResMap := resmap.New()
 	for _, res := range (*resMap).Resources() {
-		if !slices.Contains(kindsToExclude, res.GetKind()) {
+		if keep(res.GetKind()) {
 			if err := filteredResMap.Append(res); err != nil {
 				return nil, fmt.Errorf("failed to append resource while filtering %s/%s: %w", res.GetKind(), res.GetName(), err)
 			}
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/kustomize/api/resmap"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
@@ -118,6 +119,96 @@ spec:
 		require.Equal(t, "10Gi", storage, "storage size should be updated to the default")
 	})
 
+	t.Run("should render the storage class and access modes of the PVC", func(t *testing.T) {
+		fsys := filesys.MakeFsInMemory()
+		require.NoError(t, fsys.MkdirAll(manifestBasePath))
+		require.NoError(t, fsys.WriteFile(filepath.Join(manifestBasePath, "kustomization.yaml"), []byte(`
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - pvc.yaml
+`)))
+		require.NoError(t, fsys.WriteFile(filepath.Join(manifestBasePath, "pvc.yaml"), []byte(`
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: pvc
+spec:
+  accessModes:
+    - ReadWriteOnce
+  resources:
+    requests:
+      storage: 5Gi
+`)))
+
+		renderPVC := func(storage *llamav1alpha1.StorageSpec) map[string]any {
+			owner := &llamav1alpha1.LlamaStackDistribution{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-render-ns"},
+				Spec: llamav1alpha1.LlamaStackDistributionSpec{
+					Server: llamav1alpha1.ServerSpec{Storage: storage},
+				},
+			}
+			resMap, err := RenderManifest(fsys, manifestBasePath, owner)
+			require.NoError(t, err)
+			finalMap, err := (*resMap).Resources()[0].Map()
+			require.NoError(t, err)
+			return finalMap
+		}
+
+		// the defaults keep the cluster default StorageClass and ReadWriteOnce
+		pvc := renderPVC(&llamav1alpha1.StorageSpec{})
+		_, found, err := unstructured.NestedString(pvc, "spec", "storageClassName")
+		require.NoError(t, err)
+		assert.False(t, found, "storageClassName should be left to the cluster default")
+		accessModes, _, err := unstructured.NestedStringSlice(pvc, "spec", "accessModes")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ReadWriteOnce"}, accessModes)
+
+		pvc = renderPVC(&llamav1alpha1.StorageSpec{
+			StorageClassName: ptr.To("shared-fs"),
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+		})
+		storageClassName, _, err := unstructured.NestedString(pvc, "spec", "storageClassName")
+		require.NoError(t, err)
+		assert.Equal(t, "shared-fs", storageClassName)
+		accessModes, _, err = unstructured.NestedStringSlice(pvc, "spec", "accessModes")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ReadWriteMany"}, accessModes)
+	})
+
+	t.Run("should add the custom labels and annotations without overriding the operator ones", func(t *testing.T) {
+		fsys := filesys.MakeFsInMemory()
+		require.NoError(t, fsys.MkdirAll(manifestBasePath))
+		require.NoError(t, fsys.WriteFile(filepath.Join(manifestBasePath, "kustomization.yaml"), []byte(`
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - serviceaccount.yaml
+`)))
+		require.NoError(t, fsys.WriteFile(filepath.Join(manifestBasePath, "serviceaccount.yaml"), []byte(`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+  annotations:
+    openshift.io/scc: anyuid
+`)))
+		owner := &llamav1alpha1.LlamaStackDistribution{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-render-ns"},
+			Spec: llamav1alpha1.LlamaStackDistributionSpec{
+				Labels:      map[string]string{"cost-center": "ml", InstanceLabelKey: "other"},
+				Annotations: map[string]string{"sidecar.istio.io/inject": "true", "openshift.io/scc": "restricted"},
+			},
+		}
+
+		resMap, err := RenderManifest(fsys, manifestBasePath, owner)
+		require.NoError(t, err)
+		sa := (*resMap).Resources()[0]
+		assert.Equal(t, "ml", sa.GetLabels()["cost-center"])
+		assert.Equal(t, "test-instance", sa.GetLabels()[InstanceLabelKey])
+		assert.Equal(t, map[string]string{"sidecar.istio.io/inject": "true", "openshift.io/scc": "anyuid"}, sa.GetAnnotations())
+	})
+
 	t.Run("should fall back to the default directory if kustomization.yaml is missing", func(t *testing.T) {
 		// given a filesystem where the manifests are in a 'default' subdirectory
 		fsys := filesys.MakeFsInMemory()
@@ -418,6 +509,41 @@ func TestApplyResources(t *testing.T) {
 		// cleanup the clusterrole
 		require.NoError(t, k8sClient.Delete(context.Background(), createdClusterRole))
 	})
+
+	t.Run("skips patch when nothing changed", func(t *testing.T) {
+		// given a service created from the desired state
+		ctx, testNs, owner := setupApplyResourcesTest(t, "no-op-owner")
+		newResMap := func(state string) *resmap.ResMap {
+			desiredSvc := newTestResource(t, "v1", "Service", "my-service", testNs, map[string]any{
+				"ports": []any{
+					map[string]any{"name": "web", "protocol": "TCP", "port": 80, "targetPort": 8080},
+				},
+			})
+			desiredSvc.SetLabels(map[string]string{"state": state})
+			resMap := resmap.New()
+			require.NoError(t, resMap.Append(desiredSvc))
+			return &resMap
+		}
+		require.NoError(t, ApplyResources(ctx, k8sClient, scheme.Scheme, owner, newResMap("initial")))
+
+		service := &corev1.Service{}
+		serviceKey := types.NamespacedName{Name: "my-service", Namespace: testNs}
+		require.NoError(t, k8sClient.Get(ctx, serviceKey, service))
+		createdVersion := service.ResourceVersion
+
+		// when the same desired state is applied again
+		require.NoError(t, ApplyResources(ctx, k8sClient, scheme.Scheme, owner, newResMap("initial")))
+
+		// then the service is not written
+		require.NoError(t, k8sClient.Get(ctx, serviceKey, service))
+		require.Equal(t, createdVersion, service.ResourceVersion, "no-op apply should not patch the service")
+
+		// when the desired state changes, it is still applied
+		require.NoError(t, ApplyResources(ctx, k8sClient, scheme.Scheme, owner, newResMap("updated")))
+		require.NoError(t, k8sClient.Get(ctx, serviceKey, service))
+		require.NotEqual(t, createdVersion, service.ResourceVersion, "changed desired state should patch the service")
+		require.Equal(t, "updated", service.Labels["state"])
+	})
 }
 
 // TestApplyResources_PVCImmutability verifies that PVCs are not patched to maintain immutability.
@@ -480,6 +606,45 @@ func TestApplyResources_PVCImmutability(t *testing.T) {
 	require.Equal(t, expStorageSize, storageRequest.String(), "PVC storage spec should remain unchanged")
 }
 
+func TestApplyResources_HeadlessService(t *testing.T) {
+	// given
+	ctx, testNs, owner := setupApplyResourcesTest(t, "headless-service")
+
+	existingSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: testNs,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(owner, owner.GroupVersionKind()),
+			},
+		},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "web", Protocol: corev1.ProtocolTCP, Port: 80}}},
+	}
+	require.NoError(t, k8sClient.Create(ctx, existingSvc))
+	require.NotEqual(t, corev1.ClusterIPNone, existingSvc.Spec.ClusterIP)
+
+	desiredSvcSpec := map[string]any{
+		"clusterIP": corev1.ClusterIPNone,
+		"ports": []any{
+			map[string]any{"name": "web", "protocol": "TCP", "port": 80},
+		},
+	}
+	desiredSvc := newTestResource(t, "v1", "Service", "my-service", testNs, desiredSvcSpec)
+	resMap := resmap.New()
+	require.NoError(t, resMap.Append(desiredSvc))
+
+	// when
+	require.NoError(t, ApplyResources(ctx, k8sClient, scheme.Scheme, owner, &resMap))
+
+	// then
+	// the cluster IP is immutable, so the Service was replaced by a headless one
+	headlessSvc := &corev1.Service{}
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "my-service", Namespace: testNs}, headlessSvc))
+	require.Equal(t, corev1.ClusterIPNone, headlessSvc.Spec.ClusterIP)
+	require.NotEqual(t, existingSvc.UID, headlessSvc.UID, "the Service should have been recreated")
+	require.True(t, metav1.IsControlledBy(headlessSvc, owner), "the new Service should be owned by our instance")
+}
+
 // TestFilterExcludeKinds tests the filtering functionality.
 func TestFilterExcludeKinds(t *testing.T) {
 	t.Run("excludes specified kinds", func(t *testing.T) {
@@ -532,6 +697,19 @@ func TestFilterExcludeKinds(t *testing.T) {
 	})
 }
 
+func TestFilterIncludeKinds(t *testing.T) {
+	pvc := newTestResource(t, "v1", "PersistentVolumeClaim", "test-pvc", "test-ns", nil)
+	svc := newTestResource(t, "v1", "Service", "test-svc", "test-ns", nil)
+	resMap := resmap.New()
+	require.NoError(t, resMap.Append(pvc))
+	require.NoError(t, resMap.Append(svc))
+
+	filtered, err := FilterIncludeKinds(&resMap, []string{"PersistentVolumeClaim"})
+	require.NoError(t, err)
+	require.Equal(t, 1, (*filtered).Size())
+	require.Equal(t, "PersistentVolumeClaim", (*filtered).Resources()[0].GetKind())
+}
+
 func TestSetDefaultPort(t *testing.T) {
 	// arrange
 	// instance with no custom port and service with empty port values
@@ -0,0 +1,31 @@
+package deploy
+
+import "strings"
+
+const (
+	// LogCollectionConfigKey is the key used in the operator ConfigMap to store the log collection configuration.
+	LogCollectionConfigKey = "logCollection"
+	// containerNamePlaceholder is replaced by the server container name in log collection annotations.
+	containerNamePlaceholder = "{container}"
+)
+
+// LogCollectionConfig holds the pod annotations read by the log collector of the cluster, e.g. to select
+// parsing or multiline rules, set on the pods of LlamaStackDistributions that enable log collection.
+type LogCollectionConfig struct {
+	// Annotations are the annotations set on the server pods. Container-level annotations can refer
+	// to the server container with {container} in their key or value.
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// PodAnnotations returns the log collection annotations of the pods running the named server container.
+func (c *LogCollectionConfig) PodAnnotations(containerName string) map[string]string {
+	if c == nil {
+		return nil
+	}
+	annotations := make(map[string]string, len(c.Annotations))
+	for key, value := range c.Annotations {
+		key = strings.ReplaceAll(key, containerNamePlaceholder, containerName)
+		annotations[key] = strings.ReplaceAll(value, containerNamePlaceholder, containerName)
+	}
+	return annotations
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
 	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +14,43 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// NetworkPolicyConfigKey is the key used in the operator ConfigMap to store the NetworkPolicy configuration.
+	NetworkPolicyConfigKey = "networkPolicy"
+	// DefaultNamespaceLabelKey is the label identifying namespaces by name, set by Kubernetes 1.21 and later.
+	DefaultNamespaceLabelKey = "kubernetes.io/metadata.name"
+)
+
+// NetworkPolicyConfig customizes the NetworkPolicies created for LlamaStackDistributions.
+type NetworkPolicyConfig struct {
+	// OperatorNamespaceLabelKey is the namespace label matched to allow ingress from the operator namespace.
+	// Defaults to kubernetes.io/metadata.name.
+	OperatorNamespaceLabelKey string `yaml:"operatorNamespaceLabelKey,omitempty"`
+	// OperatorNamespaceLabelValue is the value of OperatorNamespaceLabelKey on the operator namespace.
+	// Defaults to the operator namespace name.
+	OperatorNamespaceLabelValue string `yaml:"operatorNamespaceLabelValue,omitempty"`
+	// LabelOperatorNamespace makes the operator add the selected label to its namespace when it is missing.
+	// Otherwise a missing label is only reported, since the NetworkPolicy then blocks the health checks.
+	LabelOperatorNamespace bool `yaml:"labelOperatorNamespace,omitempty"`
+}
+
+// ShouldLabelOperatorNamespace returns true when the operator labels its own namespace.
+func (c *NetworkPolicyConfig) ShouldLabelOperatorNamespace() bool {
+	return c != nil && c.LabelOperatorNamespace
+}
+
+// OperatorNamespaceSelector returns the labels matching the operator namespace in ingress rules.
+func (c *NetworkPolicyConfig) OperatorNamespaceSelector(operatorNamespace string) map[string]string {
+	key, value := DefaultNamespaceLabelKey, operatorNamespace
+	if c != nil && c.OperatorNamespaceLabelKey != "" {
+		key = c.OperatorNamespaceLabelKey
+	}
+	if c != nil && c.OperatorNamespaceLabelValue != "" {
+		value = c.OperatorNamespaceLabelValue
+	}
+	return map[string]string{key: value}
+}
+
 // ApplyNetworkPolicy creates or updates a NetworkPolicy.
 func ApplyNetworkPolicy(ctx context.Context, c client.Client, scheme *runtime.Scheme,
 	instance *llamav1alpha1.LlamaStackDistribution, networkPolicy *networkingv1.NetworkPolicy, log logr.Logger) error {
@@ -20,6 +58,9 @@ func ApplyNetworkPolicy(ctx context.Context, c client.Client, scheme *runtime.Sc
 	if err := ctrl.SetControllerReference(instance, networkPolicy, scheme); err != nil {
 		return fmt.Errorf("failed to set controller reference: %w", err)
 	}
+	if err := compare.SetDesiredStateHash(networkPolicy); err != nil {
+		return err
+	}
 
 	// Check if the NetworkPolicy already exists
 	existing := &networkingv1.NetworkPolicy{}
@@ -36,6 +77,15 @@ func ApplyNetworkPolicy(ctx context.Context, c client.Client, scheme *runtime.Sc
 		return fmt.Errorf("failed to get NetworkPolicy: %w", err)
 	}
 
+	upToDate, err := compare.IsUpToDate(networkPolicy, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare NetworkPolicy: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("NetworkPolicy is up to date, skipping update", "name", networkPolicy.Name)
+		return nil
+	}
+
 	// Update the NetworkPolicy if it exists
 	networkPolicy.ResourceVersion = existing.ResourceVersion
 	if err := c.Update(ctx, networkPolicy); err != nil {
@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyPodDisruptionBudget creates or updates a PodDisruptionBudget built by the controller.
+func ApplyPodDisruptionBudget(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, pdb *policyv1.PodDisruptionBudget, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, pdb, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(pdb); err != nil {
+		return err
+	}
+
+	existing := &policyv1.PodDisruptionBudget{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(pdb), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, pdb); err != nil {
+				return fmt.Errorf("failed to create PodDisruptionBudget: %w", err)
+			}
+			log.Info("Created PodDisruptionBudget", "name", pdb.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get PodDisruptionBudget: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(pdb, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare PodDisruptionBudget: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("PodDisruptionBudget is up to date, skipping update", "name", pdb.Name)
+		return nil
+	}
+
+	pdb.ResourceVersion = existing.ResourceVersion
+	if err := c.Update(ctx, pdb); err != nil {
+		return fmt.Errorf("failed to update PodDisruptionBudget: %w", err)
+	}
+	log.Info("Updated PodDisruptionBudget", "name", pdb.Name)
+	return nil
+}
@@ -0,0 +1,45 @@
+package plugins
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+// CreateAnnotationsPlugin creates a transformer plugin that adds annotations to the metadata of every resource.
+// Existing annotations with the same key, set by the manifests, are kept.
+func CreateAnnotationsPlugin(annotations map[string]string) *annotationsTransformer {
+	return &annotationsTransformer{annotations: annotations}
+}
+
+type annotationsTransformer struct {
+	annotations map[string]string
+}
+
+// Transform implements the TransformerPlugin interface.
+func (t *annotationsTransformer) Transform(m resmap.ResMap) error {
+	if len(t.annotations) == 0 {
+		return nil
+	}
+	for _, res := range m.Resources() {
+		annotations := res.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, len(t.annotations))
+		}
+		for key, value := range t.annotations {
+			if _, exists := annotations[key]; !exists {
+				annotations[key] = value
+			}
+		}
+		if err := res.SetAnnotations(annotations); err != nil {
+			return fmt.Errorf("failed to set annotations for resource %s/%s: %w", res.GetKind(), res.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// Config implements the TransformerPlugin interface.
+// This method is empty because the plugin's configuration is provided directly via `CreateAnnotationsPlugin`.
+func (t *annotationsTransformer) Config(h *resmap.PluginHelpers, _ []byte) error {
+	return nil
+}
@@ -0,0 +1,39 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+func TestAnnotationsPlugin(t *testing.T) {
+	t.Run("adds annotations without overriding the manifests", func(t *testing.T) {
+		resMap := resmap.New()
+		sa := newTestResource(t, "v1", "ServiceAccount", "my-sa", "my-ns", nil)
+		require.NoError(t, sa.SetAnnotations(map[string]string{"openshift.io/scc": "anyuid"}))
+		require.NoError(t, resMap.Append(sa))
+		svc := newTestResource(t, "v1", "Service", "my-svc", "my-ns", nil)
+		require.NoError(t, resMap.Append(svc))
+
+		plugin := CreateAnnotationsPlugin(map[string]string{"openshift.io/scc": "restricted", "sidecar.istio.io/inject": "true"})
+		require.NoError(t, plugin.Transform(resMap))
+
+		resources := resMap.Resources()
+		require.Len(t, resources, 2)
+		assert.Equal(t, map[string]string{"openshift.io/scc": "anyuid", "sidecar.istio.io/inject": "true"}, resources[0].GetAnnotations())
+		assert.Equal(t, map[string]string{"openshift.io/scc": "restricted", "sidecar.istio.io/inject": "true"}, resources[1].GetAnnotations())
+	})
+
+	t.Run("no annotations leaves resources unchanged", func(t *testing.T) {
+		resMap := resmap.New()
+		svc := newTestResource(t, "v1", "Service", "my-svc", "my-ns", nil)
+		require.NoError(t, resMap.Append(svc))
+
+		plugin := CreateAnnotationsPlugin(nil)
+		require.NoError(t, plugin.Transform(resMap))
+
+		assert.Empty(t, resMap.Resources()[0].GetAnnotations())
+	})
+}
@@ -0,0 +1,42 @@
+package plugins
+
+import (
+	"fmt"
+	"maps"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+// CreateLabelsPlugin creates a transformer plugin that adds labels to the metadata of every resource.
+// Existing labels with the same key are overwritten.
+func CreateLabelsPlugin(labels map[string]string) *labelsTransformer {
+	return &labelsTransformer{labels: labels}
+}
+
+type labelsTransformer struct {
+	labels map[string]string
+}
+
+// Transform implements the TransformerPlugin interface.
+func (t *labelsTransformer) Transform(m resmap.ResMap) error {
+	if len(t.labels) == 0 {
+		return nil
+	}
+	for _, res := range m.Resources() {
+		labels := res.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string, len(t.labels))
+		}
+		maps.Copy(labels, t.labels)
+		if err := res.SetLabels(labels); err != nil {
+			return fmt.Errorf("failed to set labels for resource %s/%s: %w", res.GetKind(), res.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// Config implements the TransformerPlugin interface.
+// This method is empty because the plugin's configuration is provided directly via `CreateLabelsPlugin`.
+func (t *labelsTransformer) Config(h *resmap.PluginHelpers, _ []byte) error {
+	return nil
+}
@@ -0,0 +1,39 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+func TestLabelsPlugin(t *testing.T) {
+	t.Run("adds labels to all resources", func(t *testing.T) {
+		resMap := resmap.New()
+		svc := newTestResource(t, "v1", "Service", "my-svc", "my-ns", nil)
+		require.NoError(t, svc.SetLabels(map[string]string{"existing": "label", "app.kubernetes.io/instance": "old"}))
+		require.NoError(t, resMap.Append(svc))
+		crb := newTestResource(t, "rbac.authorization.k8s.io/v1", "ClusterRoleBinding", "my-crb", "", nil)
+		require.NoError(t, resMap.Append(crb))
+
+		plugin := CreateLabelsPlugin(map[string]string{"app.kubernetes.io/instance": "my-instance"})
+		require.NoError(t, plugin.Transform(resMap))
+
+		resources := resMap.Resources()
+		require.Len(t, resources, 2)
+		assert.Equal(t, map[string]string{"existing": "label", "app.kubernetes.io/instance": "my-instance"}, resources[0].GetLabels())
+		assert.Equal(t, map[string]string{"app.kubernetes.io/instance": "my-instance"}, resources[1].GetLabels())
+	})
+
+	t.Run("no labels leaves resources unchanged", func(t *testing.T) {
+		resMap := resmap.New()
+		svc := newTestResource(t, "v1", "Service", "my-svc", "my-ns", nil)
+		require.NoError(t, resMap.Append(svc))
+
+		plugin := CreateLabelsPlugin(nil)
+		require.NoError(t, plugin.Transform(resMap))
+
+		assert.Empty(t, resMap.Resources()[0].GetLabels())
+	})
+}
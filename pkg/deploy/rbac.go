@@ -0,0 +1,151 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyServiceAccount creates or updates a ServiceAccount built by the controller.
+func ApplyServiceAccount(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, serviceAccount *corev1.ServiceAccount, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, serviceAccount, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(serviceAccount); err != nil {
+		return err
+	}
+
+	existing := &corev1.ServiceAccount{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(serviceAccount), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, serviceAccount); err != nil {
+				return fmt.Errorf("failed to create ServiceAccount: %w", err)
+			}
+			log.Info("Created ServiceAccount", "name", serviceAccount.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get ServiceAccount: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(serviceAccount, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare ServiceAccount: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("ServiceAccount is up to date, skipping update", "name", serviceAccount.Name)
+		return nil
+	}
+
+	// The token and pull secrets are added by the cluster, e.g. the registry pull secret on OpenShift
+	serviceAccount.ResourceVersion = existing.ResourceVersion
+	serviceAccount.Secrets = existing.Secrets
+	serviceAccount.ImagePullSecrets = existing.ImagePullSecrets
+	if err := c.Update(ctx, serviceAccount); err != nil {
+		return fmt.Errorf("failed to update ServiceAccount: %w", err)
+	}
+	log.Info("Updated ServiceAccount", "name", serviceAccount.Name)
+	return nil
+}
+
+// ApplyRole creates or updates a Role built by the controller.
+func ApplyRole(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, role *rbacv1.Role, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, role, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(role); err != nil {
+		return err
+	}
+
+	existing := &rbacv1.Role{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(role), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, role); err != nil {
+				return fmt.Errorf("failed to create Role: %w", err)
+			}
+			log.Info("Created Role", "name", role.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get Role: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(role, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare Role: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("Role is up to date, skipping update", "name", role.Name)
+		return nil
+	}
+
+	role.ResourceVersion = existing.ResourceVersion
+	if err := c.Update(ctx, role); err != nil {
+		return fmt.Errorf("failed to update Role: %w", err)
+	}
+	log.Info("Updated Role", "name", role.Name)
+	return nil
+}
+
+// ApplyRoleBinding creates or updates a RoleBinding built by the controller. The role reference of a
+// RoleBinding is immutable, so the RoleBinding is recreated when it changes.
+func ApplyRoleBinding(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, roleBinding *rbacv1.RoleBinding, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, roleBinding, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(roleBinding); err != nil {
+		return err
+	}
+
+	existing := &rbacv1.RoleBinding{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(roleBinding), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, roleBinding); err != nil {
+				return fmt.Errorf("failed to create RoleBinding: %w", err)
+			}
+			log.Info("Created RoleBinding", "name", roleBinding.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get RoleBinding: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(roleBinding, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare RoleBinding: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("RoleBinding is up to date, skipping update", "name", roleBinding.Name)
+		return nil
+	}
+
+	if existing.RoleRef != roleBinding.RoleRef {
+		if err := c.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete RoleBinding: %w", err)
+		}
+		if err := c.Create(ctx, roleBinding); err != nil {
+			return fmt.Errorf("failed to recreate RoleBinding: %w", err)
+		}
+		log.Info("Recreated RoleBinding", "name", roleBinding.Name)
+		return nil
+	}
+
+	roleBinding.ResourceVersion = existing.ResourceVersion
+	if err := c.Update(ctx, roleBinding); err != nil {
+		return fmt.Errorf("failed to update RoleBinding: %w", err)
+	}
+	log.Info("Updated RoleBinding", "name", roleBinding.Name)
+	return nil
+}
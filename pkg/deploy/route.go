@@ -0,0 +1,22 @@
+package deploy
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RouteGVK is the kind of the OpenShift Routes. Routes are handled as unstructured objects, so that the
+// operator doesn't depend on the OpenShift API.
+var RouteGVK = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+
+// ApplyRoute creates or updates an OpenShift Route built by the controller.
+func ApplyRoute(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, route *unstructured.Unstructured, log logr.Logger) error {
+	return applyUnstructured(ctx, c, scheme, instance, route, RouteGVK, log)
+}
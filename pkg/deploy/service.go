@@ -0,0 +1,83 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyService creates or updates a Service built by the controller.
+func ApplyService(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, service *corev1.Service, log logr.Logger) error {
+	if err := ctrl.SetControllerReference(instance, service, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(service); err != nil {
+		return err
+	}
+
+	existing := &corev1.Service{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(service), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, service); err != nil {
+				return fmt.Errorf("failed to create Service: %w", err)
+			}
+			log.Info("Created Service", "name", service.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get Service: %w", err)
+	}
+
+	upToDate, err := compare.IsUpToDate(service, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare Service: %w", err)
+	}
+	if upToDate {
+		log.V(1).Info("Service is up to date, skipping update", "name", service.Name)
+		return nil
+	}
+
+	// The cluster IPs are allocated by the API server and are immutable
+	service.ResourceVersion = existing.ResourceVersion
+	service.Spec.ClusterIP = existing.Spec.ClusterIP
+	service.Spec.ClusterIPs = existing.Spec.ClusterIPs
+	if err := c.Update(ctx, service); err != nil {
+		return fmt.Errorf("failed to update Service: %w", err)
+	}
+	log.V(1).Info("Updated Service", "name", service.Name)
+	return nil
+}
+
+// DeleteServiceIfExists deletes a Service built by the controller once it is no longer needed.
+// Services not controlled by the instance are left untouched.
+func DeleteServiceIfExists(ctx context.Context, c client.Client, instance *llamav1alpha1.LlamaStackDistribution,
+	service *corev1.Service, log logr.Logger) error {
+	existing := &corev1.Service{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(service), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check Service existence: %w", err)
+	}
+	if !metav1.IsControlledBy(existing, instance) {
+		log.Info("Skipping deletion of Service not owned by this instance", "name", service.Name)
+		return nil
+	}
+
+	if err := c.Delete(ctx, existing); err != nil {
+		return fmt.Errorf("failed to delete Service: %w", err)
+	}
+	log.Info("Deleted Service", "name", service.Name)
+	return nil
+}
@@ -0,0 +1,22 @@
+package deploy
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceMonitorGVK is the kind of the Prometheus Operator ServiceMonitors. ServiceMonitors are handled as
+// unstructured objects, so that the operator doesn't depend on the Prometheus Operator API.
+var ServiceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+// ApplyServiceMonitor creates or updates a Prometheus Operator ServiceMonitor built by the controller.
+func ApplyServiceMonitor(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, serviceMonitor *unstructured.Unstructured, log logr.Logger) error {
+	return applyUnstructured(ctx, c, scheme, instance, serviceMonitor, ServiceMonitorGVK, log)
+}
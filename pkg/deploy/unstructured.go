@@ -0,0 +1,58 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/compare"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// applyUnstructured creates or updates an unstructured object of an optional API built by the controller.
+func applyUnstructured(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *llamav1alpha1.LlamaStackDistribution, obj *unstructured.Unstructured, gvk schema.GroupVersionKind, log logr.Logger) error {
+	obj.SetGroupVersionKind(gvk)
+	if err := ctrl.SetControllerReference(instance, obj, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := compare.SetDesiredStateHash(obj); err != nil {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err = c.Create(ctx, obj); err != nil {
+				return fmt.Errorf("failed to create %s: %w", gvk.Kind, err)
+			}
+			log.Info("Created "+gvk.Kind, "name", obj.GetName())
+			return nil
+		}
+		return fmt.Errorf("failed to get %s: %w", gvk.Kind, err)
+	}
+
+	upToDate, err := compare.IsUpToDate(obj, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compare %s: %w", gvk.Kind, err)
+	}
+	if upToDate {
+		log.V(1).Info(gvk.Kind+" is up to date, skipping update", "name", obj.GetName())
+		return nil
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	if err := c.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to update %s: %w", gvk.Kind, err)
+	}
+	log.Info("Updated "+gvk.Kind, "name", obj.GetName())
+	return nil
+}
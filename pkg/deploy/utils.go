@@ -1,12 +1,88 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"os"
+	"reflect"
 
+	"github.com/go-logr/logr"
 	llamav1alpha1 "github.com/llamastack/llama-stack-k8s-operator/api/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// ManagedByLabelKey and ManagedByLabelValue mark the resources rendered from the operator manifests.
+	ManagedByLabelKey   = "app.kubernetes.io/managed-by"
+	ManagedByLabelValue = "llama-stack-operator"
+	// InstanceLabelKey identifies the LlamaStackDistribution a resource belongs to.
+	InstanceLabelKey = "app.kubernetes.io/instance"
+	// InstanceNamespaceLabelKey identifies the namespace of the owning LlamaStackDistribution.
+	// It is needed for cluster-scoped resources, which can't carry an owner reference.
+	InstanceNamespaceLabelKey = "llamastack.io/instance-namespace"
+	// NameLabelKey, ComponentLabelKey, PartOfLabelKey and VersionLabelKey complete the Kubernetes
+	// recommended labels set on the resources of a distribution.
+	NameLabelKey      = "app.kubernetes.io/name"
+	ComponentLabelKey = "app.kubernetes.io/component"
+	PartOfLabelKey    = "app.kubernetes.io/part-of"
+	VersionLabelKey   = "app.kubernetes.io/version"
+	// ComponentLabelValue is the component of the resources running the LlamaStack server.
+	ComponentLabelValue = "server"
+	// customDistributionName names the distributions running a custom image.
+	customDistributionName = "custom"
+)
+
+// GetPodLabels returns the recommended labels of the server pods: the distribution name, component and
+// managing operator. The version is left out so that upgrading the operator doesn't restart the pods, and the
+// part-of label so that the server pods aren't admitted as clients by the network policies of other servers.
+func GetPodLabels(instance *llamav1alpha1.LlamaStackDistribution) map[string]string {
+	name := instance.Spec.Server.Distribution.Name
+	if name == "" {
+		name = customDistributionName
+	}
+	return map[string]string{
+		NameLabelKey:      name,
+		ComponentLabelKey: ComponentLabelValue,
+		ManagedByLabelKey: ManagedByLabelValue,
+	}
+}
+
+// GetRecommendedLabels returns the recommended labels of the resources of a distribution, including the
+// operator version when it is a valid label value.
+func GetRecommendedLabels(instance *llamav1alpha1.LlamaStackDistribution) map[string]string {
+	labels := GetPodLabels(instance)
+	labels[PartOfLabelKey] = llamav1alpha1.DefaultLabelValue
+	if version := os.Getenv("OPERATOR_VERSION"); version != "" && len(validation.IsValidLabelValue(version)) == 0 {
+		labels[VersionLabelKey] = version
+	}
+	return labels
+}
+
+// GetResourceLabels returns the labels of the resources of a distribution: the custom labels of the spec
+// and the recommended labels, which the custom labels can't override.
+func GetResourceLabels(instance *llamav1alpha1.LlamaStackDistribution) map[string]string {
+	return MergeLabels(instance.Spec.Labels, GetRecommendedLabels(instance))
+}
+
+// GetResourceAnnotations returns the annotations of the resources of a distribution, the custom annotations of
+// the spec. The returned map is a copy, or nil when there are none.
+func GetResourceAnnotations(instance *llamav1alpha1.LlamaStackDistribution) map[string]string {
+	return maps.Clone(instance.Spec.Annotations)
+}
+
+// MergeLabels returns the union of the label sets, later sets taking precedence.
+func MergeLabels(labelSets ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, labels := range labelSets {
+		maps.Copy(merged, labels)
+	}
+	return merged
+}
+
 func GetOperatorNamespace() (string, error) {
 	operatorNS, exist := os.LookupEnv("OPERATOR_NAMESPACE")
 	if exist && operatorNS != "" {
@@ -25,6 +101,46 @@ func GetServicePort(instance *llamav1alpha1.LlamaStackDistribution) int32 {
 	return port
 }
 
+// GetServiceName returns the name of the Service exposing the public ports of the server: the Service
+// referenced by the service override, or else the one created by the operator.
 func GetServiceName(instance *llamav1alpha1.LlamaStackDistribution) string {
+	if override := instance.Spec.Server.ServiceOverride; override != nil {
+		return override.Name
+	}
+	return GetManagedServiceName(instance)
+}
+
+// GetManagedServiceName returns the name of the Service created by the operator for the public ports.
+func GetManagedServiceName(instance *llamav1alpha1.LlamaStackDistribution) string {
 	return fmt.Sprintf("%s-service", instance.Name)
 }
+
+func GetInternalServiceName(instance *llamav1alpha1.LlamaStackDistribution) string {
+	return fmt.Sprintf("%s-internal-service", instance.Name)
+}
+
+// DeleteIfControlled deletes an object built by the controller once it is no longer needed.
+// Objects not controlled by the instance are left untouched.
+func DeleteIfControlled(ctx context.Context, c client.Client, instance *llamav1alpha1.LlamaStackDistribution,
+	obj client.Object, log logr.Logger) error {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = reflect.TypeOf(obj).Elem().Name()
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check %s existence: %w", kind, err)
+	}
+	if !metav1.IsControlledBy(obj, instance) {
+		log.Info("Skipping deletion of object not owned by this instance", "kind", kind, "name", obj.GetName())
+		return nil
+	}
+
+	if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %s: %w", kind, err)
+	}
+	log.Info("Deleted object no longer needed", "kind", kind, "name", obj.GetName())
+	return nil
+}
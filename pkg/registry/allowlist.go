@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+)
+
+// AllowlistConfigKey is the key used in the operator ConfigMap to store the image allowlist.
+const AllowlistConfigKey = "imageAllowlist"
+
+// AllowlistConfig restricts the custom server images to trusted registries and repositories.
+type AllowlistConfig struct {
+	// Registries are the registry hosts whose images are allowed, e.g. "quay.io".
+	Registries []string `yaml:"registries,omitempty"`
+	// Repositories are the repositories whose images are allowed, with every repository below them,
+	// e.g. "quay.io/opendatahub" or "docker.io/llamastack/distribution-starter".
+	Repositories []string `yaml:"repositories,omitempty"`
+}
+
+// Validate checks that the allowlist has at least one entry.
+func (c *AllowlistConfig) Validate() error {
+	if len(c.Registries) == 0 && len(c.Repositories) == 0 {
+		return errors.New("failed to validate image allowlist: at least one registry or repository must be set")
+	}
+	return nil
+}
+
+// Allows reports whether the image is pulled from an allowed registry or repository.
+// A nil allowlist allows every image.
+func (c *AllowlistConfig) Allows(image string) bool {
+	if c == nil {
+		return true
+	}
+
+	host, repository := SplitImage(image)
+	for _, registry := range c.Registries {
+		if host == strings.TrimSuffix(registry, "/") {
+			return true
+		}
+	}
+
+	repository = stripReference(repository)
+	for _, allowed := range c.Repositories {
+		allowedHost, allowedRepository := SplitImage(strings.TrimSuffix(allowed, "/"))
+		if host != allowedHost {
+			continue
+		}
+		if repository == allowedRepository || strings.HasPrefix(repository, allowedRepository+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// stripReference removes the tag or digest of a repository.
+func stripReference(repository string) string {
+	repository, _, _ = strings.Cut(repository, "@")
+	repository, _ = splitTag(repository)
+	return repository
+}
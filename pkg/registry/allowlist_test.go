@@ -0,0 +1,74 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllows(t *testing.T) {
+	config := &registry.AllowlistConfig{
+		Registries:   []string{"registry.internal.example.com"},
+		Repositories: []string{"quay.io/opendatahub", "llamastack/distribution-starter"},
+	}
+
+	tests := []struct {
+		name     string
+		config   *registry.AllowlistConfig
+		image    string
+		expected bool
+	}{
+		{
+			name:     "nil config allows every image",
+			config:   nil,
+			image:    "evil.example.com/image:latest",
+			expected: true,
+		},
+		{
+			name:     "allowed registry",
+			config:   config,
+			image:    "registry.internal.example.com/team/llama-stack:1.0",
+			expected: true,
+		},
+		{
+			name:     "repository below an allowed repository",
+			config:   config,
+			image:    "quay.io/opendatahub/llama-stack@sha256:abc",
+			expected: true,
+		},
+		{
+			name:     "allowed repository with an implied registry",
+			config:   config,
+			image:    "docker.io/llamastack/distribution-starter:latest",
+			expected: true,
+		},
+		{
+			name:     "repository sharing a prefix with an allowed repository",
+			config:   config,
+			image:    "quay.io/opendatahub-fork/llama-stack:latest",
+			expected: false,
+		},
+		{
+			name:     "same repository on another registry",
+			config:   config,
+			image:    "ghcr.io/opendatahub/llama-stack:latest",
+			expected: false,
+		},
+		{
+			name:     "registry port is part of the host",
+			config:   config,
+			image:    "registry.internal.example.com:5000/team/llama-stack:1.0",
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.config.Allows(tc.image))
+		})
+	}
+
+	assert.Error(t, (&registry.AllowlistConfig{}).Validate())
+	assert.NoError(t, config.Validate())
+}
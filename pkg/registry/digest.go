@@ -0,0 +1,192 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// dockerHubRegistry is the host serving the distribution API of Docker Hub.
+	dockerHubRegistry = "registry-1.docker.io"
+	// defaultTag is the tag implied by image references without a tag or digest.
+	defaultTag = "latest"
+	// maxTokenResponseBytes bounds the token response read from an authorization server.
+	maxTokenResponseBytes = 64 << 10
+)
+
+// manifestMediaTypes are the manifest types accepted when resolving a digest. Multi-arch indexes come
+// first, so that the digest covers every platform of the image.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// DigestResolver resolves the tag of an image to the digest of its manifest.
+type DigestResolver interface {
+	ResolveDigest(ctx context.Context, image string) (string, error)
+}
+
+// DigestClient resolves digests with the registry API, authenticating anonymously with the bearer tokens
+// of public registries. Registries requiring credentials are not supported.
+type DigestClient struct {
+	httpClient *http.Client
+}
+
+// NewDigestClient creates a DigestClient sending its requests with the HTTP client.
+func NewDigestClient(httpClient *http.Client) *DigestClient {
+	return &DigestClient{httpClient: httpClient}
+}
+
+// ResolveDigest returns the digest the tag of the image points to, e.g. "sha256:...".
+func (c *DigestClient) ResolveDigest(ctx context.Context, image string) (string, error) {
+	if _, digest, found := strings.Cut(image, "@"); found {
+		return digest, nil
+	}
+
+	host, repository := SplitImage(image)
+	repository, tag := splitTag(repository)
+	if host == defaultRegistry {
+		host = dockerHubRegistry
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, tag)
+
+	resp, err := c.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the digest of image %s: %w", image, err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		token, err := c.fetchToken(ctx, challenge)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve the digest of image %s: %w", image, err)
+		}
+		if resp, err = c.headManifest(ctx, manifestURL, token); err != nil {
+			return "", fmt.Errorf("failed to resolve the digest of image %s: %w", image, err)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve the digest of image %s: registry returned %s", image, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("failed to resolve the digest of image %s: registry returned no sha256 digest", image)
+	}
+	return digest, nil
+}
+
+// headManifest sends a HEAD request for the manifest, with the bearer token if set.
+func (c *DigestClient) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// fetchToken requests an anonymous token from the authorization server of a Bearer challenge.
+func (c *DigestClient) fetchToken(ctx context.Context, challenge string) (string, error) {
+	params, ok := parseBearerChallenge(challenge)
+	if !ok || params["realm"] == "" {
+		return "", errors.New("registry requires an unsupported authentication")
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse token realm: %w", err)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch registry token: authorization server returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxTokenResponseBytes)).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", errors.New("failed to fetch registry token: authorization server returned no token")
+}
+
+// parseBearerChallenge parses the parameters of a WWW-Authenticate Bearer challenge, e.g.
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/busybox:pull".
+func parseBearerChallenge(challenge string) (map[string]string, bool) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(rest, ", ") {
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				return nil, false
+			}
+			params[key], rest = value[1:end+1], value[end+2:]
+		} else {
+			params[key], rest, _ = strings.Cut(value, ",")
+		}
+	}
+	return params, true
+}
+
+// splitTag splits a repository into its path and tag, defaulting to the latest tag.
+func splitTag(repository string) (string, string) {
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		return repository[:i], repository[i+1:]
+	}
+	return repository, defaultTag
+}
+
+// PinDigest returns the image reference pinned to the digest, keeping its tag for readability.
+func PinDigest(image, digest string) string {
+	image, _, _ = strings.Cut(image, "@")
+	return image + "@" + digest
+}
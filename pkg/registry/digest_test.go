@@ -0,0 +1,63 @@
+package registry_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestResolveDigest(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, "repository:org/image:pull", r.URL.Query().Get("scope"))
+			fmt.Fprint(w, `{"token":"anonymous"}`)
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/image:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/org/image/manifests/1.0":
+			assert.Equal(t, http.MethodHead, r.Method)
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json")
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		case r.URL.Path == "/v2/org/image/manifests/latest":
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := registry.NewDigestClient(server.Client())
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	digest, err := client.ResolveDigest(context.Background(), host+"/org/image:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest)
+
+	digest, err = client.ResolveDigest(context.Background(), host+"/org/image")
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest, "images without a tag resolve the latest tag")
+
+	digest, err = client.ResolveDigest(context.Background(), "quay.io/org/image@"+testDigest)
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest, "pinned images are not resolved")
+
+	_, err = client.ResolveDigest(context.Background(), host+"/org/missing:1.0")
+	require.ErrorContains(t, err, "404")
+}
+
+func TestPinDigest(t *testing.T) {
+	assert.Equal(t, "quay.io/org/image:1.0@"+testDigest, registry.PinDigest("quay.io/org/image:1.0", testDigest))
+	assert.Equal(t, "quay.io/org/image:1.0@"+testDigest, registry.PinDigest("quay.io/org/image:1.0@sha256:old", testDigest))
+}
@@ -0,0 +1,72 @@
+package registry
+
+import "strings"
+
+const (
+	// MirrorConfigKey is the key used in the operator ConfigMap to store the image registry mirror configuration.
+	MirrorConfigKey = "imageRegistryMirror"
+
+	// defaultRegistry is the registry implied by image references without a registry host.
+	defaultRegistry = "docker.io"
+	// defaultRepositoryPrefix is the repository implied by single-component Docker Hub references.
+	defaultRepositoryPrefix = "library/"
+)
+
+// MirrorConfig describes how image references are rewritten to point to a registry mirror.
+type MirrorConfig struct {
+	// Mirror replaces the registry host of every image that is not matched by a rule,
+	// e.g. "registry.internal.example.com/mirror". Leave empty to only apply the rules.
+	Mirror string `yaml:"mirror,omitempty"`
+	// Rules replace specific registry hosts. Rules take precedence over Mirror.
+	Rules []MirrorRule `yaml:"rules,omitempty"`
+}
+
+// MirrorRule maps an original registry host to a mirror location.
+type MirrorRule struct {
+	// Source is the registry host to replace, e.g. "quay.io" or "docker.io".
+	Source string `yaml:"source"`
+	// Mirror is the registry host and optional path prefix to use instead.
+	Mirror string `yaml:"mirror"`
+}
+
+// Rewrite returns the image reference pointing to the configured mirror.
+// Images that are not matched by a rule and without a default mirror are returned unchanged.
+func (c *MirrorConfig) Rewrite(image string) string {
+	if c == nil || image == "" {
+		return image
+	}
+
+	host, repository := SplitImage(image)
+	for _, rule := range c.Rules {
+		if rule.Source == host && rule.Mirror != "" {
+			return joinImage(rule.Mirror, repository)
+		}
+	}
+
+	if c.Mirror == "" {
+		return image
+	}
+	// Don't rewrite images that already point to the mirror
+	if strings.HasPrefix(image, strings.TrimSuffix(c.Mirror, "/")+"/") {
+		return image
+	}
+	return joinImage(c.Mirror, repository)
+}
+
+// SplitImage splits an image reference into its registry host and repository
+// (including the tag or digest). References without a registry host resolve to Docker Hub.
+func SplitImage(image string) (string, string) {
+	host, repository, found := strings.Cut(image, "/")
+	if !found {
+		return defaultRegistry, defaultRepositoryPrefix + image
+	}
+	// The first component is only a registry host when it looks like a hostname.
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return defaultRegistry, image
+	}
+	return host, repository
+}
+
+func joinImage(mirror, repository string) string {
+	return strings.TrimSuffix(mirror, "/") + "/" + repository
+}
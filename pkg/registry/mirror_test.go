@@ -0,0 +1,88 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/llamastack/llama-stack-k8s-operator/pkg/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image              string
+		expectedHost       string
+		expectedRepository string
+	}{
+		{"docker.io/llamastack/distribution-starter:latest", "docker.io", "llamastack/distribution-starter:latest"},
+		{"quay.io/org/image@sha256:abc", "quay.io", "org/image@sha256:abc"},
+		{"localhost:5000/image:tag", "localhost:5000", "image:tag"},
+		{"localhost/image", "localhost", "image"},
+		{"ollama/ollama:latest", "docker.io", "ollama/ollama:latest"},
+		{"busybox", "docker.io", "library/busybox"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			host, repository := registry.SplitImage(tc.image)
+			assert.Equal(t, tc.expectedHost, host)
+			assert.Equal(t, tc.expectedRepository, repository)
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *registry.MirrorConfig
+		image    string
+		expected string
+	}{
+		{
+			name:     "nil config leaves image unchanged",
+			config:   nil,
+			image:    "docker.io/llamastack/distribution-starter:latest",
+			expected: "docker.io/llamastack/distribution-starter:latest",
+		},
+		{
+			name:     "default mirror replaces registry host",
+			config:   &registry.MirrorConfig{Mirror: "registry.internal.example.com/mirror/"},
+			image:    "docker.io/llamastack/distribution-starter:latest",
+			expected: "registry.internal.example.com/mirror/llamastack/distribution-starter:latest",
+		},
+		{
+			name:     "default mirror applies to implied docker hub images",
+			config:   &registry.MirrorConfig{Mirror: "registry.internal.example.com"},
+			image:    "busybox:1.36",
+			expected: "registry.internal.example.com/library/busybox:1.36",
+		},
+		{
+			name: "rule takes precedence over default mirror",
+			config: &registry.MirrorConfig{
+				Mirror: "registry.internal.example.com/mirror",
+				Rules:  []registry.MirrorRule{{Source: "quay.io", Mirror: "registry.internal.example.com/quay"}},
+			},
+			image:    "quay.io/opendatahub/llama-stack@sha256:abc",
+			expected: "registry.internal.example.com/quay/opendatahub/llama-stack@sha256:abc",
+		},
+		{
+			name: "unmatched image without default mirror is unchanged",
+			config: &registry.MirrorConfig{
+				Rules: []registry.MirrorRule{{Source: "quay.io", Mirror: "registry.internal.example.com/quay"}},
+			},
+			image:    "docker.io/llamastack/distribution-starter:latest",
+			expected: "docker.io/llamastack/distribution-starter:latest",
+		},
+		{
+			name:     "image already on the mirror is unchanged",
+			config:   &registry.MirrorConfig{Mirror: "registry.internal.example.com/mirror"},
+			image:    "registry.internal.example.com/mirror/llamastack/distribution-starter:latest",
+			expected: "registry.internal.example.com/mirror/llamastack/distribution-starter:latest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.config.Rewrite(tc.image))
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -140,7 +141,7 @@ func testCRDeploymentUpdate(t *testing.T, distribution *v1alpha1.LlamaStackDistr
 	require.NoError(t, err)
 
 	// Update replicas
-	distribution.Spec.Replicas = 2
+	distribution.Spec.Replicas = ptr.To(int32(2))
 	err = TestEnv.Client.Update(TestEnv.Ctx, distribution)
 	require.NoError(t, err)
 